@@ -1,19 +1,54 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/google/uuid"
 
+	"github.com/bunnyholes/pokerhole/client/internal/core/application/service"
+	"github.com/bunnyholes/pokerhole/client/internal/discovery"
 	"github.com/bunnyholes/pokerhole/client/internal/identity"
 	"github.com/bunnyholes/pokerhole/client/internal/network"
+	"github.com/bunnyholes/pokerhole/client/internal/scenario"
+	"github.com/bunnyholes/pokerhole/client/internal/stats"
 	"github.com/bunnyholes/pokerhole/client/internal/ui"
 )
 
+// options holds command-line settings
+type options struct {
+	seed     int64
+	seedSet  bool
+	replay   string
+	scenario string
+	mode     service.SessionMode
+	lanAddr  string
+	discover bool
+	record   string  // file every frame to and from the server is written to
+	traffic  string  // recorded traffic played back instead of the server
+	speed    float64 // playback speed of traffic
+}
+
 func main() {
+	opts, err := parseOptions(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	var drill *scenario.Scenario
+	if opts.scenario != "" {
+		if drill, err = scenario.Load(opts.scenario); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+	}
+
 	// Generate new UUID for each session (for dev/testing with multiple clients)
 	// TODO: Use GetOrCreateUUID() for production to maintain user identity
 	clientUUID := uuid.New().String()
@@ -22,20 +57,68 @@ func main() {
 	// Create WebSocket client
 	serverURL := getServerURL()
 
+	// The model connects in the background and reports progress as
+	// connection state events
 	client := network.NewClient(serverURL, clientUUID, nickname)
+	defer client.Close()
+	// Servers that echo heartbeats are probed often enough for the status
+	// bar latency and turn clocks to stay current
+	client.SetProbeInterval(5 * time.Second)
 
-	// Connect to server with 3 second timeout
-	var isOnline bool
-	if err := client.ConnectWithTimeout(3 * time.Second); err != nil {
-		isOnline = false
-	} else {
-		defer client.Close()
-		isOnline = true
+	if opts.traffic != "" {
+		replay, err := loadReplay(opts.traffic, opts.speed)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		client.SetDialer(replay)
+	}
+	if opts.record != "" {
+		file, err := os.Create(opts.record)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		defer file.Close()
+		client.Record(network.NewRecorder(file))
+	}
+
+	model := ui.NewModel(client, nickname)
+	if historyPath, err := stats.DefaultPath(); err == nil {
+		model = model.WithHistory(stats.NewStore(historyPath))
+	}
+	if ledgerDir, err := ui.DefaultLedgerDir(); err == nil {
+		model = model.WithLedgerDir(ledgerDir)
+	}
+	if opts.seedSet {
+		model = model.WithSeed(opts.seed)
+	}
+	if opts.replay != "" {
+		model = model.WithReplay(opts.replay)
+	}
+	if drill != nil {
+		model = model.WithScenario(drill)
+	}
+	model = model.WithSessionMode(opts.mode)
+	if opts.lanAddr != "" {
+		model = model.WithLANAddr(opts.lanAddr)
+	}
+	if opts.discover {
+		// Another client on this machine may hold the port; hosting still
+		// announces without a listener, and the home screen says why no
+		// tables show up
+		listener, err := discovery.Listen(fmt.Sprintf(":%d", discovery.DefaultPort), 0)
+		if err == nil {
+			defer listener.Close()
+		} else {
+			model = model.WithDiscoveryFailure(err)
+		}
+		model = model.WithDiscovery(listener, discovery.AnnounceOptions{})
 	}
 
 	// Create and run Bubble Tea program (works in both online and offline modes)
 	p := tea.NewProgram(
-		ui.NewModel(client, isOnline, nickname),
+		model,
 		tea.WithAltScreen(),
 		tea.WithInput(os.Stdin),
 		tea.WithOutput(os.Stdout),
@@ -50,6 +133,74 @@ func main() {
 	os.Exit(0)
 }
 
+// sessionModes maps --mode values to offline session modes
+var sessionModes = map[string]service.SessionMode{
+	"practice":   service.ModePractice,
+	"scored":     service.ModeScored,
+	"tournament": service.ModeTournament,
+}
+
+// parseOptions parses command-line flags
+func parseOptions(args []string) (options, error) {
+	var opts options
+
+	fs := flag.NewFlagSet("poker-client", flag.ContinueOnError)
+	seed := fs.String("seed", "", "offline session seed for reproducible games")
+	fs.StringVar(&opts.replay, "replay", "", "replay token that reproduces an offline game")
+	fs.StringVar(&opts.scenario, "scenario", "", "scenario file to start offline games from")
+	mode := fs.String("mode", "practice", "offline session mode: practice, scored or tournament (undo is practice-only)")
+	scored := fs.Bool("scored", false, "shorthand for --mode scored")
+	fs.StringVar(&opts.lanAddr, "lan-addr", "", "address hosted LAN tables listen on (default :7777)")
+	fs.BoolVar(&opts.discover, "discovery", true, "announce hosted LAN tables and list tables announced nearby")
+	fs.StringVar(&opts.record, "record", "", "record server traffic to a JSONL file for bug reports")
+	fs.StringVar(&opts.traffic, "replay-traffic", "", "play a -record file back instead of connecting to the server")
+	fs.Float64Var(&opts.speed, "replay-speed", 1, "playback speed for -replay-traffic (0 plays without pauses)")
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+
+	if opts.replay != "" && opts.scenario != "" {
+		return opts, errors.New("--replay cannot be combined with --scenario: replay tokens only reproduce normal deals")
+	}
+
+	var ok bool
+	if opts.mode, ok = sessionModes[*mode]; !ok {
+		return opts, fmt.Errorf("invalid --mode %q: must be practice, scored or tournament", *mode)
+	}
+	if *scored {
+		if opts.mode != service.ModePractice && opts.mode != service.ModeScored {
+			return opts, fmt.Errorf("--scored cannot be combined with --mode %s", *mode)
+		}
+		opts.mode = service.ModeScored
+	}
+
+	if *seed != "" {
+		value, err := strconv.ParseInt(*seed, 10, 64)
+		if err != nil {
+			return opts, fmt.Errorf("invalid --seed %q: must be an integer", *seed)
+		}
+		opts.seed = value
+		opts.seedSet = true
+	}
+
+	return opts, nil
+}
+
+// loadReplay reads a traffic recording for playback
+func loadReplay(path string, speed float64) (*network.Replay, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	frames, err := network.ReadRecording(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return network.NewReplay(frames, speed), nil
+}
+
 // getServerURL returns the WebSocket server URL
 func getServerURL() string {
 	// Check environment variable first
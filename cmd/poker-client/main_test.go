@@ -3,6 +3,8 @@ package main
 import (
 	"os"
 	"testing"
+
+	"github.com/bunnyholes/pokerhole/client/internal/core/application/service"
 )
 
 // TestGetServerURL_Default tests default server URL
@@ -52,3 +54,76 @@ func TestGetServerURL_EnvOverridesDefault(t *testing.T) {
 		t.Error("Should not use default URL when environment variable is set")
 	}
 }
+
+// TestParseOptions_Seed tests --seed parsing
+func TestParseOptions_Seed(t *testing.T) {
+	opts, err := parseOptions([]string{"--seed", "-42"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !opts.seedSet || opts.seed != -42 {
+		t.Errorf("Expected seed -42, got %+v", opts)
+	}
+
+	opts, err = parseOptions(nil)
+	if err != nil || opts.seedSet {
+		t.Errorf("Expected no seed by default, got %+v (err %v)", opts, err)
+	}
+
+	if _, err := parseOptions([]string{"--seed", "abc"}); err == nil {
+		t.Error("Expected error for non-numeric seed")
+	}
+}
+
+// TestParseOptions_Traffic tests the recording and playback flags
+func TestParseOptions_Traffic(t *testing.T) {
+	opts, err := parseOptions([]string{"-record", "out.jsonl", "-replay-traffic", "bug.jsonl", "-replay-speed", "4"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if opts.record != "out.jsonl" || opts.traffic != "bug.jsonl" || opts.speed != 4 {
+		t.Errorf("Unexpected options %+v", opts)
+	}
+
+	if opts, _ = parseOptions(nil); opts.speed != 1 {
+		t.Errorf("Expected real-time playback by default, got %v", opts.speed)
+	}
+}
+
+// TestParseOptions_ReplayScenario tests that replay tokens are not applied to scenarios
+func TestParseOptions_ReplayScenario(t *testing.T) {
+	if _, err := parseOptions([]string{"--replay", "PH1.AA", "--scenario", "spot.json"}); err == nil {
+		t.Error("Expected error for --replay with --scenario")
+	}
+}
+
+// TestParseOptions_Mode tests --mode and the --scored shorthand
+func TestParseOptions_Mode(t *testing.T) {
+	cases := []struct {
+		args []string
+		want service.SessionMode
+	}{
+		{nil, service.ModePractice},
+		{[]string{"--mode", "practice"}, service.ModePractice},
+		{[]string{"--mode", "scored"}, service.ModeScored},
+		{[]string{"--mode", "tournament"}, service.ModeTournament},
+		{[]string{"--scored"}, service.ModeScored},
+	}
+	for _, tc := range cases {
+		opts, err := parseOptions(tc.args)
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.args, err)
+			continue
+		}
+		if opts.mode != tc.want {
+			t.Errorf("%v: expected mode %d, got %d", tc.args, tc.want, opts.mode)
+		}
+	}
+
+	if _, err := parseOptions([]string{"--mode", "ranked"}); err == nil {
+		t.Error("Expected error for unknown mode")
+	}
+	if _, err := parseOptions([]string{"--scored", "--mode", "tournament"}); err == nil {
+		t.Error("Expected error for --scored with --mode tournament")
+	}
+}
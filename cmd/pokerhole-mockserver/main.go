@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bunnyholes/pokerhole/client/internal/localserver"
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+)
+
+// options holds command-line settings
+type options struct {
+	addr   string
+	server localserver.Options
+}
+
+func main() {
+	opts, err := parseOptions(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	srv := localserver.New(opts.server)
+	defer srv.Close()
+
+	mux := http.NewServeMux()
+	mux.Handle(localserver.Path, srv)
+
+	log.Printf("pokerhole mock server listening on ws://%s%s", opts.addr, localserver.Path)
+	if err := http.ListenAndServe(opts.addr, mux); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parseOptions parses command-line flags
+func parseOptions(args []string) (options, error) {
+	var opts options
+	s := &opts.server
+
+	fs := flag.NewFlagSet("pokerhole-mockserver", flag.ContinueOnError)
+	fs.StringVar(&opts.addr, "addr", "localhost:8080", "address to listen on")
+	fs.IntVar(&s.Seats, "seats", 2, "players per random match")
+	fs.IntVar(&s.SmallBlind, "small-blind", 10, "random match small blind")
+	fs.IntVar(&s.BigBlind, "big-blind", 20, "random match big blind")
+	fs.IntVar(&s.StartChips, "chips", 1000, "starting stack")
+	fs.Int64Var(&s.Seed, "seed", time.Now().UnixNano(), "deck seed for reproducible hands")
+	fs.DurationVar(&s.HandDelay, "hand-delay", 3*time.Second, "pause between hands")
+	fs.DurationVar(&s.BotFill, "bots", 0, "fill a waiting random match with bots after this long (0 disables)")
+	fs.DurationVar(&s.BotDelay, "bot-delay", 800*time.Millisecond, "bot thinking time")
+	fs.DurationVar(&s.RoomTTL, "room-ttl", 0, "expire code rooms that are not full after this long (0 disables)")
+	fs.IntVar(&s.MinVersion, "min-version", 0, "refuse clients below this protocol version (0 accepts all)")
+	fs.DurationVar(&s.TurnTimeout, "turn-timeout", 0, "check or fold for players whose turn runs this long (0 disables)")
+
+	f := &s.Faults
+	fs.StringVar(&f.RejectRegister, "reject-register", "", "fail every REGISTER with this reason")
+	fs.IntVar(&f.DropAfter, "drop-after", 0, "close each connection after this many client messages")
+	reject := fs.String("reject-actions", "", "comma-separated game actions to answer with INVALID_ACTION, e.g. RAISE,ALL_IN")
+	fs.BoolVar(&f.SilentActions, "silent-actions", false, "never answer game actions so client requests time out")
+	fs.DurationVar(&f.Delay, "delay", 0, "delay every outgoing message")
+	fs.IntVar(&f.GarbleEvery, "garble-every", 0, "follow every Nth outgoing message with an invalid one")
+	fs.IntVar(&f.LoseEvery, "lose-every", 0, "drop every Nth numbered game update so clients must resync")
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+
+	for _, name := range strings.Split(*reject, ",") {
+		if name = strings.ToUpper(strings.TrimSpace(name)); name == "" {
+			continue
+		}
+		action := protocol.ClientMessageType(name)
+		if !protocol.IsGameAction(action) {
+			return opts, fmt.Errorf("invalid --reject-actions %q: not a game action", name)
+		}
+		f.RejectActions = append(f.RejectActions, action)
+	}
+
+	return opts, nil
+}
@@ -25,8 +25,8 @@ func NewLocalDeck() *LocalDeck {
 	return deck
 }
 
-// Compile-time check: LocalDeck implements card.DeckPort
-var _ card.DeckPort = (*LocalDeck)(nil)
+// Compile-time check: LocalDeck implements card.RestorableDeck
+var _ card.RestorableDeck = (*LocalDeck)(nil)
 
 // DrawCard draws a single card from the deck
 func (d *LocalDeck) DrawCard() (card.Card, error) {
@@ -56,6 +56,19 @@ func (d *LocalDeck) Shuffle(seed int64) error {
 	return nil
 }
 
+// Snapshot returns the remaining cards in draw order
+func (d *LocalDeck) Snapshot() []card.Card {
+	cards := make([]card.Card, len(d.cards))
+	copy(cards, d.cards)
+	return cards
+}
+
+// Restore replaces the remaining cards with a previous snapshot
+func (d *LocalDeck) Restore(cards []card.Card) {
+	d.cards = make([]card.Card, len(cards))
+	copy(d.cards, cards)
+}
+
 // RemainingCards returns the number of cards left
 func (d *LocalDeck) RemainingCards() int {
 	return len(d.cards)
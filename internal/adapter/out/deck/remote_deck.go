@@ -1,55 +1,62 @@
-//go:build ignore
-// +build ignore
-
 // Package deck provides deck adapter implementations
 package deck
 
 import (
-	"github.com/bunnyholes/pokerhole/client/internal/adapter/out/websocket"
+	"errors"
+
 	"github.com/bunnyholes/pokerhole/client/internal/core/domain/card"
 )
 
+// ErrNotDealt is returned when drawing past the cards the server has revealed
+var ErrNotDealt = errors.New("card not dealt by server yet")
+
+// DealSource reports the cards the server has revealed to this client:
+// our hole cards first, then the board in deal order
+type DealSource interface {
+	DealtCards() []card.Card
+}
+
 // RemoteDeck is an online deck implementation (Adapter)
 // Implements: card.DeckPort
-// Requests cards from server instead of managing them locally
+// The server shuffles and deals; draws replay what it has revealed so far.
+// Burn cards are never revealed, so callers must not burn from this deck.
 type RemoteDeck struct {
-	client *websocket.Client
-	gameID string
+	source DealSource
+	drawn  int
 }
 
 // NewRemoteDeck creates a new RemoteDeck
-func NewRemoteDeck(client *websocket.Client, gameID string) *RemoteDeck {
-	return &RemoteDeck{
-		client: client,
-		gameID: gameID,
-	}
+func NewRemoteDeck(source DealSource) *RemoteDeck {
+	return &RemoteDeck{source: source}
 }
 
 // Compile-time check: RemoteDeck implements card.DeckPort
 var _ card.DeckPort = (*RemoteDeck)(nil)
 
-// DrawCard requests a card from the server
+// DrawCard returns the next server-dealt card
 func (d *RemoteDeck) DrawCard() (card.Card, error) {
-	// TODO: implement
-	// NOTE: In practice, server auto-sends cards (we just parse)
-	// This is more of a placeholder
-	return card.Card{}, nil
+	cards := d.source.DealtCards()
+	if d.drawn >= len(cards) {
+		return card.Card{}, ErrNotDealt
+	}
+
+	c := cards[d.drawn]
+	d.drawn++
+	return c, nil
 }
 
 // Shuffle does nothing (server shuffles)
 func (d *RemoteDeck) Shuffle(seed int64) error {
-	// Server handles shuffling
-	// Client doesn't need to do anything
 	return nil
 }
 
-// RemainingCards returns 0 (client doesn't know)
+// RemainingCards returns how many dealt cards have not been drawn yet
 func (d *RemoteDeck) RemainingCards() int {
-	// Server tracks this, client doesn't need to know
-	return 0
+	return max(0, len(d.source.DealtCards())-d.drawn)
 }
 
-// Reset does nothing (server handles)
+// Reset starts drawing from the first dealt card again, e.g. for a new hand
 func (d *RemoteDeck) Reset() error {
+	d.drawn = 0
 	return nil
 }
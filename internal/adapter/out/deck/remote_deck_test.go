@@ -0,0 +1,40 @@
+package deck
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/card"
+)
+
+type fakeDeal []card.Card
+
+func (f *fakeDeal) DealtCards() []card.Card { return *f }
+
+func TestRemoteDeck_DrawsServerDealtCards(t *testing.T) {
+	dealt := fakeDeal{*mustParse(t, "Ah"), *mustParse(t, "Kh")}
+	deck := NewRemoteDeck(&dealt)
+
+	first, _ := deck.DrawCard()
+	second, _ := deck.DrawCard()
+	if first.String() != dealt[0].String() || second.String() != dealt[1].String() {
+		t.Fatalf("expected hole cards in deal order, got %v %v", first, second)
+	}
+	if _, err := deck.DrawCard(); !errors.Is(err, ErrNotDealt) {
+		t.Fatalf("expected ErrNotDealt before the flop, got %v", err)
+	}
+
+	// The flop arrives
+	dealt = append(dealt, *mustParse(t, "2c"), *mustParse(t, "7d"), *mustParse(t, "Jh"))
+	if deck.RemainingCards() != 3 {
+		t.Fatalf("expected 3 undrawn cards, got %d", deck.RemainingCards())
+	}
+	if c, err := deck.DrawCard(); err != nil || c.String() != dealt[2].String() {
+		t.Fatalf("expected first flop card, got %v (%v)", c, err)
+	}
+
+	deck.Reset()
+	if deck.RemainingCards() != 5 {
+		t.Fatalf("expected reset to start from the first card, got %d", deck.RemainingCards())
+	}
+}
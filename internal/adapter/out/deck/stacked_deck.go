@@ -0,0 +1,124 @@
+// Package deck provides deck adapter implementations
+package deck
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/card"
+)
+
+// StackedDeck deals a predetermined order of cards (Adapter)
+// Implements: card.DeckPort
+//
+// Each slot is either a fixed card or nil. Nil slots, and everything after
+// the last slot, are filled with the remaining cards in seeded random order.
+type StackedDeck struct {
+	slots []*card.Card
+	cards []card.Card
+}
+
+// NewStackedDeck creates a deck that deals the given slots first
+func NewStackedDeck(slots []*card.Card) (*StackedDeck, error) {
+	if len(slots) > 52 {
+		return nil, errors.New("stacked deck has more than 52 slots")
+	}
+
+	seen := make(map[card.Card]bool)
+	for _, c := range slots {
+		if c == nil {
+			continue
+		}
+		if seen[*c] {
+			return nil, fmt.Errorf("card %s is stacked more than once", c)
+		}
+		seen[*c] = true
+	}
+
+	deck := &StackedDeck{slots: slots}
+	deck.Reset()
+	return deck, nil
+}
+
+// Compile-time check: StackedDeck implements card.RestorableDeck
+var _ card.RestorableDeck = (*StackedDeck)(nil)
+
+// DrawCard draws the next card in stacked order
+func (d *StackedDeck) DrawCard() (card.Card, error) {
+	if len(d.cards) == 0 {
+		return card.Card{}, errors.New("deck is empty")
+	}
+
+	drawnCard := d.cards[0]
+	d.cards = d.cards[1:]
+
+	return drawnCard, nil
+}
+
+// Shuffle re-fills the unspecified slots from the seed; fixed slots keep their position
+func (d *StackedDeck) Shuffle(seed int64) error {
+	d.fill(rand.New(rand.NewSource(seed)))
+	return nil
+}
+
+// Snapshot returns the remaining cards in draw order
+func (d *StackedDeck) Snapshot() []card.Card {
+	cards := make([]card.Card, len(d.cards))
+	copy(cards, d.cards)
+	return cards
+}
+
+// Restore replaces the remaining cards with a previous snapshot
+func (d *StackedDeck) Restore(cards []card.Card) {
+	d.cards = make([]card.Card, len(cards))
+	copy(d.cards, cards)
+}
+
+// RemainingCards returns the number of cards left
+func (d *StackedDeck) RemainingCards() int {
+	return len(d.cards)
+}
+
+// Reset restores all 52 cards with the stacked slots on top
+func (d *StackedDeck) Reset() error {
+	d.fill(nil)
+	return nil
+}
+
+// fill lays out the deck; unspecified positions take the leftover cards,
+// shuffled with rng when given or in standard order otherwise
+func (d *StackedDeck) fill(rng *rand.Rand) {
+	fixed := make(map[card.Card]bool)
+	for _, c := range d.slots {
+		if c != nil {
+			fixed[*c] = true
+		}
+	}
+
+	rest := NewLocalDeck().cards
+	free := rest[:0]
+	for _, c := range rest {
+		if !fixed[c] {
+			free = append(free, c)
+		}
+	}
+
+	if rng != nil {
+		for i := len(free) - 1; i > 0; i-- {
+			j := rng.Intn(i + 1)
+			free[i], free[j] = free[j], free[i]
+		}
+	}
+
+	d.cards = make([]card.Card, 0, 52)
+	for _, c := range d.slots {
+		if c != nil {
+			d.cards = append(d.cards, *c)
+			continue
+		}
+		d.cards = append(d.cards, free[0])
+		free = free[1:]
+	}
+	d.cards = append(d.cards, free...)
+}
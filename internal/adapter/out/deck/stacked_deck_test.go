@@ -0,0 +1,75 @@
+package deck
+
+import (
+	"testing"
+
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/card"
+)
+
+func mustParse(t *testing.T, s string) *card.Card {
+	t.Helper()
+	c, err := card.ParseCard(s)
+	if err != nil {
+		t.Fatalf("ParseCard(%q) failed: %v", s, err)
+	}
+	return &c
+}
+
+func TestStackedDeck_DealsFixedSlotsInOrder(t *testing.T) {
+	slots := []*card.Card{mustParse(t, "As"), nil, mustParse(t, "Kd")}
+	deck, err := NewStackedDeck(slots)
+	if err != nil {
+		t.Fatalf("NewStackedDeck failed: %v", err)
+	}
+	deck.Shuffle(42)
+
+	if deck.RemainingCards() != 52 {
+		t.Fatalf("Expected 52 cards, got %d", deck.RemainingCards())
+	}
+
+	first, _ := deck.DrawCard()
+	filler, _ := deck.DrawCard()
+	third, _ := deck.DrawCard()
+
+	if !first.Equals(*slots[0]) || !third.Equals(*slots[2]) {
+		t.Errorf("Fixed slots not dealt in order: %s %s", first, third)
+	}
+	if filler.Equals(*slots[0]) || filler.Equals(*slots[2]) {
+		t.Errorf("Random fill reused a stacked card: %s", filler)
+	}
+
+	seen := map[card.Card]bool{first: true, filler: true, third: true}
+	for deck.RemainingCards() > 0 {
+		c, _ := deck.DrawCard()
+		if seen[c] {
+			t.Fatalf("Card %s dealt twice", c)
+		}
+		seen[c] = true
+	}
+	if len(seen) != 52 {
+		t.Errorf("Expected 52 unique cards, got %d", len(seen))
+	}
+}
+
+func TestStackedDeck_FillIsDeterministic(t *testing.T) {
+	slots := []*card.Card{nil, mustParse(t, "2c"), nil}
+	d1, _ := NewStackedDeck(slots)
+	d2, _ := NewStackedDeck(slots)
+	d1.Shuffle(7)
+	d2.Shuffle(7)
+
+	for i := 0; i < 52; i++ {
+		c1, _ := d1.DrawCard()
+		c2, _ := d2.DrawCard()
+		if !c1.Equals(c2) {
+			t.Fatalf("Card %d differs for the same seed: %s vs %s", i, c1, c2)
+		}
+	}
+}
+
+func TestStackedDeck_RejectsDuplicates(t *testing.T) {
+	_, err := NewStackedDeck([]*card.Card{mustParse(t, "Ah"), mustParse(t, "A♥")})
+	if err == nil {
+		t.Error("Expected error for duplicate stacked card")
+	}
+}
@@ -0,0 +1,122 @@
+// Package websocket adapts the network client to the application's
+// GameServer port
+package websocket
+
+import (
+	"fmt"
+
+	"github.com/bunnyholes/pokerhole/client/internal/core/application/service"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/card"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/game/vo"
+	"github.com/bunnyholes/pokerhole/client/internal/network"
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+	"github.com/bunnyholes/pokerhole/client/internal/state"
+)
+
+// Client is the online game server adapter (Adapter)
+// Implements: service.GameServer
+// Actions go out through the network client; the table is whatever the
+// caller has applied from the server's messages.
+type Client struct {
+	conn  *network.Client
+	table *state.GameState
+}
+
+// NewClient creates a new Client reading state from table
+func NewClient(conn *network.Client, table *state.GameState) *Client {
+	return &Client{
+		conn:  conn,
+		table: table,
+	}
+}
+
+// Compile-time check: Client implements service.GameServer
+var _ service.GameServer = (*Client)(nil)
+
+// PlayerID returns the ID the client registers with
+func (c *Client) PlayerID() string {
+	return c.conn.UUID()
+}
+
+// JoinRandomMatch asks the server to seat us at a random table
+func (c *Client) JoinRandomMatch() error {
+	return c.conn.JoinRandomMatch()
+}
+
+// SendAction sends a betting action and returns its request ID
+func (c *Client) SendAction(action vo.PlayerAction, amount int) (string, error) {
+	if !action.IsValid() {
+		return "", fmt.Errorf("invalid action %d", action)
+	}
+	return c.conn.SendGameAction(protocol.ClientMessageType(action.String()), amount)
+}
+
+// RequestSync asks the server to resend the full game state
+func (c *Client) RequestSync(gameID string) error {
+	return c.conn.SendPayload(protocol.RequestSync{GameID: gameID})
+}
+
+// Table converts the latest server state into domain terms
+func (c *Client) Table() service.RemoteTable {
+	snap := c.table.GetSnapshot()
+
+	table := service.RemoteTable{
+		GameID:        snap.GameID,
+		Round:         snap.Round,
+		Pot:           snap.Pot,
+		CurrentBet:    snap.CurrentBet,
+		Board:         parseCards(snap.CommunityCards),
+		Seats:         make([]service.RemoteSeat, 0, len(snap.Players)),
+		CurrentPlayer: snap.CurrentPlayer,
+		ValidActions:  parseActions(snap.ValidActions),
+		Ended:         snap.Ended,
+	}
+	for _, p := range snap.Players {
+		table.Seats = append(table.Seats, service.RemoteSeat{
+			ID:       p.ID,
+			Nickname: p.Nickname,
+			Chips:    p.Chips,
+			Bet:      p.Bet,
+			Status:   p.Status,
+			Hole:     parseCards(p.HoleCards),
+		})
+	}
+	return table
+}
+
+// DealtCards returns our hole cards followed by the board
+func (c *Client) DealtCards() []card.Card {
+	snap := c.table.GetSnapshot()
+
+	var dealt []card.Card
+	for _, p := range snap.Players {
+		if p.ID == c.conn.UUID() {
+			dealt = append(dealt, parseCards(p.HoleCards)...)
+		}
+	}
+	return append(dealt, parseCards(snap.CommunityCards)...)
+}
+
+// parseCards converts wire notation, skipping anything unreadable
+func parseCards(notation []string) []card.Card {
+	cards := make([]card.Card, 0, len(notation))
+	for _, s := range notation {
+		if c, err := card.ParseCard(s); err == nil {
+			cards = append(cards, c)
+		}
+	}
+	return cards
+}
+
+// parseActions converts wire action names to domain actions
+func parseActions(names []string) []vo.PlayerAction {
+	actions := make([]vo.PlayerAction, 0, len(names))
+	for _, name := range names {
+		for a := vo.Fold; a <= vo.AllIn; a++ {
+			if a.String() == name {
+				actions = append(actions, a)
+			}
+		}
+	}
+	return actions
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"math/rand"
+
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/game/vo"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/player"
+)
+
+const (
+	botRaiseChance = 0.15
+	botRaiseSize   = 50
+)
+
+// Bot decides actions for computer-controlled seats.
+// All randomness comes from its own seeded source so a session seed
+// reproduces every bot decision.
+type Bot struct {
+	seed int64
+	src  *countingSource
+	rng  *rand.Rand
+}
+
+// NewBot creates a bot with a deterministic random source
+func NewBot(seed int64) *Bot {
+	b := &Bot{seed: seed}
+	b.rewind(0)
+	return b
+}
+
+// draws returns how many values the bot has consumed from its source
+func (b *Bot) draws() uint64 {
+	return b.src.draws
+}
+
+// rewind resets the bot's source to the state after n draws, so undone
+// decisions are made again identically
+func (b *Bot) rewind(n uint64) {
+	b.src = &countingSource{src: rand.NewSource(b.seed)}
+	for b.src.draws < n {
+		b.src.Int63()
+	}
+	b.rng = rand.New(b.src)
+}
+
+// countingSource wraps a rand.Source and counts values drawn from it
+type countingSource struct {
+	src   rand.Source
+	draws uint64
+}
+
+func (s *countingSource) Int63() int64 {
+	s.draws++
+	return s.src.Int63()
+}
+
+func (s *countingSource) Seed(seed int64) {
+	s.src.Seed(seed)
+	s.draws = 0
+}
+
+// Decide chooses an action for the player in the given seat
+func (b *Bot) Decide(g *OfflineGame, seat int) (vo.PlayerAction, int) {
+	p := g.players[seat]
+	callAmount := g.currentBet - p.Bet()
+	roll := b.rng.Float64()
+
+	switch {
+	case p.Status() == player.AllIn || p.Status() == player.Folded:
+		return vo.Check, g.currentBet
+	case callAmount <= 0:
+		raiseTo := g.currentBet + botRaiseSize
+		if roll < botRaiseChance && raiseTo-p.Bet() < p.Chips() {
+			return vo.Raise, raiseTo
+		}
+		return vo.Check, g.currentBet
+	case callAmount < p.Chips():
+		return vo.Call, g.currentBet
+	default:
+		return vo.AllIn, g.currentBet
+	}
+}
+
+// BotAction returns the bot's decision for the given seat
+func (g *OfflineGame) BotAction(seat int) (vo.PlayerAction, int) {
+	return g.bot.Decide(g, seat)
+}
+
+// PlayBot decides and plays the bot's action for the given seat. A
+// rejected action rewinds the bot's source: replay draws one decision per
+// recorded action, so a decision that was never played must not count.
+func (g *OfflineGame) PlayBot(seat int) (vo.PlayerAction, int, error) {
+	draws := g.bot.draws()
+	action, amount := g.BotAction(seat)
+	if err := g.PlayerAction(seat, action, amount); err != nil {
+		g.bot.rewind(draws)
+		return action, amount, err
+	}
+	return action, amount, nil
+}
@@ -0,0 +1,19 @@
+package service
+
+import "github.com/bunnyholes/pokerhole/client/internal/core/domain/game/vo"
+
+// Game is a table the local player sits at, offline or online
+// Implementations: OfflineGame, OnlineGameService
+type Game interface {
+	// GetGameState returns the table as a snapshot
+	GetGameState() GameStateSnapshot
+
+	// LocalSeat returns the local player's seat index, -1 if not seated
+	LocalSeat() int
+
+	// ValidActions returns what the local player may do now
+	ValidActions() []vo.PlayerAction
+
+	// Act takes an action for the local player
+	Act(action vo.PlayerAction, amount int) error
+}
@@ -2,12 +2,14 @@ package service
 
 import (
 	"fmt"
+	"math/rand"
 	"time"
 
 	"github.com/bunnyholes/pokerhole/client/internal/adapter/out/deck"
 	"github.com/bunnyholes/pokerhole/client/internal/core/domain/card"
 	"github.com/bunnyholes/pokerhole/client/internal/core/domain/game"
 	"github.com/bunnyholes/pokerhole/client/internal/core/domain/game/vo"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/ledger"
 	"github.com/bunnyholes/pokerhole/client/internal/core/domain/player"
 )
 
@@ -21,15 +23,37 @@ type OfflineGame struct {
 	pot            int
 	currentBet     int
 	currentPlayer  int
+	aggressor      int // seat that opened or last raised the street
+	streetActions  int // actions taken on the current street
 	gameState      game.GameState
+	handNumber     int
+	handStartChips []int
+	showdownPot    int
+	seed           int64
+	deckRng        *rand.Rand // per-hand shuffle seeds
+	bot            *Bot
+	events         []GameEvent
+	setup          *OfflineSetup // non-nil when started from a fixed spot
+	replayed       bool          // rebuilt from a replay token
+	ledger         *ledger.Ledger
+	handID         string
+	mode           SessionMode
+	undo           []offlineSnapshot
 }
 
-// NewOfflineGame creates a new offline game with 2 players
+// NewOfflineGame creates a new offline game with 2 players and a random session seed
 func NewOfflineGame(userNickname string) *OfflineGame {
+	return NewOfflineGameWithSeed(userNickname, time.Now().UnixNano())
+}
+
+// NewOfflineGameWithSeed creates a new offline game whose shuffles and bot
+// decisions are all derived from the given session seed
+func NewOfflineGameWithSeed(userNickname string, seed int64) *OfflineGame {
+	deckRng := rand.New(rand.NewSource(deriveSeed(seed, seedStreamDeck)))
+
 	// Create deck
 	localDeck := deck.NewLocalDeck()
-	seed := time.Now().UnixNano()
-	localDeck.Shuffle(seed)
+	localDeck.Shuffle(deckRng.Int63())
 
 	// Create game service
 	handEvaluator := game.NewHandEvaluator()
@@ -57,12 +81,27 @@ func NewOfflineGame(userNickname string) *OfflineGame {
 		currentBet:     0,
 		currentPlayer:  0,
 		gameState:      game.Waiting,
+		seed:           seed,
+		deckRng:        deckRng,
+		bot:            NewBot(deriveSeed(seed, seedStreamBot)),
+		ledger:         ledger.New(),
 	}
 }
 
+// Seed returns the session seed that reproduces this game
+func (g *OfflineGame) Seed() int64 {
+	return g.seed
+}
+
 // Start starts the game
 func (g *OfflineGame) Start() error {
 	g.gameState = game.Playing
+	g.handNumber++
+	g.handStartChips = make([]int, len(g.players))
+	for i, p := range g.players {
+		g.handStartChips[i] = p.Chips()
+	}
+	g.openHand()
 
 	// Deal hole cards
 	err := g.gameService.DealHoleCards(g.players)
@@ -71,10 +110,16 @@ func (g *OfflineGame) Start() error {
 	}
 
 	// Set small blind and big blind
-	g.players[0].PlaceBet(10)  // Small blind
-	g.players[1].PlaceBet(20)  // Big blind
-	g.pot = 30
+	if err := g.postBlind(g.players[0], 10); err != nil { // Small blind
+		return err
+	}
+	if err := g.postBlind(g.players[1], 20); err != nil { // Big blind
+		return err
+	}
 	g.currentBet = 20
+	// The small blind acts first and the big blind still gets its option
+	g.openStreet(0)
+	g.resetUndo()
 
 	return nil
 }
@@ -91,9 +136,14 @@ func (g *OfflineGame) GetGameState() GameStateSnapshot {
 		WinnerIndex:    -1, // No winner by default
 	}
 
-	// If Showdown, evaluate hands and determine winner
-	if g.round == vo.Showdown && len(g.communityCards) == 5 {
-		snapshot = g.evaluateShowdown(snapshot)
+	// If Showdown, evaluate hands and determine winner; a hand won by
+	// folds shows no cards
+	if g.round == vo.Showdown {
+		if seat := g.lastStanding(); seat >= 0 {
+			snapshot.WinnerIndex = seat
+		} else if len(g.communityCards) == 5 {
+			snapshot = g.evaluateShowdown(snapshot)
+		}
 	}
 
 	return snapshot
@@ -122,6 +172,22 @@ type PlayerSnapshot struct {
 	BestCards []string // Best 5 cards used for hand evaluation
 }
 
+// lastStanding returns the seat of the only player who has not folded, or
+// -1 while the hand is still contested
+func (g *OfflineGame) lastStanding() int {
+	seat := -1
+	for i, p := range g.players {
+		if p.Status() == player.Folded {
+			continue
+		}
+		if seat >= 0 {
+			return -1
+		}
+		seat = i
+	}
+	return seat
+}
+
 // formatCards formats cards for display
 func formatCards(cards []card.Card) []string {
 	result := make([]string, len(cards))
@@ -205,43 +271,80 @@ func (g *OfflineGame) PlayerAction(playerIndex int, action vo.PlayerAction, amou
 
 	p := g.players[playerIndex]
 
+	// Only the user's own actions can be taken back
+	var before *offlineSnapshot
+	if playerIndex == 0 {
+		before = g.captureUndo(undoAction)
+	}
+
 	switch action {
 	case vo.Fold:
 		p.Fold()
 	case vo.Call:
 		callAmount := g.currentBet - p.Bet()
-		if err := p.PlaceBet(callAmount); err != nil {
+		if err := g.commit(p, callAmount, ledger.ReasonCall); err != nil {
 			return err
 		}
-		g.pot += callAmount
 	case vo.Raise:
 		raiseAmount := amount - p.Bet()
-		if err := p.PlaceBet(raiseAmount); err != nil {
+		if err := g.commit(p, raiseAmount, ledger.ReasonRaise); err != nil {
 			return err
 		}
-		g.pot += raiseAmount
 		g.currentBet = amount
+		g.aggressor = playerIndex
 	case vo.AllIn:
-		allInAmount := p.Chips()
-		p.AllIn()
-		g.pot += allInAmount
+		g.commitAllIn(p)
 		// Update currentBet if all-in amount exceeds it
 		totalBet := p.Bet()
 		if totalBet > g.currentBet {
 			g.currentBet = totalBet
+			g.aggressor = playerIndex
 		}
 	case vo.Check:
 		// Do nothing
 	}
 
+	g.events = append(g.events, GameEvent{Kind: EventAction, Seat: playerIndex, Action: action, Amount: amount})
+	g.pushUndo(before)
+
 	// Move to next player
 	g.currentPlayer = (g.currentPlayer + 1) % len(g.players)
+	g.streetActions++
 
 	return nil
 }
 
+// openStreet starts a betting round with seat first to act
+func (g *OfflineGame) openStreet(seat int) {
+	g.currentPlayer = seat
+	g.aggressor = seat
+	g.streetActions = 0
+}
+
+// StreetComplete reports whether the current betting round is over: one
+// player is left, or the action has come back to the player who opened the
+// street or last raised it
+func (g *OfflineGame) StreetComplete() bool {
+	if g.round == vo.Showdown {
+		return false
+	}
+	return g.lastStanding() >= 0 || g.streetActions > 0 && g.currentPlayer == g.aggressor
+}
+
 // ProgressRound progresses to the next betting round
 func (g *OfflineGame) ProgressRound() error {
+	if g.round != vo.Showdown {
+		g.events = append(g.events, GameEvent{Kind: EventProgress})
+	}
+
+	// A hand everyone else folded ends without dealing the rest of the board
+	if g.round != vo.Showdown && g.lastStanding() >= 0 {
+		g.round = vo.Showdown
+		g.gameState = game.Finished
+		g.undo = nil
+		return g.resolveShowdown()
+	}
+
 	switch g.round {
 	case vo.PreFlop:
 		// Deal flop
@@ -252,10 +355,11 @@ func (g *OfflineGame) ProgressRound() error {
 		g.communityCards = append(g.communityCards, cards...)
 		g.round = vo.Flop
 		g.currentBet = 0
-		g.currentPlayer = 0 // Reset to first player
+		g.openStreet(0)
 		for _, p := range g.players {
 			p.ResetBet()
 		}
+		g.pushUndo(g.captureUndo(undoStreet))
 
 	case vo.Flop:
 		// Deal turn
@@ -266,10 +370,11 @@ func (g *OfflineGame) ProgressRound() error {
 		g.communityCards = append(g.communityCards, turnCard)
 		g.round = vo.Turn
 		g.currentBet = 0
-		g.currentPlayer = 0 // Reset to first player
+		g.openStreet(0)
 		for _, p := range g.players {
 			p.ResetBet()
 		}
+		g.pushUndo(g.captureUndo(undoStreet))
 
 	case vo.Turn:
 		// Deal river
@@ -280,15 +385,17 @@ func (g *OfflineGame) ProgressRound() error {
 		g.communityCards = append(g.communityCards, riverCard)
 		g.round = vo.River
 		g.currentBet = 0
-		g.currentPlayer = 0 // Reset to first player
+		g.openStreet(0)
 		for _, p := range g.players {
 			p.ResetBet()
 		}
+		g.pushUndo(g.captureUndo(undoStreet))
 
 	case vo.River:
 		// Showdown
 		g.round = vo.Showdown
 		g.gameState = game.Finished
+		g.undo = nil
 
 		// Determine winner and distribute pot
 		return g.resolveShowdown()
@@ -310,6 +417,9 @@ func (g *OfflineGame) resolveShowdown() error {
 		return fmt.Errorf("no winners found")
 	}
 
+	g.refundUncalled()
+	g.showdownPot = g.pot
+
 	// Distribute pot evenly among winners
 	potShare := g.pot / len(winners)
 	remainder := g.pot % len(winners)
@@ -320,11 +430,121 @@ func (g *OfflineGame) resolveShowdown() error {
 		if i == 0 {
 			share += remainder
 		}
-		winner.AddChips(share)
+		g.payout(winner, share, ledger.ReasonPotAward)
 	}
 
-	g.pot = 0
-	return nil
+	return g.closeHand()
+}
+
+// CompletedHand summarizes a finished hand for history and statistics
+type CompletedHand struct {
+	HandNumber int
+	Pot        int
+	Seats      []CompletedSeat
+}
+
+// CompletedSeat is one player's result within a CompletedHand
+type CompletedSeat struct {
+	Nickname   string
+	Position   string
+	StartChips int
+	EndChips   int
+	HandRank   string
+	Winner     bool
+}
+
+// Net returns the chips won (positive) or lost (negative) during the hand
+func (s CompletedSeat) Net() int {
+	return s.EndChips - s.StartChips
+}
+
+// CompletedHand returns the result of the current hand (only valid after showdown)
+func (g *OfflineGame) CompletedHand() (CompletedHand, error) {
+	if g.round != vo.Showdown {
+		return CompletedHand{}, fmt.Errorf("game not in showdown state")
+	}
+
+	snapshot := g.GetGameState()
+	winners, err := g.GetWinners()
+	if err != nil {
+		return CompletedHand{}, err
+	}
+
+	hand := CompletedHand{
+		HandNumber: g.handNumber,
+		Pot:        g.showdownPot,
+		Seats:      make([]CompletedSeat, len(g.players)),
+	}
+
+	for i, p := range g.players {
+		seat := CompletedSeat{
+			Nickname: p.Nickname().String(),
+			Position: PositionName(i, len(g.players)),
+			EndChips: p.Chips(),
+			HandRank: snapshot.Players[i].HandRank,
+		}
+		if i < len(g.handStartChips) {
+			seat.StartChips = g.handStartChips[i]
+		}
+		for _, w := range winners {
+			if w == p {
+				seat.Winner = true
+			}
+		}
+		hand.Seats[i] = seat
+	}
+
+	return hand, nil
+}
+
+// PositionName returns the table position label for a seat index.
+// Seat 0 posts the small blind and seat 1 the big blind.
+func PositionName(seat, seats int) string {
+	switch {
+	case seat == 0:
+		return "SB"
+	case seat == 1:
+		return "BB"
+	case seat == seats-1:
+		return "BTN"
+	case seat == 2:
+		return "UTG"
+	default:
+		return fmt.Sprintf("MP%d", seat-2)
+	}
+}
+
+// Compile-time check: OfflineGame is a Game
+var _ Game = (*OfflineGame)(nil)
+
+// LocalSeat returns the user's seat; the AI always sits in seat 1
+func (g *OfflineGame) LocalSeat() int {
+	return 0
+}
+
+// ValidActions returns the user's legal actions when it is their turn
+func (g *OfflineGame) ValidActions() []vo.PlayerAction {
+	p := g.players[0]
+	if g.round == vo.Showdown || g.currentPlayer != 0 || p.Status() == player.Folded || p.Chips() == 0 {
+		return nil
+	}
+
+	toCall := g.currentBet - p.Bet()
+	actions := []vo.PlayerAction{vo.Fold}
+	if toCall <= 0 {
+		actions = append(actions, vo.Check)
+	} else {
+		actions = append(actions, vo.Call)
+	}
+	if p.Chips() > toCall {
+		actions = append(actions, vo.Raise)
+	}
+	return append(actions, vo.AllIn)
+}
+
+// Act takes an action for the user
+func (g *OfflineGame) Act(action vo.PlayerAction, amount int) error {
+	return g.PlayerAction(g.LocalSeat(), action, amount)
 }
 
 // GetPlayers returns the players
@@ -349,6 +569,12 @@ func (g *OfflineGame) GetWinners() ([]*player.Player, error) {
 
 // Restart resets the game for a new hand
 func (g *OfflineGame) Restart() error {
+	// Scenario games deal the same spot again
+	if g.setup != nil {
+		g.events = append(g.events, GameEvent{Kind: EventRestart})
+		return g.dealSetup()
+	}
+
 	// Check if any player has run out of chips
 	for _, p := range g.players {
 		if p.Chips() <= 0 {
@@ -362,11 +588,12 @@ func (g *OfflineGame) Restart() error {
 		return err
 	}
 
-	seed := time.Now().UnixNano()
-	if err := g.deck.Shuffle(seed); err != nil {
+	if err := g.deck.Shuffle(g.deckRng.Int63()); err != nil {
 		return err
 	}
 
+	g.events = append(g.events, GameEvent{Kind: EventRestart})
+
 	// Reset game state
 	g.communityCards = make([]card.Card, 0)
 	g.round = vo.PreFlop
@@ -343,6 +343,33 @@ func TestProgressRound_RiverToShowdown(t *testing.T) {
 	}
 }
 
+func TestProgressRound_FoldEndsHand(t *testing.T) {
+	game := NewOfflineGame("TestPlayer")
+	game.Start()
+
+	// The small blind folds; the big blind takes the pot without a board
+	game.PlayerAction(0, vo.Fold, 0)
+	if err := game.ProgressRound(); err != nil {
+		t.Fatalf("ProgressRound failed: %v", err)
+	}
+
+	state := game.GetGameState()
+	if state.Round != "SHOWDOWN" || len(state.CommunityCards) != 0 {
+		t.Fatalf("Expected showdown without a board, got %s %v", state.Round, state.CommunityCards)
+	}
+	if state.WinnerIndex != 1 || state.Players[1].HandRank != "" {
+		t.Errorf("Expected the AI to win unseen, got winner %d %q", state.WinnerIndex, state.Players[1].HandRank)
+	}
+
+	hand, err := game.CompletedHand()
+	if err != nil {
+		t.Fatalf("CompletedHand failed: %v", err)
+	}
+	if hand.Pot != 20 || hand.Seats[0].Net() != -10 || !hand.Seats[1].Winner || hand.Seats[1].Net() != 10 {
+		t.Errorf("Unexpected completed hand: %+v", hand)
+	}
+}
+
 func TestGetGameState_Snapshot(t *testing.T) {
 	game := NewOfflineGame("TestPlayer")
 	game.Start()
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/game"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/ledger"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/player"
+)
+
+// Ledger returns the chip ledger of the session
+func (g *OfflineGame) Ledger() *ledger.Ledger {
+	return g.ledger
+}
+
+// HandID returns the identifier of the current hand
+func (g *OfflineGame) HandID() string {
+	return g.handID
+}
+
+// openHand starts a new ledger hand with the current balances as opening
+func (g *OfflineGame) openHand() {
+	g.handID = game.GenerateGameId().String()
+	for _, p := range g.players {
+		g.ledger.Label(p.ID().String(), p.Nickname().String())
+	}
+	g.ledger.OpenHand(g.handID, g.balances())
+}
+
+// closeHand audits chip conservation for the finished hand
+func (g *OfflineGame) closeHand() error {
+	return g.ledger.Close(g.handID, g.balances())
+}
+
+// balances returns every player's stack plus the pot
+func (g *OfflineGame) balances() ledger.Balances {
+	b := ledger.Balances{ledger.PotAccount: g.pot}
+	for _, p := range g.players {
+		b[p.ID().String()] = p.Chips()
+	}
+	return b
+}
+
+// postBlind commits a blind, putting a short stack all-in
+func (g *OfflineGame) postBlind(p *player.Player, amount int) error {
+	if amount >= p.Chips() {
+		g.commitAllIn(p)
+		return nil
+	}
+	return g.commit(p, amount, ledger.ReasonBlind)
+}
+
+// commit moves chips from a player's stack into the pot
+func (g *OfflineGame) commit(p *player.Player, amount int, reason ledger.Reason) error {
+	if err := p.PlaceBet(amount); err != nil {
+		return err
+	}
+	g.pot += amount
+	g.ledger.Record(g.handID, reason, p.ID().String(), ledger.PotAccount, amount)
+	return nil
+}
+
+// commitAllIn moves a player's whole stack into the pot
+func (g *OfflineGame) commitAllIn(p *player.Player) {
+	amount := p.Chips()
+	p.AllIn()
+	g.pot += amount
+	g.ledger.Record(g.handID, ledger.ReasonAllIn, p.ID().String(), ledger.PotAccount, amount)
+}
+
+// payout moves chips from the pot to a player
+func (g *OfflineGame) payout(p *player.Player, amount int, reason ledger.Reason) {
+	if amount <= 0 {
+		return
+	}
+	p.AddChips(amount)
+	g.pot -= amount
+	g.ledger.Record(g.handID, reason, ledger.PotAccount, p.ID().String(), amount)
+}
+
+// refundUncalled returns the part of the largest contribution that no other
+// player matched
+func (g *OfflineGame) refundUncalled() {
+	contributions := g.ledger.Contributions(g.handID)
+
+	var top *player.Player
+	topAmount, second := 0, 0
+	for _, p := range g.players {
+		amount := contributions[p.ID().String()]
+		switch {
+		case amount > topAmount:
+			top, second, topAmount = p, topAmount, amount
+		case amount > second:
+			second = amount
+		}
+	}
+
+	if top != nil && topAmount > second {
+		g.payout(top, topAmount-second, ledger.ReasonRefund)
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/game/vo"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/ledger"
+)
+
+func TestOfflineGame_LedgerConservesChips(t *testing.T) {
+	g := NewOfflineGameWithSeed("Hero", 11)
+	if err := g.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	for hand := 0; hand < 5; hand++ {
+		playScripted(t, g)
+		if g.players[0].Chips()+g.players[1].Chips() != 2000 {
+			t.Fatalf("Hand %d: chips not conserved: %d + %d", hand, g.players[0].Chips(), g.players[1].Chips())
+		}
+		if g.players[0].Chips() == 0 || g.players[1].Chips() == 0 {
+			break
+		}
+		if err := g.Restart(); err != nil {
+			t.Fatalf("Restart failed: %v", err)
+		}
+	}
+
+	if len(g.Ledger().Hands()) == 0 {
+		t.Fatal("Expected hands in the ledger")
+	}
+}
+
+func TestOfflineGame_RefundsUncalledRaise(t *testing.T) {
+	g := NewOfflineGameWithSeed("Hero", 3)
+	if err := g.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	if err := g.PlayerAction(0, vo.Raise, 100); err != nil {
+		t.Fatalf("Raise failed: %v", err)
+	}
+	if err := g.PlayerAction(1, vo.Fold, 0); err != nil {
+		t.Fatalf("Fold failed: %v", err)
+	}
+	for g.round != vo.Showdown {
+		if err := g.ProgressRound(); err != nil {
+			t.Fatalf("ProgressRound failed: %v", err)
+		}
+	}
+
+	if chips := g.players[0].Chips(); chips != 1020 {
+		t.Errorf("Expected hero to win the bot's blind, chips %d", chips)
+	}
+
+	var refunded, awarded int
+	for _, e := range g.Ledger().Entries(g.HandID()) {
+		switch e.Reason {
+		case ledger.ReasonRefund:
+			refunded += e.Amount
+		case ledger.ReasonPotAward:
+			awarded += e.Amount
+		}
+	}
+	if refunded != 80 || awarded != 40 {
+		t.Errorf("Expected refund 80 and award 40, got %d and %d", refunded, awarded)
+	}
+}
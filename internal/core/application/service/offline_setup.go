@@ -0,0 +1,268 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/bunnyholes/pokerhole/client/internal/adapter/out/deck"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/card"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/game"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/game/vo"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/ledger"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/player"
+)
+
+// OfflineSetup describes an exact spot to start an offline hand from
+type OfflineSetup struct {
+	Seed       int64
+	SmallBlind int
+	BigBlind   int
+	Round      vo.BettingRound
+	Pot        int         // chips already in the pot from earlier streets
+	Board      []card.Card // community cards in deal order: those dealt by Round, then any fixed later streets
+	Seats      []SeatSetup
+	ToAct      int
+}
+
+// SeatSetup describes one seat of an OfflineSetup
+type SeatSetup struct {
+	Nickname string
+	Chips    int         // stack at the start of the current street
+	Bet      int         // amount already bet on the current street (taken from Chips)
+	Hole     []card.Card // fixed hole cards; missing cards are dealt randomly
+	Folded   bool
+}
+
+// visibleBoardCards returns how many community cards are dealt by the given round
+func visibleBoardCards(round vo.BettingRound) int {
+	switch round {
+	case vo.Flop:
+		return 3
+	case vo.Turn:
+		return 4
+	case vo.River, vo.Showdown:
+		return 5
+	default:
+		return 0
+	}
+}
+
+// Validate checks the setup for impossible spots
+func (s OfflineSetup) Validate() error {
+	if len(s.Seats) != 2 {
+		return fmt.Errorf("offline games are heads-up: expected 2 seats, got %d", len(s.Seats))
+	}
+	if s.Round < vo.PreFlop || s.Round > vo.River {
+		return fmt.Errorf("cannot start from round %s", s.Round)
+	}
+	if s.ToAct < 0 || s.ToAct >= len(s.Seats) {
+		return fmt.Errorf("seat to act %d is out of range", s.ToAct)
+	}
+	if s.SmallBlind < 0 || s.BigBlind < s.SmallBlind {
+		return errors.New("invalid blinds")
+	}
+	if s.Pot < 0 {
+		return errors.New("pot cannot be negative")
+	}
+	if want := visibleBoardCards(s.Round); len(s.Board) < want || len(s.Board) > 5 {
+		return fmt.Errorf("board has %d cards, but %s shows %d and a board holds at most 5", len(s.Board), s.Round, want)
+	}
+
+	seen := make(map[card.Card]bool)
+	use := func(c card.Card) error {
+		if seen[c] {
+			return fmt.Errorf("card %s appears more than once", c)
+		}
+		seen[c] = true
+		return nil
+	}
+
+	for i, seat := range s.Seats {
+		if len(seat.Hole) > 2 {
+			return fmt.Errorf("seat %d has %d hole cards", i, len(seat.Hole))
+		}
+		if seat.Chips < 0 || seat.Bet < 0 || seat.Bet > seat.Chips {
+			return fmt.Errorf("seat %d has an invalid stack or bet", i)
+		}
+		for _, c := range seat.Hole {
+			if err := use(c); err != nil {
+				return err
+			}
+		}
+	}
+	for _, c := range s.Board {
+		if err := use(c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// stackedSlots lays out the deck in the order the spot is dealt: hole cards
+// seat by seat, the visible board, then burn cards and the later streets,
+// fixed where the board gives them
+func (s OfflineSetup) stackedSlots() []*card.Card {
+	var slots []*card.Card
+	fixed := func(cards []card.Card, i int) *card.Card {
+		if i < len(cards) {
+			c := cards[i]
+			return &c
+		}
+		return nil
+	}
+
+	for _, seat := range s.Seats {
+		slots = append(slots, fixed(seat.Hole, 0), fixed(seat.Hole, 1))
+	}
+
+	visible := visibleBoardCards(s.Round)
+	for i := 0; i < visible; i++ {
+		slots = append(slots, fixed(s.Board, i))
+	}
+
+	for i := visible; i < 5; i++ {
+		if i == 0 || i == 3 || i == 4 {
+			slots = append(slots, nil) // burn
+		}
+		slots = append(slots, fixed(s.Board, i))
+	}
+
+	return slots
+}
+
+// NewOfflineGameFromSetup starts an offline game at the exact spot described
+// by setup. Restarting the game deals the same spot again with fresh random fill.
+func NewOfflineGameFromSetup(setup OfflineSetup) (*OfflineGame, error) {
+	if err := setup.Validate(); err != nil {
+		return nil, err
+	}
+
+	players := make([]*player.Player, len(setup.Seats))
+	for i, seat := range setup.Seats {
+		nick, err := player.NewNickname(seat.Nickname)
+		if err != nil {
+			return nil, fmt.Errorf("seat %d: %w", i, err)
+		}
+		p, err := player.NewPlayer(player.GeneratePlayerId(), nick, seat.Chips)
+		if err != nil {
+			return nil, fmt.Errorf("seat %d: %w", i, err)
+		}
+		players[i] = p
+	}
+
+	seed := setup.Seed
+	g := &OfflineGame{
+		players: players,
+		seed:    seed,
+		deckRng: rand.New(rand.NewSource(deriveSeed(seed, seedStreamDeck))),
+		bot:     NewBot(deriveSeed(seed, seedStreamBot)),
+		setup:   &setup,
+		ledger:  ledger.New(),
+	}
+
+	if err := g.dealSetup(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// dealSetup resets the table to the stored setup and deals it
+func (g *OfflineGame) dealSetup() error {
+	setup := g.setup
+
+	stacked, err := deck.NewStackedDeck(setup.stackedSlots())
+	if err != nil {
+		return err
+	}
+	if err := stacked.Shuffle(g.deckRng.Int63()); err != nil {
+		return err
+	}
+
+	g.deck = stacked
+	g.gameService = NewGameService(stacked, game.NewHandEvaluator())
+	g.communityCards = make([]card.Card, 0, 5)
+	g.round = setup.Round
+	g.pot = setup.Pot
+	g.currentBet = 0
+	g.openStreet(setup.ToAct)
+	g.gameState = game.Playing
+	g.handNumber++
+	g.handStartChips = make([]int, len(g.players))
+
+	for i, p := range g.players {
+		seat := setup.Seats[i]
+		p.ResetBet()
+		p.SetStatus(player.Waiting)
+		p.AddChips(seat.Chips - p.Chips())
+		g.handStartChips[i] = seat.Chips
+	}
+	g.openHand()
+
+	if err := g.gameService.DealHoleCards(g.players); err != nil {
+		return fmt.Errorf("failed to deal hole cards: %w", err)
+	}
+	for i := 0; i < visibleBoardCards(setup.Round); i++ {
+		c, err := stacked.DrawCard()
+		if err != nil {
+			return err
+		}
+		g.communityCards = append(g.communityCards, c)
+	}
+
+	bets := make([]int, len(g.players))
+	for i, seat := range setup.Seats {
+		bets[i] = seat.Bet
+	}
+	if setup.Round == vo.PreFlop && bets[0] == 0 && bets[1] == 0 {
+		bets[0] = minInt(setup.SmallBlind, setup.Seats[0].Chips)
+		bets[1] = minInt(setup.BigBlind, setup.Seats[1].Chips)
+	}
+
+	for i, p := range g.players {
+		if bets[i] > 0 {
+			reason := ledger.ReasonBet
+			if setup.Round == vo.PreFlop && setup.Seats[i].Bet == 0 {
+				reason = ledger.ReasonBlind
+			}
+			if err := g.commit(p, bets[i], reason); err != nil {
+				return fmt.Errorf("seat %d: %w", i, err)
+			}
+			if bets[i] > g.currentBet {
+				g.currentBet = bets[i]
+			}
+		}
+		switch {
+		case setup.Seats[i].Folded:
+			p.Fold()
+		case p.Chips() == 0:
+			p.SetStatus(player.AllIn)
+		}
+	}
+
+	// A seat facing a bet leaves the bettor to close the street; blinds
+	// alone still give the big blind its option
+	if g.players[setup.ToAct].Bet() < g.currentBet {
+		for i, seat := range setup.Seats {
+			if seat.Bet == g.currentBet && !(setup.Round == vo.PreFlop && seat.Bet <= setup.BigBlind) {
+				g.aggressor = i
+			}
+		}
+	}
+	g.resetUndo()
+
+	return nil
+}
+
+// IsScenario reports whether the game was started from a setup
+func (g *OfflineGame) IsScenario() bool {
+	return g.setup != nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
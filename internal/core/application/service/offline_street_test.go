@@ -0,0 +1,110 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/card"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/game/vo"
+)
+
+func streetSetup(t *testing.T, toAct int, bets ...int) *OfflineGame {
+	t.Helper()
+	var board []card.Card
+	for _, s := range []string{"2h", "9h", "Kc", "4d"} {
+		c, err := card.ParseCard(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		board = append(board, c)
+	}
+	setup := OfflineSetup{
+		SmallBlind: 10,
+		BigBlind:   20,
+		Round:      vo.Turn,
+		Pot:        100,
+		Board:      board,
+		ToAct:      toAct,
+		Seats:      []SeatSetup{{Nickname: "Hero", Chips: 500}, {Nickname: "AI Player", Chips: 500}},
+	}
+	for i, bet := range bets {
+		setup.Seats[i].Bet = bet
+	}
+	g, err := NewOfflineGameFromSetup(setup)
+	if err != nil {
+		t.Fatalf("NewOfflineGameFromSetup failed: %v", err)
+	}
+	return g
+}
+
+func act(t *testing.T, g *OfflineGame, seat int, action vo.PlayerAction, amount int) {
+	t.Helper()
+	if g.StreetComplete() {
+		t.Fatalf("street closed before seat %d could %s", seat, action)
+	}
+	if err := g.PlayerAction(seat, action, amount); err != nil {
+		t.Fatalf("seat %d %s failed: %v", seat, action, err)
+	}
+}
+
+func TestStreetComplete_BotOpens(t *testing.T) {
+	// The bot checking first does not close the street; the hero still acts
+	g := streetSetup(t, 1)
+	act(t, g, 1, vo.Check, 0)
+	act(t, g, 0, vo.Check, 0)
+	if !g.StreetComplete() {
+		t.Fatal("expected the street to close after both checked")
+	}
+
+	// A bet after a check goes back to the player who checked
+	g = streetSetup(t, 1)
+	act(t, g, 1, vo.Check, 0)
+	act(t, g, 0, vo.Raise, 50)
+	act(t, g, 1, vo.Call, 0)
+	if !g.StreetComplete() {
+		t.Fatal("expected the call to close the street")
+	}
+}
+
+func TestStreetComplete_FacingBet(t *testing.T) {
+	// The bot bet 50 before the spot; calling it closes the street
+	g := streetSetup(t, 0, 0, 50)
+	act(t, g, 0, vo.Call, 0)
+	if !g.StreetComplete() {
+		t.Fatal("expected calling the bet to close the street")
+	}
+
+	// A raise reopens the action for the bettor only
+	g = streetSetup(t, 0, 0, 50)
+	act(t, g, 0, vo.Raise, 150)
+	act(t, g, 1, vo.Call, 0)
+	if !g.StreetComplete() {
+		t.Fatal("expected calling the raise to close the street")
+	}
+
+	if err := g.ProgressRound(); err != nil {
+		t.Fatalf("ProgressRound failed: %v", err)
+	}
+	if g.StreetComplete() || g.GetGameState().CurrentPlayer != 0 {
+		t.Fatal("expected the river to open with the hero to act")
+	}
+}
+
+func TestStreetComplete_PreFlop(t *testing.T) {
+	// The big blind keeps its option after the small blind calls
+	g := NewOfflineGameWithSeed("Hero", 1)
+	g.Start()
+	act(t, g, 0, vo.Call, 0)
+	act(t, g, 1, vo.Check, 0)
+	if !g.StreetComplete() {
+		t.Fatal("expected the big blind's check to close the street")
+	}
+
+	// A check then a bet on the flop goes back to the checker
+	g.ProgressRound()
+	act(t, g, 0, vo.Check, 0)
+	act(t, g, 1, vo.Raise, 40)
+	act(t, g, 0, vo.Call, 0)
+	if !g.StreetComplete() {
+		t.Fatal("expected the call to close the flop")
+	}
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/card"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/game"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/game/vo"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/player"
+)
+
+// SessionMode tells how an offline session counts
+type SessionMode int
+
+const (
+	// ModePractice is free play against the AI; actions can be taken back
+	ModePractice SessionMode = iota
+	// ModeScored counts toward results, so undo is disabled
+	ModeScored
+	// ModeTournament is a tournament session, so undo is disabled
+	ModeTournament
+)
+
+// String returns the mode's name as used on the command line and in hand
+// history
+func (m SessionMode) String() string {
+	switch m {
+	case ModeScored:
+		return "scored"
+	case ModeTournament:
+		return "tournament"
+	default:
+		return "practice"
+	}
+}
+
+var (
+	ErrUndoDisabled  = errors.New("undo is only available in practice sessions")
+	ErrNothingToUndo = errors.New("nothing to undo")
+)
+
+// undoKind marks what an undo snapshot was taken before
+type undoKind int
+
+const (
+	undoStreet undoKind = iota // start of a betting round
+	undoAction                 // a user action
+)
+
+// offlineSnapshot captures the hand state needed to take back actions
+type offlineSnapshot struct {
+	kind           undoKind
+	players        []*player.Player
+	deck           []card.Card
+	communityCards []card.Card
+	round          vo.BettingRound
+	pot            int
+	currentBet     int
+	currentPlayer  int
+	aggressor      int
+	streetActions  int
+	gameState      game.GameState
+	events         int
+	ledgerEntries  int
+	botDraws       uint64
+}
+
+// SetMode sets how the session counts; leaving practice drops undo history
+func (g *OfflineGame) SetMode(mode SessionMode) {
+	g.mode = mode
+	if mode != ModePractice {
+		g.undo = nil
+	}
+}
+
+// Mode returns how the session counts
+func (g *OfflineGame) Mode() SessionMode {
+	return g.mode
+}
+
+// CanUndo reports whether the last user action can be taken back
+func (g *OfflineGame) CanUndo() bool {
+	return g.lastUndo(undoAction) >= 0
+}
+
+// CanRewindStreet reports whether the current street can be restarted
+func (g *OfflineGame) CanRewindStreet() bool {
+	return g.lastUndo(undoStreet) >= 0
+}
+
+// UndoLastAction takes back the user's last action along with any AI
+// actions and deals that followed it
+func (g *OfflineGame) UndoLastAction() error {
+	if g.mode != ModePractice {
+		return ErrUndoDisabled
+	}
+
+	i := g.lastUndo(undoAction)
+	if i < 0 {
+		return ErrNothingToUndo
+	}
+
+	g.restoreUndo(g.undo[i])
+	g.undo = g.undo[:i]
+	return nil
+}
+
+// RewindStreet puts the hand back to the start of the current betting round
+func (g *OfflineGame) RewindStreet() error {
+	if g.mode != ModePractice {
+		return ErrUndoDisabled
+	}
+
+	i := g.lastUndo(undoStreet)
+	if i < 0 {
+		return ErrNothingToUndo
+	}
+
+	g.restoreUndo(g.undo[i])
+	g.undo = g.undo[:i+1]
+	return nil
+}
+
+// lastUndo returns the index of the newest snapshot of the given kind, or -1
+func (g *OfflineGame) lastUndo(kind undoKind) int {
+	for i := len(g.undo) - 1; i >= 0; i-- {
+		if g.undo[i].kind == kind {
+			return i
+		}
+	}
+	return -1
+}
+
+// resetUndo starts a fresh undo history at the beginning of a hand
+func (g *OfflineGame) resetUndo() {
+	g.undo = nil
+	g.pushUndo(g.captureUndo(undoStreet))
+}
+
+// pushUndo records a snapshot taken by captureUndo
+func (g *OfflineGame) pushUndo(s *offlineSnapshot) {
+	if s != nil {
+		g.undo = append(g.undo, *s)
+	}
+}
+
+// captureUndo snapshots the hand, or returns nil when undo is unavailable
+func (g *OfflineGame) captureUndo(kind undoKind) *offlineSnapshot {
+	d, ok := g.deck.(card.RestorableDeck)
+	if g.mode != ModePractice || !ok {
+		return nil
+	}
+
+	s := &offlineSnapshot{
+		kind:           kind,
+		players:        make([]*player.Player, len(g.players)),
+		deck:           d.Snapshot(),
+		communityCards: append([]card.Card(nil), g.communityCards...),
+		round:          g.round,
+		pot:            g.pot,
+		currentBet:     g.currentBet,
+		currentPlayer:  g.currentPlayer,
+		aggressor:      g.aggressor,
+		streetActions:  g.streetActions,
+		gameState:      g.gameState,
+		events:         len(g.events),
+		ledgerEntries:  g.ledger.Len(g.handID),
+		botDraws:       g.bot.draws(),
+	}
+	for i, p := range g.players {
+		s.players[i] = p.Clone()
+	}
+	return s
+}
+
+// restoreUndo puts the hand back to a snapshot. Ledger transfers are
+// reversed with UNDO entries so the hand still audits, and the replay log
+// and bot randomness are rewound so the replay token stays valid.
+func (g *OfflineGame) restoreUndo(s offlineSnapshot) {
+	for i, p := range g.players {
+		p.Restore(s.players[i])
+	}
+	g.deck.(card.RestorableDeck).Restore(s.deck)
+	g.communityCards = append([]card.Card(nil), s.communityCards...)
+	g.round = s.round
+	g.pot = s.pot
+	g.currentBet = s.currentBet
+	g.currentPlayer = s.currentPlayer
+	g.aggressor = s.aggressor
+	g.streetActions = s.streetActions
+	g.gameState = s.gameState
+	g.events = g.events[:s.events]
+	g.ledger.Revert(g.handID, s.ledgerEntries)
+	g.bot.rewind(s.botDraws)
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/game/vo"
+)
+
+func TestOfflineGame_UndoAllIn(t *testing.T) {
+	g := NewOfflineGameWithSeed("Hero", 5)
+	if err := g.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	before := g.GetGameState()
+
+	if err := g.PlayerAction(0, vo.AllIn, 0); err != nil {
+		t.Fatalf("AllIn failed: %v", err)
+	}
+	if err := g.UndoLastAction(); err != nil {
+		t.Fatalf("UndoLastAction failed: %v", err)
+	}
+
+	if after := g.GetGameState(); !reflect.DeepEqual(before, after) {
+		t.Fatalf("Undo did not restore the hand:\nbefore %+v\nafter  %+v", before, after)
+	}
+	if err := g.UndoLastAction(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Expected ErrNothingToUndo, got %v", err)
+	}
+}
+
+func TestOfflineGame_RewindStreetRestoresDeck(t *testing.T) {
+	g := NewOfflineGameWithSeed("Hero", 9)
+	if err := g.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	g.PlayerAction(0, vo.Call, 0)
+	g.PlayerAction(1, vo.Check, 0)
+	if err := g.ProgressRound(); err != nil {
+		t.Fatalf("ProgressRound failed: %v", err)
+	}
+	flop := g.GetGameState()
+	remaining := g.deck.RemainingCards()
+
+	g.PlayerAction(0, vo.Raise, 100)
+	g.PlayerAction(1, vo.Call, 0)
+
+	if err := g.RewindStreet(); err != nil {
+		t.Fatalf("RewindStreet failed: %v", err)
+	}
+	if state := g.GetGameState(); !reflect.DeepEqual(flop, state) || g.deck.RemainingCards() != remaining {
+		t.Fatalf("Rewind did not restore the flop:\nwant %+v\ngot  %+v", flop, state)
+	}
+
+	// The hand still audits after the rewound transfers
+	for g.round != vo.Showdown {
+		if err := g.ProgressRound(); err != nil {
+			t.Fatalf("Hand failed to audit after rewind: %v", err)
+		}
+	}
+}
+
+func TestOfflineGame_UndoKeepsReplayValid(t *testing.T) {
+	g := NewOfflineGameWithSeed("Hero", 21)
+	if err := g.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	g.PlayerAction(0, vo.Call, 0)
+	action, amount := g.BotAction(1)
+	g.PlayerAction(1, action, amount)
+	if err := g.UndoLastAction(); err != nil {
+		t.Fatalf("UndoLastAction failed: %v", err)
+	}
+	playScripted(t, g)
+
+	token, err := g.ReplayToken()
+	if err != nil {
+		t.Fatalf("ReplayToken failed: %v", err)
+	}
+	replayed, err := ReplayOfflineGame("Hero", token)
+	if err != nil {
+		t.Fatalf("ReplayOfflineGame failed: %v", err)
+	}
+	if !reflect.DeepEqual(g.GetGameState(), replayed.GetGameState()) {
+		t.Error("Replay diverged after an undo")
+	}
+}
+
+func TestOfflineGame_UndoDisabledOutsidePractice(t *testing.T) {
+	for _, mode := range []SessionMode{ModeScored, ModeTournament} {
+		g := NewOfflineGameWithSeed("Hero", 1)
+		g.SetMode(mode)
+		if err := g.Start(); err != nil {
+			t.Fatalf("Start failed: %v", err)
+		}
+		g.PlayerAction(0, vo.AllIn, 0)
+
+		if g.CanUndo() || g.CanRewindStreet() {
+			t.Errorf("mode %d: undo should be unavailable", mode)
+		}
+		if err := g.UndoLastAction(); !errors.Is(err, ErrUndoDisabled) {
+			t.Errorf("mode %d: expected ErrUndoDisabled, got %v", mode, err)
+		}
+		if err := g.RewindStreet(); !errors.Is(err, ErrUndoDisabled) {
+			t.Errorf("mode %d: expected ErrUndoDisabled, got %v", mode, err)
+		}
+	}
+}
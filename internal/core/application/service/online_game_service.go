@@ -1,50 +1,202 @@
-//go:build ignore
-// +build ignore
-
 // Package service provides application services
 package service
 
 import (
-	"github.com/bunnyholes/pokerhole/client/internal/adapter/out/websocket"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/bunnyholes/pokerhole/client/internal/adapter/out/deck"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/card"
 	"github.com/bunnyholes/pokerhole/client/internal/core/domain/game"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/game/vo"
 	"github.com/bunnyholes/pokerhole/client/internal/core/domain/player"
 )
 
+var (
+	// ErrNotInGame is returned when acting before the server seated us
+	ErrNotInGame = errors.New("not seated in an online game")
+	// ErrNotYourTurn is returned when acting while another player is to act
+	ErrNotYourTurn = errors.New("not your turn")
+	// ErrActionNotAllowed is returned for actions the server did not offer
+	ErrActionNotAllowed = errors.New("action not allowed by server")
+)
+
+// GameServer is the outbound port to the game server (Port)
+// Implementations: websocket.Client (adapter)
+type GameServer interface {
+	// PlayerID returns the ID the server knows us by
+	PlayerID() string
+
+	// JoinRandomMatch asks the server to seat us at a random table
+	JoinRandomMatch() error
+
+	// SendAction sends a betting action and returns its request ID
+	SendAction(action vo.PlayerAction, amount int) (string, error)
+
+	// RequestSync asks the server to resend the full game state
+	RequestSync(gameID string) error
+
+	// Table returns the latest game state reported by the server
+	Table() RemoteTable
+
+	// DealtCards returns the cards revealed to us in deal order
+	DealtCards() []card.Card
+}
+
+// RemoteTable is the server's view of a game in domain terms
+type RemoteTable struct {
+	GameID        string
+	Round         string
+	Pot           int
+	CurrentBet    int
+	Board         []card.Card
+	Seats         []RemoteSeat
+	CurrentPlayer string // player ID, empty between turns
+	ValidActions  []vo.PlayerAction
+	Ended         bool
+}
+
+// RemoteSeat is one player at a remote table
+type RemoteSeat struct {
+	ID       string
+	Nickname string
+	Chips    int
+	Bet      int
+	Status   string
+	Hole     []card.Card // only ours, or everyone's at showdown
+}
+
 // OnlineGameService handles online game mode (Application Service)
 type OnlineGameService struct {
-	client      *websocket.Client
+	server      GameServer
+	deck        *deck.RemoteDeck
 	gameService *GameService
 }
 
-// NewOnlineGameService creates a new OnlineGameService
-func NewOnlineGameService(client *websocket.Client, gameService *GameService) *OnlineGameService {
+// NewOnlineGameService creates a new OnlineGameService dealing from the
+// cards the server reveals
+func NewOnlineGameService(server GameServer) *OnlineGameService {
+	remoteDeck := deck.NewRemoteDeck(server)
 	return &OnlineGameService{
-		client:      client,
-		gameService: gameService,
+		server:      server,
+		deck:        remoteDeck,
+		gameService: NewGameService(remoteDeck, game.NewHandEvaluator()),
 	}
 }
 
-// JoinGame joins an online game
+// Compile-time check: OnlineGameService is a Game
+var _ Game = (*OnlineGameService)(nil)
+
+// JoinGame joins a random online game as the given player
 func (s *OnlineGameService) JoinGame(playerID player.PlayerId) error {
-	// TODO: implement
-	// 1. Send JOIN message to server
-	// 2. Wait for confirmation
-	return nil
-}
-
-// ExecuteAction sends action to server (online mode)
-func (s *OnlineGameService) ExecuteAction(gameID game.GameId, playerID player.PlayerId, action game.PlayerAction, amount int) error {
-	// TODO: implement
-	// 1. Validate action locally (UX)
-	// 2. Send action to server
-	// 3. Wait for server confirmation
-	return nil
-}
-
-// SyncGameState syncs game state from server
-func (s *OnlineGameService) SyncGameState(gameID game.GameId) error {
-	// TODO: implement
-	// 1. Request game state from server
-	// 2. Update local state
-	return nil
+	if id := s.server.PlayerID(); id != playerID.String() {
+		return fmt.Errorf("player %s is registered as %s", playerID, id)
+	}
+	return s.server.JoinRandomMatch()
+}
+
+// ExecuteAction sends an action to the server after checking it is one the
+// server offered us. The server's reply settles the returned request ID.
+func (s *OnlineGameService) ExecuteAction(action vo.PlayerAction, amount int) (string, error) {
+	table := s.server.Table()
+	switch {
+	case table.GameID == "" || table.Ended:
+		return "", ErrNotInGame
+	case table.CurrentPlayer != s.server.PlayerID():
+		return "", ErrNotYourTurn
+	case !slices.Contains(table.ValidActions, action):
+		return "", fmt.Errorf("%w: %s", ErrActionNotAllowed, action)
+	}
+	return s.server.SendAction(action, amount)
+}
+
+// SyncGameState asks the server to resend the current game
+func (s *OnlineGameService) SyncGameState() error {
+	table := s.server.Table()
+	if table.GameID == "" {
+		return ErrNotInGame
+	}
+	return s.server.RequestSync(table.GameID)
+}
+
+// HoleCards returns our hole cards as dealt by the server
+func (s *OnlineGameService) HoleCards() ([]card.Card, error) {
+	seat := s.LocalSeat()
+	if seat < 0 || len(s.server.Table().Seats[seat].Hole) < 2 {
+		return nil, deck.ErrNotDealt
+	}
+
+	s.deck.Reset()
+	cards := make([]card.Card, 2)
+	for i := range cards {
+		c, err := s.deck.DrawCard()
+		if err != nil {
+			return nil, err
+		}
+		cards[i] = c
+	}
+	return cards, nil
+}
+
+// GetGameState returns the server's state as a snapshot
+func (s *OnlineGameService) GetGameState() GameStateSnapshot {
+	table := s.server.Table()
+	snapshot := GameStateSnapshot{
+		Round:          table.Round,
+		Pot:            table.Pot,
+		CurrentBet:     table.CurrentBet,
+		CommunityCards: formatCards(table.Board),
+		Players:        make([]PlayerSnapshot, len(table.Seats)),
+		CurrentPlayer:  -1,
+		WinnerIndex:    -1,
+	}
+
+	for i, seat := range table.Seats {
+		snapshot.Players[i] = PlayerSnapshot{
+			Nickname:  seat.Nickname,
+			Chips:     seat.Chips,
+			Bet:       seat.Bet,
+			Status:    seat.Status,
+			Hand:      card.NewHand(seat.Hole).String(),
+			BestCards: []string{},
+		}
+		if seat.ID == table.CurrentPlayer {
+			snapshot.CurrentPlayer = i
+		}
+		if len(seat.Hole) == 2 && len(table.Board) >= 3 {
+			if result, err := s.gameService.HandEvaluator.Evaluate(seat.Hole, table.Board); err == nil {
+				snapshot.Players[i].HandRank = result.String()
+				snapshot.Players[i].BestCards = formatCards(result.GetRankCards())
+			}
+		}
+	}
+
+	return snapshot
+}
+
+// LocalSeat returns our seat index, or -1 before we are seated
+func (s *OnlineGameService) LocalSeat() int {
+	id := s.server.PlayerID()
+	for i, seat := range s.server.Table().Seats {
+		if seat.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// ValidActions returns the actions the server offers us, if it is our turn
+func (s *OnlineGameService) ValidActions() []vo.PlayerAction {
+	table := s.server.Table()
+	if table.Ended || table.CurrentPlayer != s.server.PlayerID() {
+		return nil
+	}
+	return slices.Clone(table.ValidActions)
+}
+
+// Act sends our action to the server
+func (s *OnlineGameService) Act(action vo.PlayerAction, amount int) error {
+	_, err := s.ExecuteAction(action, amount)
+	return err
 }
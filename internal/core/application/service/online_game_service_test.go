@@ -0,0 +1,129 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/card"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/game/vo"
+)
+
+// fakeServer is an in-memory GameServer
+type fakeServer struct {
+	table RemoteTable
+	sent  []vo.PlayerAction
+	syncs []string
+}
+
+func (f *fakeServer) PlayerID() string       { return "me" }
+func (f *fakeServer) JoinRandomMatch() error { return nil }
+func (f *fakeServer) Table() RemoteTable     { return f.table }
+func (f *fakeServer) RequestSync(id string) error {
+	f.syncs = append(f.syncs, id)
+	return nil
+}
+
+func (f *fakeServer) SendAction(action vo.PlayerAction, amount int) (string, error) {
+	f.sent = append(f.sent, action)
+	return "req-1", nil
+}
+
+func (f *fakeServer) DealtCards() []card.Card {
+	var dealt []card.Card
+	for _, s := range f.table.Seats {
+		if s.ID == "me" {
+			dealt = append(dealt, s.Hole...)
+		}
+	}
+	return append(dealt, f.table.Board...)
+}
+
+func mustCards(t *testing.T, notation ...string) []card.Card {
+	t.Helper()
+	cards := make([]card.Card, len(notation))
+	for i, s := range notation {
+		c, err := card.ParseCard(s)
+		if err != nil {
+			t.Fatalf("ParseCard(%q) failed: %v", s, err)
+		}
+		cards[i] = c
+	}
+	return cards
+}
+
+func TestOnlineGameService_ExecuteAction(t *testing.T) {
+	server := &fakeServer{table: RemoteTable{
+		GameID: "game-1",
+		Round:  "FLOP",
+		Board:  mustCards(t, "2c", "7d", "Jh"),
+		Seats: []RemoteSeat{
+			{ID: "p2", Nickname: "QuietFox", Chips: 900},
+			{ID: "me", Nickname: "Hero", Chips: 900, Hole: mustCards(t, "Jc", "Js")},
+		},
+		CurrentPlayer: "p2",
+		ValidActions:  []vo.PlayerAction{vo.Fold, vo.Call},
+	}}
+	svc := NewOnlineGameService(server)
+
+	if _, err := svc.ExecuteAction(vo.Call, 0); !errors.Is(err, ErrNotYourTurn) {
+		t.Fatalf("expected ErrNotYourTurn, got %v", err)
+	}
+
+	server.table.CurrentPlayer = "me"
+	if _, err := svc.ExecuteAction(vo.Check, 0); !errors.Is(err, ErrActionNotAllowed) {
+		t.Fatalf("expected ErrActionNotAllowed, got %v", err)
+	}
+	if id, err := svc.ExecuteAction(vo.Call, 0); err != nil || id != "req-1" || len(server.sent) != 1 {
+		t.Fatalf("expected call sent, got %q %v (sent %v)", id, err, server.sent)
+	}
+
+	if err := svc.SyncGameState(); err != nil || len(server.syncs) != 1 || server.syncs[0] != "game-1" {
+		t.Fatalf("expected sync for game-1, got %v %v", err, server.syncs)
+	}
+
+	hole, err := svc.HoleCards()
+	if err != nil || hole[0].String() != "♣J" || hole[1].String() != "♠J" {
+		t.Fatalf("expected server-dealt hole cards, got %v (%v)", hole, err)
+	}
+}
+
+func TestGame_OfflineAndOnlineShareInterface(t *testing.T) {
+	offline := NewOfflineGameWithSeed("Hero", 7)
+	if err := offline.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	server := &fakeServer{table: RemoteTable{
+		GameID: "game-1",
+		Round:  "FLOP",
+		Board:  mustCards(t, "2c", "7d", "Jh"),
+		Seats: []RemoteSeat{
+			{ID: "p2", Nickname: "QuietFox", Chips: 900},
+			{ID: "me", Nickname: "Hero", Chips: 900, Hole: mustCards(t, "Jc", "Js")},
+		},
+		CurrentPlayer: "me",
+		ValidActions:  []vo.PlayerAction{vo.Fold, vo.Call, vo.Raise},
+	}}
+
+	for name, g := range map[string]Game{"offline": offline, "online": NewOnlineGameService(server)} {
+		seat := g.LocalSeat()
+		state := g.GetGameState()
+		if state.CurrentPlayer != seat {
+			t.Fatalf("%s: expected local seat %d to act, got %d", name, seat, state.CurrentPlayer)
+		}
+		if !slices.Contains(g.ValidActions(), vo.Call) {
+			t.Fatalf("%s: expected call to be offered, got %v", name, g.ValidActions())
+		}
+		if err := g.Act(vo.Call, 0); err != nil {
+			t.Fatalf("%s: Act failed: %v", name, err)
+		}
+	}
+
+	if got := NewOnlineGameService(server).GetGameState().Players[1].HandRank; got == "" {
+		t.Error("expected our hand evaluated against the board")
+	}
+	if offline.ValidActions() != nil {
+		t.Error("offline user should not act twice in a row")
+	}
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/game/vo"
+)
+
+// Seed streams keep deck shuffles and bot decisions independent of each other
+const (
+	seedStreamDeck uint64 = iota + 1
+	seedStreamBot
+)
+
+// replayTokenPrefix identifies the token format version
+const replayTokenPrefix = "PH1."
+
+// ErrScenarioReplay is returned for replay tokens of scenario games. The
+// token holds only the seed and events, and replaying them against a
+// normal deal would reproduce a different game.
+var ErrScenarioReplay = errors.New("scenario games have no replay token")
+
+// EventKind identifies an entry in the offline game event log
+type EventKind byte
+
+const (
+	EventAction   EventKind = iota // A player action
+	EventProgress                  // Betting round advanced
+	EventRestart                   // A new hand was dealt
+)
+
+// GameEvent is one recorded step of an offline game
+type GameEvent struct {
+	Kind   EventKind
+	Seat   int
+	Action vo.PlayerAction
+	Amount int
+}
+
+// Events returns a copy of the recorded event log
+func (g *OfflineGame) Events() []GameEvent {
+	events := make([]GameEvent, len(g.events))
+	copy(events, g.events)
+	return events
+}
+
+// ReplayToken encodes the session seed and event log as a compact,
+// copy-pasteable string suitable for bug reports
+func (g *OfflineGame) ReplayToken() (string, error) {
+	if g.IsScenario() {
+		return "", ErrScenarioReplay
+	}
+	return EncodeReplayToken(g.seed, g.events), nil
+}
+
+// EncodeReplayToken encodes a seed and event list
+func EncodeReplayToken(seed int64, events []GameEvent) string {
+	buf := binary.AppendVarint(nil, seed)
+	buf = binary.AppendUvarint(buf, uint64(len(events)))
+
+	for _, e := range events {
+		buf = append(buf, byte(e.Kind)<<6|byte(e.Seat&0x07)<<3|byte(e.Action&0x07))
+		if e.Kind == EventAction && e.Action == vo.Raise {
+			buf = binary.AppendUvarint(buf, uint64(e.Amount))
+		}
+	}
+
+	return replayTokenPrefix + base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// DecodeReplayToken parses a token produced by EncodeReplayToken
+func DecodeReplayToken(token string) (int64, []GameEvent, error) {
+	token = strings.TrimSpace(token)
+	if !strings.HasPrefix(token, replayTokenPrefix) {
+		return 0, nil, errors.New("unsupported replay token format")
+	}
+
+	buf, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(token, replayTokenPrefix))
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid replay token: %w", err)
+	}
+
+	seed, n := binary.Varint(buf)
+	if n <= 0 {
+		return 0, nil, errors.New("invalid replay token: missing seed")
+	}
+	buf = buf[n:]
+
+	count, n := binary.Uvarint(buf)
+	if n <= 0 || count > uint64(len(buf)) {
+		return 0, nil, errors.New("invalid replay token: bad event count")
+	}
+	buf = buf[n:]
+
+	events := make([]GameEvent, 0, count)
+	for i := uint64(0); i < count; i++ {
+		if len(buf) == 0 {
+			return 0, nil, errors.New("invalid replay token: truncated")
+		}
+		b := buf[0]
+		buf = buf[1:]
+
+		e := GameEvent{
+			Kind:   EventKind(b >> 6),
+			Seat:   int(b >> 3 & 0x07),
+			Action: vo.PlayerAction(b & 0x07),
+		}
+		if e.Kind > EventRestart || (e.Kind == EventAction && !e.Action.IsValid()) {
+			return 0, nil, fmt.Errorf("invalid replay token: bad event %d", i)
+		}
+		if e.Kind == EventAction && e.Action == vo.Raise {
+			amount, n := binary.Uvarint(buf)
+			if n <= 0 {
+				return 0, nil, errors.New("invalid replay token: truncated amount")
+			}
+			e.Amount = int(amount)
+			buf = buf[n:]
+		}
+		events = append(events, e)
+	}
+
+	return seed, events, nil
+}
+
+// ReplayOfflineGame rebuilds a game from a replay token by starting a game
+// with the recorded seed and re-applying every event in order
+func ReplayOfflineGame(userNickname, token string) (*OfflineGame, error) {
+	seed, events, err := DecodeReplayToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	g := NewOfflineGameWithSeed(userNickname, seed)
+	g.replayed = true
+	if err := g.Start(); err != nil {
+		return nil, err
+	}
+
+	for i, e := range events {
+		switch e.Kind {
+		case EventAction:
+			if e.Seat != 0 {
+				// Keep the bot's random stream aligned with the original session
+				g.BotAction(e.Seat)
+			}
+			err = g.PlayerAction(e.Seat, e.Action, e.Amount)
+		case EventProgress:
+			err = g.ProgressRound()
+		case EventRestart:
+			err = g.Restart()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("replay failed at event %d: %w", i, err)
+		}
+	}
+
+	return g, nil
+}
+
+// IsReplay reports whether the game was rebuilt from a replay token
+func (g *OfflineGame) IsReplay() bool {
+	return g.replayed
+}
+
+// deriveSeed mixes a session seed with a stream id (splitmix64)
+func deriveSeed(seed int64, stream uint64) int64 {
+	z := uint64(seed) + stream*0x9E3779B97F4A7C15
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return int64(z ^ (z >> 31))
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/game/vo"
+)
+
+// playScripted plays one hand where the user always calls or checks and the
+// bot decides for itself, then starts the next hand
+func playScripted(t *testing.T, g *OfflineGame) {
+	t.Helper()
+
+	for g.round != vo.Showdown {
+		for turn := 0; turn < 6; turn++ {
+			seat := g.currentPlayer
+			action, amount := vo.Check, 0
+			if seat == 0 {
+				if g.currentBet > g.players[0].Bet() {
+					action = vo.Call
+				}
+			} else {
+				action, amount = g.BotAction(seat)
+			}
+			if err := g.PlayerAction(seat, action, amount); err != nil {
+				t.Fatalf("PlayerAction failed: %v", err)
+			}
+			if g.currentPlayer == 0 && g.players[0].Bet() == g.players[1].Bet() {
+				break
+			}
+		}
+		if err := g.ProgressRound(); err != nil {
+			t.Fatalf("ProgressRound failed: %v", err)
+		}
+	}
+}
+
+func TestOfflineGame_SameSeedReproducesGame(t *testing.T) {
+	run := func() (*OfflineGame, GameStateSnapshot) {
+		g := NewOfflineGameWithSeed("TestPlayer", 20251019)
+		if err := g.Start(); err != nil {
+			t.Fatalf("Start failed: %v", err)
+		}
+		playScripted(t, g)
+		if err := g.Restart(); err != nil {
+			t.Fatalf("Restart failed: %v", err)
+		}
+		playScripted(t, g)
+		return g, g.GetGameState()
+	}
+
+	g1, s1 := run()
+	g2, s2 := run()
+
+	if !reflect.DeepEqual(s1, s2) {
+		t.Errorf("Snapshots differ for the same seed:\n%+v\n%+v", s1, s2)
+	}
+	if !reflect.DeepEqual(g1.Events(), g2.Events()) {
+		t.Error("Event logs (including bot decisions) differ for the same seed")
+	}
+}
+
+func TestOfflineGame_DifferentSeedsDealDifferentCards(t *testing.T) {
+	g1 := NewOfflineGameWithSeed("TestPlayer", 1)
+	g2 := NewOfflineGameWithSeed("TestPlayer", 2)
+	g1.Start()
+	g2.Start()
+
+	if g1.players[0].Hand().String() == g2.players[0].Hand().String() &&
+		g1.players[1].Hand().String() == g2.players[1].Hand().String() {
+		t.Error("Different seeds dealt identical hands")
+	}
+}
+
+func TestReplayToken_RoundTrip(t *testing.T) {
+	events := []GameEvent{
+		{Kind: EventAction, Seat: 0, Action: vo.Call},
+		{Kind: EventAction, Seat: 1, Action: vo.Raise, Amount: 70},
+		{Kind: EventAction, Seat: 0, Action: vo.AllIn},
+		{Kind: EventProgress},
+		{Kind: EventRestart},
+	}
+
+	token := EncodeReplayToken(-987654321, events)
+	seed, decoded, err := DecodeReplayToken(token)
+	if err != nil {
+		t.Fatalf("DecodeReplayToken failed: %v", err)
+	}
+	if seed != -987654321 {
+		t.Errorf("Expected seed -987654321, got %d", seed)
+	}
+	if !reflect.DeepEqual(events, decoded) {
+		t.Errorf("Events differ after round trip:\n%+v\n%+v", events, decoded)
+	}
+
+	if _, _, err := DecodeReplayToken("garbage"); err == nil {
+		t.Error("Expected error for malformed token")
+	}
+	if _, _, err := DecodeReplayToken(token[:len(token)-2]); err == nil {
+		t.Error("Expected error for truncated token")
+	}
+}
+
+func TestReplayOfflineGame_ReproducesState(t *testing.T) {
+	g := NewOfflineGameWithSeed("TestPlayer", 77)
+	if err := g.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	playScripted(t, g)
+	if err := g.Restart(); err != nil {
+		t.Fatalf("Restart failed: %v", err)
+	}
+	if err := g.PlayerAction(0, vo.Call, 0); err != nil {
+		t.Fatalf("PlayerAction failed: %v", err)
+	}
+
+	token, err := g.ReplayToken()
+	if err != nil {
+		t.Fatalf("ReplayToken failed: %v", err)
+	}
+	replayed, err := ReplayOfflineGame("TestPlayer", token)
+	if err != nil {
+		t.Fatalf("ReplayOfflineGame failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(g.GetGameState(), replayed.GetGameState()) {
+		t.Errorf("Replayed state differs:\n%+v\n%+v", g.GetGameState(), replayed.GetGameState())
+	}
+
+	// The bot's random stream must continue identically after the replay
+	a1, amt1 := g.BotAction(1)
+	a2, amt2 := replayed.BotAction(1)
+	if a1 != a2 || amt1 != amt2 {
+		t.Errorf("Bot diverged after replay: %v/%d vs %v/%d", a1, amt1, a2, amt2)
+	}
+}
+
+func TestPlayBot_RejectedActionDoesNotDraw(t *testing.T) {
+	g := NewOfflineGameWithSeed("TestPlayer", 77)
+	if err := g.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	// A bot whose first roll raises, sitting on a bet the raise cannot top
+	seed := int64(1)
+	for rand.New(rand.NewSource(seed)).Float64() >= botRaiseChance {
+		seed++
+	}
+	g.bot = NewBot(seed)
+	if err := g.players[1].PlaceBet(botRaiseSize + 10); err != nil {
+		t.Fatalf("PlaceBet failed: %v", err)
+	}
+	g.currentBet = g.players[1].Bet() - botRaiseSize
+
+	events := g.Events()
+	if _, _, err := g.PlayBot(1); err == nil {
+		t.Fatal("expected the bot's raise to be rejected")
+	}
+	if g.bot.draws() != 0 || !reflect.DeepEqual(g.Events(), events) {
+		t.Errorf("a rejected action moved the bot by %d draws or logged an event", g.bot.draws())
+	}
+}
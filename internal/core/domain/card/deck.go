@@ -17,3 +17,15 @@ type DeckPort interface {
 	// Reset resets the deck to 52 cards
 	Reset() error
 }
+
+// RestorableDeck is a deck whose remaining cards can be saved and restored,
+// used to take back actions in offline practice
+type RestorableDeck interface {
+	DeckPort
+
+	// Snapshot returns the remaining cards in draw order
+	Snapshot() []Card
+
+	// Restore replaces the remaining cards with a previous snapshot
+	Restore(cards []Card)
+}
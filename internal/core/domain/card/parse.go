@@ -0,0 +1,58 @@
+// Package card provides core card domain types
+package card
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseCard parses card notation such as "Ah", "TD", "10c", "♠A" or "A♠"
+func ParseCard(s string) (Card, error) {
+	runes := []rune(strings.TrimSpace(s))
+	if len(runes) < 2 {
+		return Card{}, fmt.Errorf("invalid card %q", s)
+	}
+
+	suitRune, rankStr := runes[len(runes)-1], string(runes[:len(runes)-1])
+	if _, ok := parseSuit(runes[0]); ok {
+		suitRune, rankStr = runes[0], string(runes[1:])
+	}
+
+	suit, ok := parseSuit(suitRune)
+	if !ok {
+		return Card{}, fmt.Errorf("invalid suit in card %q", s)
+	}
+
+	rank, ok := parseRank(strings.ToUpper(rankStr))
+	if !ok {
+		return Card{}, fmt.Errorf("invalid rank in card %q", s)
+	}
+
+	return NewCard(suit, rank)
+}
+
+func parseSuit(r rune) (Suit, bool) {
+	switch r {
+	case '♣', 'c', 'C':
+		return Clubs, true
+	case '♦', 'd', 'D':
+		return Diamonds, true
+	case '♥', 'h', 'H':
+		return Hearts, true
+	case '♠', 's', 'S':
+		return Spades, true
+	}
+	return 0, false
+}
+
+func parseRank(s string) (Rank, bool) {
+	if s == "T" {
+		return Ten, true
+	}
+	for i, symbol := range rankSymbols {
+		if symbol == s {
+			return Rank(i), true
+		}
+	}
+	return 0, false
+}
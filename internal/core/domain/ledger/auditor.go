@@ -0,0 +1,58 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrConservation is returned when chips were created or destroyed
+var ErrConservation = errors.New("chip conservation violated")
+
+// Audit verifies that replaying entries on top of opening reproduces closing
+// for every account, and that the total number of chips is unchanged
+func Audit(opening Balances, entries []Entry, closing Balances) error {
+	expected := copyBalances(opening)
+	if expected == nil {
+		expected = Balances{}
+	}
+
+	var problems []string
+	for _, e := range entries {
+		if e.Amount < 0 {
+			problems = append(problems, fmt.Sprintf("entry #%d has negative amount %d", e.Seq, e.Amount))
+		}
+		expected[e.From] -= e.Amount
+		expected[e.To] += e.Amount
+	}
+
+	accounts := make(map[string]bool)
+	for a := range expected {
+		accounts[a] = true
+	}
+	for a := range closing {
+		accounts[a] = true
+	}
+
+	names := make([]string, 0, len(accounts))
+	for a := range accounts {
+		names = append(names, a)
+	}
+	sort.Strings(names)
+
+	for _, a := range names {
+		if expected[a] != closing[a] {
+			problems = append(problems, fmt.Sprintf("%s: ledger %d, actual %d", a, expected[a], closing[a]))
+		}
+	}
+
+	if opening.Total() != closing.Total() {
+		problems = append(problems, fmt.Sprintf("total chips changed from %d to %d", opening.Total(), closing.Total()))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrConservation, strings.Join(problems, "; "))
+	}
+	return nil
+}
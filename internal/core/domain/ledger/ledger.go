@@ -0,0 +1,237 @@
+// Package ledger records chip transfers and audits chip conservation
+package ledger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// PotAccount is the account holding chips committed to the current hand
+const PotAccount = "POT"
+
+// Reason describes why chips moved
+type Reason string
+
+const (
+	ReasonBlind    Reason = "BLIND"
+	ReasonBet      Reason = "BET"
+	ReasonCall     Reason = "CALL"
+	ReasonRaise    Reason = "RAISE"
+	ReasonAllIn    Reason = "ALL_IN"
+	ReasonRefund   Reason = "REFUND"
+	ReasonPotAward Reason = "POT_AWARD"
+	ReasonUndo     Reason = "UNDO"
+)
+
+// Entry is a single chip transfer between two accounts
+type Entry struct {
+	Seq    int       `json:"seq"`
+	HandID string    `json:"handId"`
+	Reason Reason    `json:"reason"`
+	From   string    `json:"from"`
+	To     string    `json:"to"`
+	Amount int       `json:"amount"`
+	At     time.Time `json:"at"`
+}
+
+// Balances maps account names to chip counts
+type Balances map[string]int
+
+// Total returns the sum of all balances
+func (b Balances) Total() int {
+	total := 0
+	for _, v := range b {
+		total += v
+	}
+	return total
+}
+
+// hand holds the ledger state for one hand
+type hand struct {
+	opening Balances
+	closing Balances
+	entries []Entry
+}
+
+// Ledger is an append-only journal of chip transfers grouped by hand
+type Ledger struct {
+	mu     sync.Mutex
+	seq    int
+	hands  map[string]*hand
+	order  []string
+	labels map[string]string
+}
+
+// New creates an empty ledger
+func New() *Ledger {
+	return &Ledger{
+		hands:  make(map[string]*hand),
+		labels: make(map[string]string),
+	}
+}
+
+// Label sets a human readable name for an account (e.g. a nickname)
+func (l *Ledger) Label(account, name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.labels[account] = name
+}
+
+// OpenHand starts a hand with the given opening balances
+func (l *Ledger) OpenHand(handID string, opening Balances) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.hands[handID]; !ok {
+		l.order = append(l.order, handID)
+	}
+	l.hands[handID] = &hand{opening: copyBalances(opening)}
+}
+
+// Record appends a transfer to the given hand
+func (l *Ledger) Record(handID string, reason Reason, from, to string, amount int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	h, ok := l.hands[handID]
+	if !ok {
+		h = &hand{opening: Balances{}}
+		l.hands[handID] = h
+		l.order = append(l.order, handID)
+	}
+
+	l.seq++
+	h.entries = append(h.entries, Entry{
+		Seq:    l.seq,
+		HandID: handID,
+		Reason: reason,
+		From:   from,
+		To:     to,
+		Amount: amount,
+		At:     time.Now(),
+	})
+}
+
+// Entries returns the transfers recorded for a hand
+func (l *Ledger) Entries(handID string) []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	h, ok := l.hands[handID]
+	if !ok {
+		return nil
+	}
+	entries := make([]Entry, len(h.entries))
+	copy(entries, h.entries)
+	return entries
+}
+
+// Len returns the number of transfers recorded for a hand
+func (l *Ledger) Len(handID string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if h, ok := l.hands[handID]; ok {
+		return len(h.entries)
+	}
+	return 0
+}
+
+// Revert appends UNDO entries reversing every transfer after the first keep
+// entries of the hand, newest first. The journal itself is never rewritten.
+func (l *Ledger) Revert(handID string, keep int) {
+	entries := l.Entries(handID)
+	for i := len(entries) - 1; i >= keep; i-- {
+		e := entries[i]
+		l.Record(handID, ReasonUndo, e.To, e.From, e.Amount)
+	}
+}
+
+// Hands returns the recorded hand IDs in the order they were opened
+func (l *Ledger) Hands() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	hands := make([]string, len(l.order))
+	copy(hands, l.order)
+	return hands
+}
+
+// Contributions returns how much each account has moved into the pot this hand,
+// net of refunds
+func (l *Ledger) Contributions(handID string) Balances {
+	contributions := Balances{}
+	for _, e := range l.Entries(handID) {
+		switch {
+		case e.To == PotAccount && e.From != PotAccount:
+			contributions[e.From] += e.Amount
+		case e.From == PotAccount && (e.Reason == ReasonRefund || e.Reason == ReasonUndo):
+			contributions[e.To] -= e.Amount
+		}
+	}
+	return contributions
+}
+
+// Close audits the hand against the actual closing balances. The closing
+// balances are stored even when the audit fails so the export shows both.
+func (l *Ledger) Close(handID string, closing Balances) error {
+	l.mu.Lock()
+	h, ok := l.hands[handID]
+	if ok {
+		h.closing = copyBalances(closing)
+	}
+	l.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("%w: hand %s was never opened", ErrConservation, handID)
+	}
+	return Audit(h.opening, l.Entries(handID), closing)
+}
+
+// HandExport is the serialized form of one hand's ledger
+type HandExport struct {
+	HandID   string            `json:"handId"`
+	Accounts map[string]string `json:"accounts"`
+	Opening  Balances          `json:"opening"`
+	Closing  Balances          `json:"closing,omitempty"`
+	Entries  []Entry           `json:"entries"`
+}
+
+// ExportHand returns the hand's ledger as indented JSON
+func (l *Ledger) ExportHand(handID string) ([]byte, error) {
+	l.mu.Lock()
+	h, ok := l.hands[handID]
+	if !ok {
+		l.mu.Unlock()
+		return nil, fmt.Errorf("unknown hand %s", handID)
+	}
+
+	export := HandExport{
+		HandID:   handID,
+		Accounts: make(map[string]string),
+		Opening:  copyBalances(h.opening),
+		Closing:  copyBalances(h.closing),
+		Entries:  append([]Entry(nil), h.entries...),
+	}
+	for account := range h.opening {
+		if label, ok := l.labels[account]; ok {
+			export.Accounts[account] = label
+		}
+	}
+	l.mu.Unlock()
+
+	return json.MarshalIndent(export, "", "  ")
+}
+
+func copyBalances(b Balances) Balances {
+	if b == nil {
+		return nil
+	}
+	out := make(Balances, len(b))
+	for k, v := range b {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,92 @@
+package ledger
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestLedger_CloseBalancedHand(t *testing.T) {
+	l := New()
+	l.OpenHand("h1", Balances{"a": 1000, "b": 1000, PotAccount: 0})
+	l.Record("h1", ReasonBlind, "a", PotAccount, 10)
+	l.Record("h1", ReasonBlind, "b", PotAccount, 20)
+	l.Record("h1", ReasonRaise, "a", PotAccount, 90)
+	l.Record("h1", ReasonRefund, PotAccount, "a", 80)
+	l.Record("h1", ReasonPotAward, PotAccount, "a", 40)
+
+	if got := l.Contributions("h1"); got["a"] != 20 || got["b"] != 20 {
+		t.Errorf("Unexpected contributions: %v", got)
+	}
+
+	if err := l.Close("h1", Balances{"a": 1020, "b": 980, PotAccount: 0}); err != nil {
+		t.Fatalf("Expected balanced hand, got %v", err)
+	}
+}
+
+func TestLedger_CloseDetectsViolation(t *testing.T) {
+	l := New()
+	l.OpenHand("h1", Balances{"a": 100, "b": 100})
+	l.Record("h1", ReasonBet, "a", PotAccount, 50)
+
+	// b gained chips that were never recorded
+	err := l.Close("h1", Balances{"a": 50, "b": 110, PotAccount: 50})
+	if !errors.Is(err, ErrConservation) {
+		t.Fatalf("Expected ErrConservation, got %v", err)
+	}
+}
+
+func TestLedger_CloseUnknownHand(t *testing.T) {
+	if err := New().Close("missing", Balances{}); !errors.Is(err, ErrConservation) {
+		t.Errorf("Expected ErrConservation for unknown hand, got %v", err)
+	}
+}
+
+func TestLedger_ExportHand(t *testing.T) {
+	l := New()
+	l.Label("a", "Hero")
+	l.OpenHand("h1", Balances{"a": 100, PotAccount: 0})
+	l.Record("h1", ReasonBet, "a", PotAccount, 30)
+	_ = l.Close("h1", Balances{"a": 70, PotAccount: 30})
+
+	data, err := l.ExportHand("h1")
+	if err != nil {
+		t.Fatalf("ExportHand failed: %v", err)
+	}
+
+	var export HandExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		t.Fatalf("Export is not valid JSON: %v", err)
+	}
+	if export.HandID != "h1" || export.Accounts["a"] != "Hero" || len(export.Entries) != 1 {
+		t.Errorf("Unexpected export: %+v", export)
+	}
+	if export.Entries[0].Reason != ReasonBet || export.Closing["a"] != 70 {
+		t.Errorf("Unexpected export contents: %+v", export)
+	}
+
+	if _, err := l.ExportHand("missing"); err == nil {
+		t.Error("Expected error for unknown hand")
+	}
+}
+
+func TestLedger_RevertAppendsReversals(t *testing.T) {
+	l := New()
+	l.OpenHand("h1", Balances{"a": 100, PotAccount: 0})
+	l.Record("h1", ReasonBlind, "a", PotAccount, 10)
+	keep := l.Len("h1")
+	l.Record("h1", ReasonAllIn, "a", PotAccount, 90)
+
+	l.Revert("h1", keep)
+
+	entries := l.Entries("h1")
+	if len(entries) != 3 || entries[2].Reason != ReasonUndo || entries[2].To != "a" || entries[2].Amount != 90 {
+		t.Fatalf("Unexpected entries after revert: %+v", entries)
+	}
+	if got := l.Contributions("h1"); got["a"] != 10 {
+		t.Errorf("Expected contribution 10 after revert, got %d", got["a"])
+	}
+	if err := l.Close("h1", Balances{"a": 90, PotAccount: 10}); err != nil {
+		t.Errorf("Reverted hand should audit: %v", err)
+	}
+}
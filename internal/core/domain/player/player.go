@@ -112,6 +112,18 @@ func (p *Player) AddChips(amount int) {
 	p.chips += amount
 }
 
+// RemoveChips takes chips off the player's stack (e.g., a host correction)
+func (p *Player) RemoveChips(amount int) error {
+	if amount <= 0 {
+		return ErrInvalidBetAmount
+	}
+	if amount > p.chips {
+		return ErrInsufficientChips
+	}
+	p.chips -= amount
+	return nil
+}
+
 // SetHand sets the player's hand
 func (p *Player) SetHand(hand card.Hand) {
 	p.hand = hand
@@ -127,6 +139,18 @@ func (p *Player) SetStatus(status PlayerStatus) {
 	p.status = status
 }
 
+// Clone returns an independent copy of the player (e.g., for undo snapshots)
+func (p *Player) Clone() *Player {
+	c := *p
+	c.hand = card.NewHand(p.hand.Cards())
+	return &c
+}
+
+// Restore overwrites the player's state with a previously cloned copy
+func (p *Player) Restore(from *Player) {
+	*p = *from.Clone()
+}
+
 // String returns string representation
 func (p *Player) String() string {
 	return p.nickname.String()
@@ -197,6 +197,29 @@ func TestAddChips(t *testing.T) {
 	}
 }
 
+func TestRemoveChips(t *testing.T) {
+	id := GeneratePlayerId()
+	nickname, _ := NewNickname("TestPlayer")
+	player, _ := NewPlayer(id, nickname, 500)
+
+	if err := player.RemoveChips(200); err != nil {
+		t.Fatalf("RemoveChips failed: %v", err)
+	}
+	if player.Chips() != 300 {
+		t.Errorf("Expected chips 300, got %d", player.Chips())
+	}
+
+	if err := player.RemoveChips(301); err != ErrInsufficientChips {
+		t.Errorf("Expected ErrInsufficientChips, got %v", err)
+	}
+	if err := player.RemoveChips(0); err != ErrInvalidBetAmount {
+		t.Errorf("Expected ErrInvalidBetAmount, got %v", err)
+	}
+	if player.Chips() != 300 {
+		t.Errorf("Expected chips unchanged at 300, got %d", player.Chips())
+	}
+}
+
 func TestSetHand(t *testing.T) {
 	id := GeneratePlayerId()
 	nickname, _ := NewNickname("TestPlayer")
@@ -0,0 +1,124 @@
+package discovery
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AnnounceOptions configures an Announcer. Zero values fall back to the
+// defaults.
+type AnnounceOptions struct {
+	Targets  []string      // UDP addresses to send to (default BroadcastTargets(DefaultPort))
+	Interval time.Duration // time between announcements (default DefaultInterval)
+}
+
+// Announcer periodically sends the tables reported by its source to every
+// target until it is closed
+type Announcer struct {
+	id       string
+	source   func() []Announcement
+	conn     *net.UDPConn
+	targets  []*net.UDPAddr
+	interval time.Duration
+
+	now  chan struct{}
+	done chan struct{}
+	wg   sync.WaitGroup
+	once sync.Once
+}
+
+// NewAnnouncer starts announcing the tables source returns. It announces
+// once right away and then every interval.
+func NewAnnouncer(source func() []Announcement, opts AnnounceOptions) (*Announcer, error) {
+	if len(opts.Targets) == 0 {
+		opts.Targets = BroadcastTargets(DefaultPort)
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+
+	var targets []*net.UDPAddr
+	for _, target := range opts.Targets {
+		addr, err := net.ResolveUDPAddr("udp4", target)
+		if err != nil {
+			return nil, fmt.Errorf("resolve announce target %s: %w", target, err)
+		}
+		targets = append(targets, addr)
+	}
+
+	conn, err := net.ListenUDP("udp4", nil)
+	if err != nil {
+		return nil, fmt.Errorf("open announce socket: %w", err)
+	}
+
+	a := &Announcer{
+		id:       uuid.NewString(),
+		source:   source,
+		conn:     conn,
+		targets:  targets,
+		interval: opts.Interval,
+		now:      make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	a.wg.Add(1)
+	go a.loop()
+	return a, nil
+}
+
+// ID identifies this announcer's tables, so a host can skip its own
+func (a *Announcer) ID() string {
+	return a.id
+}
+
+// Announce sends the current tables without waiting for the next interval
+func (a *Announcer) Announce() {
+	select {
+	case a.now <- struct{}{}:
+	default:
+	}
+}
+
+// Close withdraws the tables from every listener and stops announcing
+func (a *Announcer) Close() error {
+	var err error
+	a.once.Do(func() {
+		close(a.done)
+		a.wg.Wait()
+		a.send(nil)
+		err = a.conn.Close()
+	})
+	return err
+}
+
+func (a *Announcer) loop() {
+	defer a.wg.Done()
+
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		a.send(a.source())
+		select {
+		case <-ticker.C:
+		case <-a.now:
+		case <-a.done:
+			return
+		}
+	}
+}
+
+// send writes one packet to every target. Unreachable targets are skipped;
+// the next interval tries again.
+func (a *Announcer) send(tables []Announcement) {
+	data, err := encode(a.id, tables)
+	if err != nil || len(data) > maxPacket {
+		return
+	}
+	for _, target := range a.targets {
+		_, _ = a.conn.WriteToUDP(data, target)
+	}
+}
@@ -0,0 +1,115 @@
+// Package discovery lets hosted LAN tables announce themselves by UDP
+// broadcast so clients on the same network can list and join them without
+// typing addresses.
+package discovery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// DefaultPort is the UDP port announcements are sent to and listened on
+const DefaultPort = 47777
+
+// VariantHoldem names the only game the local server runs
+const VariantHoldem = "No-Limit Texas Hold'em"
+
+// Defaults used when an option is left zero
+const (
+	DefaultInterval = 2 * time.Second
+	DefaultTTL      = 3 * DefaultInterval
+)
+
+// app and version tag our datagrams so stray traffic on the port is ignored
+const (
+	app     = "pokerhole"
+	version = 1
+)
+
+// maxPacket bounds one datagram; a host announces a handful of tables
+const maxPacket = 8 << 10
+
+// Announcement describes one open table as its host advertises it
+type Announcement struct {
+	Name       string `json:"name"`
+	Variant    string `json:"variant"`
+	Code       string `json:"code"`
+	SmallBlind int    `json:"smallBlind"`
+	BigBlind   int    `json:"bigBlind"`
+	Seats      int    `json:"seats"`
+	FreeSeats  int    `json:"freeSeats"`
+	Port       int    `json:"port"` // WebSocket port on the announcing machine
+	Path       string `json:"path"`
+}
+
+// packet is one datagram. A host sends all its tables at once; an empty
+// list withdraws them.
+type packet struct {
+	App     string         `json:"app"`
+	Version int            `json:"v"`
+	Host    string         `json:"host"`
+	Tables  []Announcement `json:"tables"`
+}
+
+var errForeign = errors.New("not a pokerhole announcement")
+
+func encode(host string, tables []Announcement) ([]byte, error) {
+	if tables == nil {
+		tables = []Announcement{}
+	}
+	return json.Marshal(packet{App: app, Version: version, Host: host, Tables: tables})
+}
+
+func decode(data []byte) (packet, error) {
+	var p packet
+	if err := json.Unmarshal(data, &p); err != nil {
+		return p, fmt.Errorf("%w: %v", errForeign, err)
+	}
+	if p.App != app || p.Host == "" {
+		return p, errForeign
+	}
+	if p.Version != version {
+		return p, fmt.Errorf("unsupported announcement version %d", p.Version)
+	}
+	return p, nil
+}
+
+// BroadcastTargets returns the broadcast address of every IPv4 interface
+// that supports it, plus the limited broadcast address, at port
+func BroadcastTargets(port int) []string {
+	targets := []string{fmt.Sprintf("255.255.255.255:%d", port)}
+
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return targets
+	}
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagBroadcast == 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			ipnet, ok := addr.(*net.IPNet)
+			if !ok || ipnet.IP.To4() == nil {
+				continue
+			}
+			ip := ipnet.IP.To4()
+			mask := net.IP(ipnet.Mask).To4()
+			if mask == nil {
+				continue
+			}
+			broadcast := make(net.IP, net.IPv4len)
+			for i := range broadcast {
+				broadcast[i] = ip[i] | ^mask[i]
+			}
+			targets = append(targets, net.JoinHostPort(broadcast.String(), fmt.Sprint(port)))
+		}
+	}
+	return targets
+}
@@ -0,0 +1,142 @@
+package discovery
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// listen starts a listener on a free loopback port
+func listen(t *testing.T, ttl time.Duration) *Listener {
+	t.Helper()
+	l, err := Listen("127.0.0.1:0", ttl)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { l.Close() })
+	return l
+}
+
+// waitFor polls until cond holds for the listener's tables
+func waitFor(t *testing.T, l *Listener, what string, cond func([]Table) bool) []Table {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		tables := l.Tables()
+		if cond(tables) {
+			return tables
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s: listener %s has %+v", what, l.Addr(), tables)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// source is a mutable table list for an announcer
+type source struct {
+	mu     sync.Mutex
+	tables []Announcement
+}
+
+func (s *source) set(tables ...Announcement) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tables = tables
+}
+
+func (s *source) get() []Announcement {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables
+}
+
+func TestAnnouncersReachEveryListener(t *testing.T) {
+	listeners := []*Listener{listen(t, time.Second), listen(t, time.Second), listen(t, time.Second)}
+	var targets []string
+	for _, l := range listeners {
+		targets = append(targets, l.Addr())
+	}
+
+	alice, bob := &source{}, &source{}
+	alice.set(Announcement{Name: "Alice", Variant: VariantHoldem, Code: "AAAAAA", SmallBlind: 10, BigBlind: 20, Seats: 6, FreeSeats: 5, Port: 7777, Path: "/ws/game"})
+	bob.set(Announcement{Name: "Bob", Variant: VariantHoldem, Code: "BBBBBB", SmallBlind: 50, BigBlind: 100, Seats: 2, FreeSeats: 1, Port: 7778, Path: "/ws/game"})
+
+	opts := AnnounceOptions{Targets: targets, Interval: 20 * time.Millisecond}
+	a, err := NewAnnouncer(alice.get, opts)
+	if err != nil {
+		t.Fatalf("announcer: %v", err)
+	}
+	defer a.Close()
+	b, err := NewAnnouncer(bob.get, opts)
+	if err != nil {
+		t.Fatalf("announcer: %v", err)
+	}
+
+	for _, l := range listeners {
+		tables := waitFor(t, l, "both tables", func(tables []Table) bool { return len(tables) == 2 })
+		if tables[0].Name != "Alice" || tables[0].Host != a.ID() || tables[0].URL != "ws://127.0.0.1:7777/ws/game" {
+			t.Fatalf("unexpected first table %+v", tables[0])
+		}
+		if tables[1].BigBlind != 100 || tables[1].FreeSeats != 1 || tables[1].Variant != VariantHoldem {
+			t.Fatalf("unexpected second table %+v", tables[1])
+		}
+	}
+
+	// Seats filling up show on the next announcement
+	alice.set(Announcement{Name: "Alice", Code: "AAAAAA", Seats: 6, FreeSeats: 2, Port: 7777, Path: "/ws/game"})
+	a.Announce()
+	for _, l := range listeners {
+		waitFor(t, l, "updated seats", func(tables []Table) bool { return len(tables) == 2 && tables[0].FreeSeats == 2 })
+	}
+
+	// Closing withdraws a host's tables right away
+	if err := b.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	for _, l := range listeners {
+		waitFor(t, l, "bob withdrawn", func(tables []Table) bool { return len(tables) == 1 && tables[0].Name == "Alice" })
+	}
+}
+
+func TestListenerExpiresSilentHosts(t *testing.T) {
+	l := listen(t, 100*time.Millisecond)
+	conn, err := net.Dial("udp4", l.Addr())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+
+	data, err := encode("host-1", []Announcement{{Name: "Gone", Code: "GGGGGG", Port: 7777, Path: "/ws/game"}})
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	for _, packet := range [][]byte{
+		[]byte("not json"),
+		[]byte(`{"app":"other","host":"x","tables":[{"code":"XXXXXX","port":1,"path":"/"}]}`),
+		data,
+	} {
+		if _, err := conn.Write(packet); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	tables := waitFor(t, l, "announced table", func(tables []Table) bool { return len(tables) > 0 })
+	if len(tables) != 1 || tables[0].Name != "Gone" {
+		t.Fatalf("foreign packets should be ignored, got %+v", tables)
+	}
+	waitFor(t, l, "expiry", func(tables []Table) bool { return len(tables) == 0 })
+}
+
+func TestBroadcastTargets(t *testing.T) {
+	targets := BroadcastTargets(DefaultPort)
+	if len(targets) == 0 || targets[0] != "255.255.255.255:47777" {
+		t.Fatalf("expected the limited broadcast address first, got %v", targets)
+	}
+	for _, target := range targets {
+		if _, err := net.ResolveUDPAddr("udp4", target); err != nil {
+			t.Fatalf("bad target %q: %v", target, err)
+		}
+	}
+}
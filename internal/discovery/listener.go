@@ -0,0 +1,136 @@
+package discovery
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Table is an announced table as a listener last heard it
+type Table struct {
+	Announcement
+	Host string    // announcer ID; stable while the host keeps running
+	URL  string    // WebSocket address built from the sender's IP
+	Seen time.Time // when the last announcement arrived
+}
+
+// Key identifies a table across announcements
+func (t Table) Key() string {
+	return t.Host + "/" + t.Code
+}
+
+// Listener collects announcements sent to its UDP address and forgets
+// tables that stop being announced
+type Listener struct {
+	conn *net.UDPConn
+	ttl  time.Duration
+
+	mu     sync.Mutex
+	tables map[string]Table
+	wg     sync.WaitGroup
+}
+
+// Listen receives announcements on addr, e.g. ":47777". Tables not heard
+// from within ttl disappear; zero uses DefaultTTL.
+func Listen(addr string, ttl time.Duration) (*Listener, error) {
+	udpAddr, err := net.ResolveUDPAddr("udp4", addr)
+	if err != nil {
+		return nil, fmt.Errorf("resolve discovery address %s: %w", addr, err)
+	}
+	conn, err := net.ListenUDP("udp4", udpAddr)
+	if err != nil {
+		return nil, fmt.Errorf("listen for tables on %s: %w", addr, err)
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	l := &Listener{conn: conn, ttl: ttl, tables: make(map[string]Table)}
+	l.wg.Add(1)
+	go l.readLoop()
+	return l, nil
+}
+
+// Addr returns the address the listener receives on
+func (l *Listener) Addr() string {
+	return l.conn.LocalAddr().String()
+}
+
+// Tables returns the tables heard from recently, ordered by name
+func (l *Listener) Tables() []Table {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	tables := make([]Table, 0, len(l.tables))
+	for key, t := range l.tables {
+		if now.Sub(t.Seen) > l.ttl {
+			delete(l.tables, key)
+			continue
+		}
+		tables = append(tables, t)
+	}
+	slices.SortFunc(tables, func(a, b Table) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Key(), b.Key()))
+	})
+	return tables
+}
+
+// Close stops listening
+func (l *Listener) Close() error {
+	err := l.conn.Close()
+	l.wg.Wait()
+	return err
+}
+
+func (l *Listener) readLoop() {
+	defer l.wg.Done()
+
+	buf := make([]byte, maxPacket)
+	for {
+		n, from, err := l.conn.ReadFromUDP(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+		p, err := decode(buf[:n])
+		if err != nil {
+			continue
+		}
+		l.update(p, from.IP)
+	}
+}
+
+// update replaces everything a host announced before with its latest list
+func (l *Listener) update(p packet, from net.IP) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for key, t := range l.tables {
+		if t.Host == p.Host {
+			delete(l.tables, key)
+		}
+	}
+
+	now := time.Now()
+	for _, a := range p.Tables {
+		if a.Port <= 0 || a.Port > 65535 || a.Code == "" || !strings.HasPrefix(a.Path, "/") {
+			continue
+		}
+		t := Table{
+			Announcement: a,
+			Host:         p.Host,
+			URL:          "ws://" + net.JoinHostPort(from.String(), strconv.Itoa(a.Port)) + a.Path,
+			Seen:         now,
+		}
+		l.tables[t.Key()] = t
+	}
+}
@@ -0,0 +1,259 @@
+package localserver
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/player"
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+)
+
+var (
+	errNotStarted    = errors.New("게임이 아직 시작되지 않았습니다.")
+	errPaused        = errors.New("게임이 일시정지되었습니다.")
+	errNotPaused     = errors.New("게임이 일시정지 상태가 아닙니다.")
+	errNoSuchPlayer  = errors.New("테이블에 없는 플레이어입니다.")
+	errKickSelf      = errors.New("자기 자신은 내보낼 수 없습니다.")
+	errNoSeatRequest = errors.New("입장 요청이 없습니다.")
+)
+
+// hostCommand applies a dealer tool sent by the host of a private room.
+// The host's UUID is broadcast with the table state, so the command must
+// also carry the token the host was handed.
+func (s *Server) hostCommand(sess *session, msg protocol.ClientMessage) {
+	st := sess.seat
+	if st == nil || st.table.code == "" || st.table.hostID != st.id || msg.HostToken == "" || msg.HostToken != st.table.hostToken {
+		sess.reply(msg, protocol.Error{Code: protocol.ErrorNotHost, Message: "방장만 할 수 있습니다."})
+		return
+	}
+	if err := st.table.command(msg.Payload); err != nil {
+		sess.reply(msg, protocol.Error{Code: protocol.ErrorHostCommand, Message: err.Error()})
+		return
+	}
+	sess.reply(msg, protocol.Ack{Action: msg.Type})
+}
+
+// command runs one host command and records it in the audit trail
+func (t *table) command(payload protocol.ClientPayload) error {
+	entry := protocol.HostActed{GameID: t.id, Action: payload.ClientType()}
+	if t.hand == 0 {
+		entry.GameID = ""
+	}
+
+	switch p := payload.(type) {
+	case protocol.HostPause:
+		if !t.started {
+			return errNotStarted
+		}
+		if t.paused {
+			return errPaused
+		}
+		t.paused = true
+		t.audit(entry)
+		t.broadcastState()
+		return nil
+
+	case protocol.HostResume:
+		if !t.paused {
+			return errNotPaused
+		}
+		t.paused = false
+		t.audit(entry)
+		t.resume()
+		return nil
+
+	case protocol.HostKick:
+		st, err := t.target(p.PlayerID, &entry)
+		if err != nil {
+			return err
+		}
+		if st.id == t.hostID {
+			return errKickSelf
+		}
+		t.audit(entry)
+		t.kick(st)
+		return nil
+
+	case protocol.HostSitOut:
+		st, err := t.target(p.PlayerID, &entry)
+		if err != nil {
+			return err
+		}
+		st.sitOut = p.SitOut
+		entry.SitOut = p.SitOut
+		t.audit(entry)
+		return nil
+
+	case protocol.HostAdjustStack:
+		st, err := t.target(p.PlayerID, &entry)
+		if err != nil {
+			return err
+		}
+		if p.Amount > 0 {
+			st.player.AddChips(p.Amount)
+		} else if err := st.player.RemoveChips(-p.Amount); err != nil {
+			return fmt.Errorf("칩이 부족합니다 (보유 %d).", st.player.Chips())
+		}
+		entry.Amount = p.Amount
+		entry.Chips = st.player.Chips()
+		entry.Reason = p.Reason
+		t.audit(entry)
+		if t.hand > 0 {
+			t.broadcastState()
+		}
+		return nil
+
+	case protocol.HostSetBlinds:
+		t.nextSmallBlind, t.nextBigBlind = p.SmallBlind, p.BigBlind
+		entry.SmallBlind, entry.BigBlind = p.SmallBlind, p.BigBlind
+		t.audit(entry)
+		return nil
+
+	case protocol.HostSeatDecision:
+		i := slices.IndexFunc(t.requests, func(c *session) bool { return c.id == p.PlayerID })
+		if i < 0 {
+			return errNoSeatRequest
+		}
+		c := t.requests[i]
+		t.requests = slices.Delete(t.requests, i, i+1)
+		c.requested = nil
+		entry.PlayerID, entry.Nickname, entry.Approved = c.id, c.nickname, p.Approve
+
+		if !p.Approve {
+			c.send(protocol.Error{Code: protocol.ErrorSeatDenied, Message: "방장이 입장을 거절했습니다."})
+			t.audit(entry)
+			return nil
+		}
+		if t.started || len(t.seats) >= t.size {
+			c.send(protocol.Error{Code: protocol.ErrorRoomFull, Message: "room is full"})
+			return errors.New("자리가 없습니다.")
+		}
+		t.sit(c.id, c.nickname, c)
+		t.audit(entry)
+		t.roomProgress()
+		if len(t.seats) == t.size {
+			t.start()
+		}
+		return nil
+	}
+	return fmt.Errorf("알 수 없는 방장 명령 %s", payload.ClientType())
+}
+
+// target finds the seat a host command names and fills in the audit entry
+func (t *table) target(id string, entry *protocol.HostActed) (*seat, error) {
+	st := t.seatOf(id)
+	if st == nil || st.left {
+		return nil, errNoSuchPlayer
+	}
+	entry.PlayerID = st.id
+	entry.Nickname = st.player.Nickname().Value()
+	return st, nil
+}
+
+// audit keeps a host decision and tells everyone at the table about it
+func (t *table) audit(entry protocol.HostActed) {
+	t.log = append(t.log, entry)
+	t.srv.opts.Logf("localserver: room %s host %s %s %s amount=%d chips=%d blinds=%d/%d reason=%q",
+		t.code, t.hostID, entry.Action, entry.PlayerID, entry.Amount, entry.Chips, entry.SmallBlind, entry.BigBlind, entry.Reason)
+	t.broadcast(entry)
+}
+
+// resume picks the game up where the pause stopped it
+func (t *table) resume() {
+	switch {
+	case t.over:
+	case t.turn >= 0:
+		// Players may have left while paused; advance finds who acts now
+		t.advance()
+	case t.handDue:
+		t.handDue = false
+		t.startHand()
+	default:
+		t.broadcastState()
+	}
+}
+
+// kick removes a player; before the game starts they get their seat back
+// as an error so the lobby closes
+func (t *table) kick(st *seat) {
+	if t.started {
+		t.leave(st, "방장이 테이블에서 내보냈습니다.")
+		return
+	}
+	if c := st.session; c != nil {
+		c.send(protocol.Error{Code: protocol.ErrorKicked, Message: "방장이 방에서 내보냈습니다."})
+	}
+	t.unseat(st)
+}
+
+// request queues a join for the host to approve
+func (t *table) request(c *session) {
+	t.requests = append(t.requests, c)
+	c.requested = t
+	c.send(protocol.MatchingStarted{RequiredPlayers: t.size})
+	if host := t.seatOf(t.hostID); host != nil && host.session != nil {
+		host.session.send(protocol.SeatRequested{Code: t.code, PlayerID: c.id, Nickname: c.nickname})
+	}
+}
+
+// dropRequest forgets a join request whose player gave up waiting
+func (t *table) dropRequest(c *session) {
+	t.requests = slices.DeleteFunc(t.requests, func(other *session) bool { return other == c })
+	c.requested = nil
+}
+
+// denyRequests answers every waiting join request with e
+func (t *table) denyRequests(e protocol.Error) {
+	for _, c := range t.requests {
+		c.requested = nil
+		c.send(e)
+	}
+	t.requests = nil
+}
+
+// passHost hands the host tools, under a fresh token, to the next player
+// when the host leaves, along with any join requests still waiting
+func (t *table) passHost(from *seat) {
+	if t.hostID != from.id {
+		return
+	}
+	t.hostID, t.hostToken = "", uuid.NewString()
+	for _, st := range t.seats {
+		if st != from && !st.left && !st.bot {
+			t.hostID = st.id
+			break
+		}
+	}
+
+	host := t.seatOf(t.hostID)
+	t.grantDue = host != nil && host.session == nil
+	if host == nil || host.session == nil {
+		return
+	}
+	host.session.send(protocol.HostGranted{Code: t.code, HostToken: t.hostToken})
+	for _, c := range t.requests {
+		host.session.send(protocol.SeatRequested{Code: t.code, PlayerID: c.id, Nickname: c.nickname})
+	}
+}
+
+// sitsOut reports whether a seat skips the next hand
+func (st *seat) sitsOut() bool {
+	return st.left || st.sitOut || st.player.Chips() == 0
+}
+
+// waitForPlayers pauses a table whose sat-out players could still play,
+// instead of ending it
+func (t *table) waitForPlayers() bool {
+	n := t.count(func(p *player.Player) bool { return p.Chips() > 0 && !t.seatFor(p).left })
+	if n < 2 {
+		return false
+	}
+	t.paused = true
+	t.handDue = true
+	t.turn = -1
+	t.broadcastState()
+	return true
+}
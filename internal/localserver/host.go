@@ -0,0 +1,81 @@
+package localserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Path is where the game protocol is served, matching the real server
+const Path = "/ws/game"
+
+// Host serves a Server on a network address so other clients on the LAN
+// can join its tables
+type Host struct {
+	*Server
+	listener net.Listener
+	http     *http.Server
+}
+
+// Listen starts serving the game protocol on addr, e.g. ":7777". Port 0
+// picks a free port.
+func Listen(addr string, opts Options) (*Host, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("listen on %s: %w", addr, err)
+	}
+
+	srv := New(opts)
+	mux := http.NewServeMux()
+	mux.Handle(Path, srv)
+	h := &Host{
+		Server:   srv,
+		listener: listener,
+		http:     &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second},
+	}
+	go func() {
+		if err := h.http.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			srv.opts.Logf("localserver: serve: %v", err)
+		}
+	}()
+	return h, nil
+}
+
+// Port returns the TCP port the host listens on
+func (h *Host) Port() int {
+	return h.listener.Addr().(*net.TCPAddr).Port
+}
+
+// LocalURL returns the address the hosting client itself connects to
+func (h *Host) LocalURL() string {
+	return fmt.Sprintf("ws://127.0.0.1:%d%s", h.Port(), Path)
+}
+
+// LANURLs returns one address per IPv4 network interface that other
+// machines can use to reach the host
+func (h *Host) LANURLs() []string {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil
+	}
+	var urls []string
+	for _, addr := range addrs {
+		ipnet, ok := addr.(*net.IPNet)
+		if !ok || ipnet.IP.IsLoopback() || ipnet.IP.To4() == nil {
+			continue
+		}
+		urls = append(urls, fmt.Sprintf("ws://%s:%d%s", ipnet.IP, h.Port(), Path))
+	}
+	return urls
+}
+
+// Close disconnects every client and stops listening
+func (h *Host) Close() error {
+	h.Server.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return h.http.Shutdown(ctx)
+}
@@ -0,0 +1,696 @@
+// Package localserver runs PokerHole tables in-process and serves them over
+// the game WebSocket protocol, so the online client can be used without the
+// real server.
+package localserver
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/player"
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+)
+
+// Error codes sent with ERROR besides the protocol's room codes
+const (
+	ErrorNotRegistered = "NOT_REGISTERED"
+	ErrorAlreadySeated = "ALREADY_SEATED"
+	ErrorNotSeated     = "NOT_SEATED"
+	ErrorMalformed     = "MALFORMED"
+)
+
+// Options configures a Server. Zero values fall back to the defaults.
+type Options struct {
+	Seats       int           // players per random match (default 2)
+	SmallBlind  int           // random match small blind (default 10)
+	BigBlind    int           // random match big blind (default 20)
+	StartChips  int           // stack each player sits down with (default 1000)
+	Seed        int64         // deck seed; hand n is shuffled with Seed+n
+	HandDelay   time.Duration // pause between hands (default 3s)
+	BotFill     time.Duration // seat bots after a random match waits this long; 0 disables bots
+	BotDelay    time.Duration // bot thinking time (default 800ms)
+	RoomTTL     time.Duration // code rooms expire if not full by then; 0 keeps them forever
+	MinVersion  int           // refuse clients older than this protocol version; 0 accepts all
+	TurnTimeout time.Duration // check or fold for players who let their turn run out; 0 disables
+	Faults      Faults
+	Logf        func(format string, args ...any) // defaults to log.Printf
+}
+
+// Faults scripts failures so the client's error paths can be exercised
+type Faults struct {
+	RejectRegister string                       // answer REGISTER with REGISTER_FAILURE and this reason
+	DropAfter      int                          // drop each client's first connection after this many messages
+	RejectActions  []protocol.ClientMessageType // answer these game actions with INVALID_ACTION
+	SilentActions  bool                         // never answer game actions so requests time out
+	Delay          time.Duration                // hold every outgoing message this long
+	GarbleEvery    int                          // follow every Nth outgoing message with an invalid one
+	LoseEvery      int                          // silently drop every Nth numbered game update
+}
+
+// garbled is a message that fails validation on the client
+var garbled = []byte(`{"type":"GAME_STATE_UPDATE","timestamp":0,"payload":{"pot":-1}}`)
+
+func (o Options) withDefaults() Options {
+	if o.Seats < protocol.MinSeats || o.Seats > protocol.MaxSeats {
+		o.Seats = 2
+	}
+	if o.SmallBlind <= 0 {
+		o.SmallBlind = 10
+	}
+	if o.BigBlind <= o.SmallBlind {
+		o.BigBlind = o.SmallBlind * 2
+	}
+	if o.StartChips <= 0 {
+		o.StartChips = 1000
+	}
+	if o.HandDelay <= 0 {
+		o.HandDelay = 3 * time.Second
+	}
+	if o.BotDelay <= 0 {
+		o.BotDelay = 800 * time.Millisecond
+	}
+	if o.Logf == nil {
+		o.Logf = log.Printf
+	}
+	return o
+}
+
+// Server hosts tables for every client connected to it. All state is
+// guarded by one mutex; timers take it before touching a table.
+type Server struct {
+	opts     Options
+	upgrader websocket.Upgrader
+	rng      *rand.Rand
+
+	mu       sync.Mutex
+	sessions map[*session]bool
+	queue    []*session
+	tables   map[string]*table // by game ID
+	rooms    map[string]*table // by room code, until the room expires or ends
+	dropped  map[string]bool   // clients whose connection a fault already dropped
+	bots     int
+	closed   bool
+}
+
+// New creates a server with the given options
+func New(opts Options) *Server {
+	opts = opts.withDefaults()
+	return &Server{
+		opts:     opts,
+		upgrader: websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }},
+		rng:      rand.New(rand.NewSource(opts.Seed)),
+		sessions: make(map[*session]bool),
+		tables:   make(map[string]*table),
+		rooms:    make(map[string]*table),
+		dropped:  make(map[string]bool),
+	}
+}
+
+// ServeHTTP upgrades the request and serves one client until it disconnects
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	sess := newSession(conn, s.opts.Faults)
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		conn.Close()
+		return
+	}
+	s.sessions[sess] = true
+	s.mu.Unlock()
+
+	go sess.writeLoop()
+	defer s.disconnect(sess)
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		msg, err := protocol.DecodeClient(data)
+		s.mu.Lock()
+		if err != nil {
+			sess.send(protocol.Error{Code: ErrorMalformed, Message: err.Error()})
+		} else {
+			s.handle(sess, msg)
+		}
+		sess.received++
+		drop := s.opts.Faults.DropAfter > 0 && sess.received >= s.opts.Faults.DropAfter && sess.id != "" && !s.dropped[sess.id]
+		if drop {
+			s.dropped[sess.id] = true
+		}
+		s.mu.Unlock()
+
+		if drop {
+			s.opts.Logf("localserver: dropping %s after %d messages", sess.label(), s.opts.Faults.DropAfter)
+			return
+		}
+	}
+}
+
+// Close disconnects every client and stops all tables
+func (s *Server) Close() {
+	s.mu.Lock()
+	s.closed = true
+	sessions := make([]*session, 0, len(s.sessions))
+	for sess := range s.sessions {
+		sessions = append(sessions, sess)
+	}
+	s.mu.Unlock()
+
+	for _, sess := range sessions {
+		sess.close()
+	}
+}
+
+// disconnect forgets a closed connection. Seats are kept so the player can
+// resume the game after reconnecting.
+func (s *Server) disconnect(sess *session) {
+	sess.close()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, sess)
+	s.leaveQueue(sess)
+	if sess.watching != nil {
+		sess.watching.unwatch(sess)
+	}
+	if t := sess.requested; t != nil {
+		t.dropRequest(sess)
+	}
+	if st := sess.seat; st != nil {
+		if !st.table.started {
+			st.table.unseat(st)
+		} else if st.session == sess {
+			st.session = nil
+		}
+	}
+}
+
+func (s *Server) handle(sess *session, msg protocol.ClientMessage) {
+	if msg.Type != protocol.ClientRegister && sess.id == "" {
+		sess.reply(msg, protocol.Error{Code: ErrorNotRegistered, Message: "register first"})
+		return
+	}
+
+	switch p := msg.Payload.(type) {
+	case protocol.Register:
+		s.register(sess, p)
+	case protocol.Heartbeat:
+		sess.lastSeen = time.Now()
+		if p.Seq > 0 && msg.Timestamp > 0 {
+			sess.send(protocol.HeartbeatEcho{Seq: p.Seq, SentAt: msg.Timestamp, ReceivedAt: sess.lastSeen.UnixMilli()})
+		}
+	case protocol.JoinRandomMatch:
+		s.joinQueue(sess, msg)
+	case protocol.CancelMatching:
+		s.cancelMatching(sess)
+	case protocol.CreateRoom:
+		s.createRoom(sess, msg, p)
+	case protocol.JoinCodeMatch:
+		s.joinRoom(sess, msg, p)
+	case protocol.ListTables:
+		sess.send(s.tableList())
+	case protocol.SpectateGame:
+		s.spectate(sess, msg, p)
+	case protocol.LeaveSpectate:
+		if sess.watching != nil {
+			sess.watching.unwatch(sess)
+		}
+	case protocol.LeaveGame:
+		if sess.seat != nil {
+			sess.seat.table.leave(sess.seat, "게임에서 나갔습니다.")
+		}
+	case protocol.SendChat:
+		s.chat(sess, msg, p)
+	case protocol.ResumeGame:
+		s.resume(sess, p)
+	case protocol.RequestSync:
+		if t := s.tables[p.GameID]; t != nil && (sess.watching == t || sess.seat != nil && sess.seat.table == t) {
+			t.sync(sess)
+		}
+	default:
+		switch {
+		case protocol.IsGameAction(msg.Type):
+			s.gameAction(sess, msg)
+		case protocol.IsHostCommand(msg.Type):
+			s.hostCommand(sess, msg)
+		}
+	}
+}
+
+func (s *Server) register(sess *session, p protocol.Register) {
+	if reason := s.opts.Faults.RejectRegister; reason != "" {
+		sess.send(protocol.RegisterFailure{Reason: reason})
+		return
+	}
+	if _, err := player.NewNickname(p.Nickname); err != nil {
+		sess.send(protocol.RegisterFailure{Reason: err.Error()})
+		return
+	}
+
+	if version := protocol.EffectiveVersion(p.ProtocolVersion); version < s.opts.MinVersion {
+		sess.send(protocol.RegisterFailure{
+			Reason:     fmt.Sprintf("프로토콜 v%d 이상이 필요합니다 (현재 v%d).", s.opts.MinVersion, version),
+			Code:       protocol.RegisterUpgradeRequired,
+			MinVersion: s.opts.MinVersion,
+		})
+		return
+	}
+
+	sess.id, sess.nickname, sess.token = p.UUID, p.Nickname, uuid.NewString()
+	sess.send(protocol.RegisterSuccess{
+		UUID:            p.UUID,
+		Nickname:        p.Nickname,
+		ProtocolVersion: protocol.Version,
+		MinVersion:      s.opts.MinVersion,
+		Capabilities:    protocol.Capabilities(),
+		ResumeToken:     sess.token,
+	})
+}
+
+// resume moves a seat kept for a dropped connection onto the new one. The
+// UUID is public, so the seat only moves for the resume token it was last
+// held with; a connection still holding it is told why and closed.
+func (s *Server) resume(sess *session, p protocol.ResumeGame) {
+	t := s.tables[p.GameID]
+	var st *seat
+	if t != nil {
+		st = t.seatOf(sess.id)
+	}
+	if st == nil || st.left {
+		sess.send(protocol.GameEnded{GameID: p.GameID, Reason: "테이블이 사라졌습니다."})
+		return
+	}
+	if p.Token == "" || p.Token != st.token {
+		s.opts.Logf("localserver: %s sent a bad resume token for %s", sess.label(), t.id)
+		sess.send(protocol.GameEnded{GameID: p.GameID, Reason: "이 자리를 이어받을 수 없습니다."})
+		return
+	}
+
+	if old := st.session; old != nil && old != sess {
+		old.seat = nil
+		old.finish(protocol.GameEnded{GameID: t.id, Reason: "다른 연결에서 게임을 이어받았습니다."})
+	}
+	st.session, st.token = sess, sess.token
+	sess.seat = st
+	if t.grantDue && t.hostID == st.id {
+		t.grantDue = false
+		sess.send(protocol.HostGranted{Code: t.code, HostToken: t.hostToken})
+	}
+	s.opts.Logf("localserver: %s resumed %s", sess.label(), t.id)
+}
+
+func (s *Server) joinQueue(sess *session, msg protocol.ClientMessage) {
+	if sess.seat != nil || sess.requested != nil || slices.Contains(s.queue, sess) {
+		sess.reply(msg, protocol.Error{Code: ErrorAlreadySeated, Message: "already matching or seated"})
+		return
+	}
+
+	s.queue = append(s.queue, sess)
+	sess.queuedAt = time.Now()
+	sess.send(protocol.MatchingStarted{RequiredPlayers: s.opts.Seats})
+	s.queueProgress()
+
+	if len(s.queue) >= s.opts.Seats {
+		s.matchQueue(s.queue[:s.opts.Seats], 0)
+		return
+	}
+	if wait := s.opts.BotFill; wait > 0 {
+		time.AfterFunc(wait, func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if !s.closed && len(s.queue) > 0 && s.queue[0] == sess && time.Since(sess.queuedAt) >= wait {
+				s.matchQueue(s.queue, s.opts.Seats-len(s.queue))
+			}
+		})
+	}
+}
+
+// matchQueue seats the given queued players and bots at a new table
+func (s *Server) matchQueue(players []*session, bots int) {
+	t := s.newTable("", s.opts.SmallBlind, s.opts.BigBlind, len(players)+bots)
+	for _, sess := range slices.Clone(players) {
+		s.leaveQueue(sess)
+		t.sit(sess.id, sess.nickname, sess)
+	}
+	for range bots {
+		s.bots++
+		t.sit(uuid.NewString(), fmt.Sprintf("Bot%d", s.bots), nil)
+	}
+	t.start()
+}
+
+func (s *Server) leaveQueue(sess *session) bool {
+	i := slices.Index(s.queue, sess)
+	if i < 0 {
+		return false
+	}
+	s.queue = slices.Delete(s.queue, i, i+1)
+	s.queueProgress()
+	return true
+}
+
+func (s *Server) queueProgress() {
+	for _, sess := range s.queue {
+		sess.send(protocol.MatchingProgress{CurrentPlayers: len(s.queue), RequiredPlayers: s.opts.Seats})
+	}
+}
+
+func (s *Server) cancelMatching(sess *session) {
+	switch {
+	case s.leaveQueue(sess):
+	case sess.requested != nil:
+		sess.requested.dropRequest(sess)
+	case sess.seat != nil && !sess.seat.table.started:
+		sess.seat.table.unseat(sess.seat)
+	default:
+		return
+	}
+	sess.send(protocol.MatchingCancelled{})
+}
+
+func (s *Server) createRoom(sess *session, msg protocol.ClientMessage, p protocol.CreateRoom) {
+	if sess.seat != nil || sess.requested != nil || slices.Contains(s.queue, sess) {
+		sess.reply(msg, protocol.Error{Code: ErrorAlreadySeated, Message: "already matching or seated"})
+		return
+	}
+
+	t := s.newTable(s.roomCode(), p.SmallBlind, p.BigBlind, p.Seats)
+	s.rooms[t.code] = t
+	t.hostID, t.hostToken, t.approve = sess.id, uuid.NewString(), p.ApproveSeats
+	t.sit(sess.id, sess.nickname, sess)
+	sess.send(protocol.RoomCreated{Code: t.code, SmallBlind: t.smallBlind, BigBlind: t.bigBlind, Seats: t.size, HostToken: t.hostToken})
+	t.roomProgress()
+}
+
+func (s *Server) joinRoom(sess *session, msg protocol.ClientMessage, p protocol.JoinCodeMatch) {
+	if sess.seat != nil || sess.requested != nil || slices.Contains(s.queue, sess) {
+		sess.reply(msg, protocol.Error{Code: ErrorAlreadySeated, Message: "already matching or seated"})
+		return
+	}
+
+	t, err := s.room(p.Code)
+	if err == nil && (t.started || len(t.seats) >= t.size) {
+		err = &protocol.Error{Code: protocol.ErrorRoomFull, Message: "room is full"}
+	}
+	if err != nil {
+		sess.reply(msg, *err)
+		return
+	}
+	if t.approve {
+		t.request(sess)
+		return
+	}
+
+	sess.send(protocol.MatchingStarted{RequiredPlayers: t.size})
+	t.sit(sess.id, sess.nickname, sess)
+	t.roomProgress()
+	if len(t.seats) == t.size {
+		t.start()
+	}
+}
+
+// room looks up an open room, expiring it when its time is up
+func (s *Server) room(code string) (*table, *protocol.Error) {
+	t := s.rooms[protocol.NormalizeRoomCode(code)]
+	if t == nil {
+		return nil, &protocol.Error{Code: protocol.ErrorRoomNotFound, Message: "no room with that code"}
+	}
+	if ttl := s.opts.RoomTTL; ttl > 0 && !t.started && time.Since(t.created) > ttl {
+		t.close("방이 만료되었습니다.")
+		return nil, &protocol.Error{Code: protocol.ErrorRoomExpired, Message: "room expired"}
+	}
+	return t, nil
+}
+
+// roomCode returns an unused invite code without look-alike characters
+func (s *Server) roomCode() string {
+	const alphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	for {
+		var b strings.Builder
+		for range protocol.RoomCodeLength {
+			b.WriteByte(alphabet[s.rng.Intn(len(alphabet))])
+		}
+		if code := b.String(); s.rooms[code] == nil {
+			return code
+		}
+	}
+}
+
+func (s *Server) tableList() protocol.TableList {
+	list := protocol.TableList{Tables: []protocol.TableInfo{}}
+	for _, t := range s.tables {
+		if !t.started || t.code != "" {
+			continue
+		}
+		list.Tables = append(list.Tables, t.info())
+	}
+	slices.SortFunc(list.Tables, func(a, b protocol.TableInfo) int { return strings.Compare(a.GameID, b.GameID) })
+	return list
+}
+
+// RoomInfo describes a code room for announcing it on the network
+type RoomInfo struct {
+	Code       string
+	SmallBlind int
+	BigBlind   int
+	Seats      int
+	Players    int
+	Started    bool
+}
+
+// Rooms lists the code rooms that have not ended, ordered by code
+func (s *Server) Rooms() []RoomInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rooms := make([]RoomInfo, 0, len(s.rooms))
+	for code, t := range s.rooms {
+		if ttl := s.opts.RoomTTL; ttl > 0 && !t.started && time.Since(t.created) > ttl {
+			continue
+		}
+		rooms = append(rooms, RoomInfo{
+			Code:       code,
+			SmallBlind: t.smallBlind,
+			BigBlind:   t.bigBlind,
+			Seats:      t.size,
+			Players:    len(t.seats),
+			Started:    t.started,
+		})
+	}
+	slices.SortFunc(rooms, func(a, b RoomInfo) int { return strings.Compare(a.Code, b.Code) })
+	return rooms
+}
+
+func (s *Server) spectate(sess *session, msg protocol.ClientMessage, p protocol.SpectateGame) {
+	if sess.seat != nil {
+		sess.reply(msg, protocol.Error{Code: ErrorAlreadySeated, Message: "players cannot spectate"})
+		return
+	}
+
+	t := s.tables[p.GameID]
+	if p.Code != "" {
+		var err *protocol.Error
+		if t, err = s.room(p.Code); err != nil {
+			sess.reply(msg, *err)
+			return
+		}
+	}
+	if t == nil || t.over {
+		sess.reply(msg, protocol.Error{Code: protocol.ErrorRoomNotFound, Message: "no such table"})
+		return
+	}
+
+	if sess.watching != nil {
+		sess.watching.unwatch(sess)
+	}
+	t.watch(sess)
+}
+
+func (s *Server) chat(sess *session, msg protocol.ClientMessage, p protocol.SendChat) {
+	t := sess.watching
+	if sess.seat != nil {
+		t = sess.seat.table
+	}
+	if t == nil {
+		sess.reply(msg, protocol.Error{Code: ErrorNotSeated, Message: "not at a table"})
+		return
+	}
+	// Chat is not part of the game state, so it is not numbered
+	chat := protocol.ChatReceived{PlayerID: sess.id, Nickname: sess.nickname, Message: p.Message}
+	t.each(func(c *session) { c.send(chat) })
+}
+
+func (s *Server) gameAction(sess *session, msg protocol.ClientMessage) {
+	faults := s.opts.Faults
+	if faults.SilentActions {
+		return
+	}
+	if slices.Contains(faults.RejectActions, msg.Type) {
+		sess.reply(msg, protocol.InvalidAction{Action: msg.Type, Reason: "서버가 이 액션을 거부했습니다."})
+		return
+	}
+	if sess.seat == nil {
+		sess.reply(msg, protocol.InvalidAction{Action: msg.Type, Reason: "테이블에 앉아 있지 않습니다."})
+		return
+	}
+
+	amount := 0
+	if raise, ok := msg.Payload.(protocol.Raise); ok {
+		amount = raise.Amount
+	}
+	if err := sess.seat.table.act(sess.seat, msg.Type, amount); err != nil {
+		sess.reply(msg, protocol.InvalidAction{Action: msg.Type, Reason: err.Error()})
+		return
+	}
+	sess.reply(msg, protocol.Ack{Action: msg.Type})
+}
+
+func (s *Server) newTable(code string, smallBlind, bigBlind, size int) *table {
+	t := newTable(s, uuid.NewString(), code, smallBlind, bigBlind, size)
+	s.tables[t.id] = t
+	return t
+}
+
+// removeTable forgets a table once it has ended
+func (s *Server) removeTable(t *table) {
+	t.denyRequests(protocol.Error{Code: protocol.ErrorRoomNotFound, Message: "room closed"})
+	delete(s.tables, t.id)
+	if t.code != "" && s.rooms[t.code] == t {
+		delete(s.rooms, t.code)
+	}
+}
+
+// session is one client connection
+type session struct {
+	conn   *websocket.Conn
+	faults Faults
+	out    chan []byte
+	done   chan struct{}
+	once   sync.Once
+
+	// Guarded by the server mutex
+	id        string
+	nickname  string
+	token     string // resume token issued at REGISTER
+	seat      *seat
+	watching  *table
+	requested *table // room waiting to approve our seat
+	queuedAt  time.Time
+	lastSeen  time.Time
+	received  int
+	sent      int
+	numbered  int // game updates sent, for LoseEvery
+}
+
+func newSession(conn *websocket.Conn, faults Faults) *session {
+	return &session{
+		conn:   conn,
+		faults: faults,
+		out:    make(chan []byte, 256),
+		done:   make(chan struct{}),
+	}
+}
+
+func (c *session) label() string {
+	if c.nickname == "" {
+		return c.conn.RemoteAddr().String()
+	}
+	return c.nickname
+}
+
+// send queues a server message; a client that cannot keep up is dropped
+func (c *session) send(p protocol.ServerPayload) {
+	c.queue(protocol.NewServerMessage(p))
+}
+
+// sendSeq queues a game update numbered seq
+func (c *session) sendSeq(seq int64, p protocol.ServerPayload) {
+	c.numbered++
+	if every := c.faults.LoseEvery; every > 0 && c.numbered%every == 0 {
+		return
+	}
+	msg := protocol.NewServerMessage(p)
+	msg.Seq = seq
+	c.queue(msg)
+}
+
+// reply answers the request that msg carried
+func (c *session) reply(msg protocol.ClientMessage, p protocol.ServerPayload) {
+	out := protocol.NewServerMessage(p)
+	out.RequestID = msg.RequestID
+	c.queue(out)
+}
+
+func (c *session) queue(msg protocol.ServerMessage) {
+	data, err := protocol.EncodeServer(msg)
+	if err != nil {
+		return
+	}
+	c.push(data)
+
+	c.sent++
+	if every := c.faults.GarbleEvery; every > 0 && c.sent%every == 0 {
+		c.push(garbled)
+	}
+}
+
+func (c *session) push(data []byte) {
+	select {
+	case c.out <- data:
+	case <-c.done:
+	default:
+		c.close()
+	}
+}
+
+// finish queues p as the last message; the connection closes once it is
+// written
+func (c *session) finish(p protocol.ServerPayload) {
+	c.send(p)
+	c.push(nil)
+}
+
+func (c *session) writeLoop() {
+	for {
+		select {
+		case data := <-c.out:
+			if data == nil {
+				c.close()
+				return
+			}
+			if c.faults.Delay > 0 {
+				time.Sleep(c.faults.Delay)
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				c.close()
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *session) close() {
+	c.once.Do(func() {
+		close(c.done)
+		c.conn.Close()
+	})
+}
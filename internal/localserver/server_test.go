@@ -0,0 +1,657 @@
+package localserver
+
+import (
+	"errors"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/bunnyholes/pokerhole/client/internal/network"
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+	"github.com/bunnyholes/pokerhole/client/internal/state"
+)
+
+func startServer(t *testing.T, opts Options) string {
+	t.Helper()
+	opts.Logf = t.Logf
+	srv := New(opts)
+	hs := httptest.NewServer(srv)
+	t.Cleanup(func() {
+		srv.Close()
+		hs.Close()
+	})
+	return "ws" + strings.TrimPrefix(hs.URL, "http") + "/ws/game"
+}
+
+func connect(t *testing.T, url, id, nickname string) *network.Client {
+	t.Helper()
+	c := network.NewClient(url, id, nickname)
+	c.SetBackoff(network.Backoff{Initial: 10 * time.Millisecond, Max: 50 * time.Millisecond, Multiplier: 2})
+	if err := c.ConnectWithTimeout(time.Second); err != nil {
+		t.Fatalf("connect %s: %v", nickname, err)
+	}
+	t.Cleanup(func() { c.Close() })
+	await(t, c.Receive(), protocol.ServerRegisterSuccess)
+	return c
+}
+
+// await reads messages until one of the given type arrives
+func await(t *testing.T, in <-chan protocol.ServerMessage, want protocol.ServerMessageType) protocol.ServerMessage {
+	t.Helper()
+	deadline := time.After(3 * time.Second)
+	for {
+		select {
+		case msg := <-in:
+			if msg.Type == want {
+				return msg
+			}
+		case <-deadline:
+			t.Fatalf("no %s received", want)
+			return protocol.ServerMessage{}
+		}
+	}
+}
+
+// autoplay checks or calls whenever it is c's turn and forwards every
+// message it sees
+func autoplay(c *network.Client) <-chan protocol.ServerMessage {
+	out := make(chan protocol.ServerMessage, 256)
+	go func() {
+		for msg := range c.Receive() {
+			if turn, ok := msg.Payload.(protocol.TurnChanged); ok && turn.CurrentPlayer == c.UUID() {
+				action := protocol.ClientCall
+				if slices.Contains(turn.ValidActions, protocol.ClientCheck) {
+					action = protocol.ClientCheck
+				}
+				c.SendGameAction(action, 0)
+			}
+			select {
+			case out <- msg:
+			default:
+			}
+		}
+	}()
+	return out
+}
+
+func TestRandomMatchPlaysFullHand(t *testing.T) {
+	url := startServer(t, Options{Seats: 2, Seed: 7, HandDelay: time.Hour})
+	alice := connect(t, url, "alice-id", "Alice")
+	bob := connect(t, url, "bob-id", "Bob")
+
+	alice.JoinRandomMatch()
+	if started := await(t, alice.Receive(), protocol.ServerMatchingStarted).Payload.(protocol.MatchingStarted); started.RequiredPlayers != 2 {
+		t.Fatalf("required players = %d, want 2", started.RequiredPlayers)
+	}
+	bob.JoinRandomMatch()
+
+	aliceIn, bobIn := autoplay(alice), autoplay(bob)
+	game := await(t, aliceIn, protocol.ServerGameStarted).Payload.(protocol.GameStarted)
+	if len(game.Players) != 2 || game.Pot != 30 {
+		t.Fatalf("unexpected first state: %+v", game.GameState)
+	}
+	for _, p := range game.Players {
+		if (p.ID == "alice-id") != (len(p.HoleCards) == 2) {
+			t.Errorf("hole cards of %s leaked or missing: %v", p.Nickname, p.HoleCards)
+		}
+	}
+
+	result := await(t, aliceIn, protocol.ServerRoundCompleted).Payload.(protocol.RoundCompleted)
+	if result.Pot != 40 {
+		t.Errorf("check-down pot = %d, want 40", result.Pot)
+	}
+	if err := result.Validate(); err != nil {
+		t.Errorf("invalid result: %v", err)
+	}
+
+	final := await(t, bobIn, protocol.ServerGameStateUpdate).Payload.(protocol.GameStateUpdate)
+	for final.Round != "SHOWDOWN" {
+		final = await(t, bobIn, protocol.ServerGameStateUpdate).Payload.(protocol.GameStateUpdate)
+	}
+	total := 0
+	for _, p := range final.Players {
+		total += p.Chips
+		if len(p.HoleCards) != 2 {
+			t.Errorf("showdown should reveal %s's cards", p.Nickname)
+		}
+	}
+	if total != 2000 || len(final.CommunityCards) != 5 {
+		t.Errorf("showdown chips = %d board = %v", total, final.CommunityCards)
+	}
+}
+
+func TestCodeRooms(t *testing.T) {
+	url := startServer(t, Options{HandDelay: time.Hour})
+	host := connect(t, url, "host-id", "Host")
+	guest := connect(t, url, "guest-id", "Guest")
+	late := connect(t, url, "late-id", "Late")
+
+	host.CreateRoom(5, 10, 2)
+	room := await(t, host.Receive(), protocol.ServerRoomCreated).Payload.(protocol.RoomCreated)
+	if !protocol.ValidRoomCode(room.Code) || room.Seats != 2 {
+		t.Fatalf("unexpected room: %+v", room)
+	}
+
+	guest.JoinCodeMatch("ZZZZZZ")
+	if e := await(t, guest.Receive(), protocol.ServerError).Payload.(protocol.Error); e.Code != protocol.ErrorRoomNotFound {
+		t.Errorf("unknown code error = %q", e.Code)
+	}
+
+	guest.JoinCodeMatch(strings.ToLower(room.Code))
+	started := await(t, guest.Receive(), protocol.ServerGameStarted).Payload.(protocol.GameStarted)
+	if started.Pot != 15 {
+		t.Errorf("room blinds not used, pot = %d", started.Pot)
+	}
+	await(t, host.Receive(), protocol.ServerGameStarted)
+
+	late.JoinCodeMatch(room.Code)
+	if e := await(t, late.Receive(), protocol.ServerError).Payload.(protocol.Error); e.Code != protocol.ErrorRoomFull {
+		t.Errorf("full room error = %q", e.Code)
+	}
+
+	guest.SendPayload(protocol.SendChat{Message: "안녕하세요"})
+	chat := await(t, host.Receive(), protocol.ServerChatMessage).Payload.(protocol.ChatReceived)
+	if chat.Nickname != "Guest" || chat.Message != "안녕하세요" {
+		t.Errorf("unexpected chat: %+v", chat)
+	}
+}
+
+func TestRoomExpires(t *testing.T) {
+	url := startServer(t, Options{RoomTTL: time.Millisecond})
+	host := connect(t, url, "host-id", "Host")
+	guest := connect(t, url, "guest-id", "Guest")
+
+	host.CreateRoom(10, 20, 4)
+	room := await(t, host.Receive(), protocol.ServerRoomCreated).Payload.(protocol.RoomCreated)
+	time.Sleep(10 * time.Millisecond)
+
+	guest.JoinCodeMatch(room.Code)
+	if e := await(t, guest.Receive(), protocol.ServerError).Payload.(protocol.Error); e.Code != protocol.ErrorRoomExpired {
+		t.Errorf("expired room error = %q", e.Code)
+	}
+	await(t, host.Receive(), protocol.ServerGameEnded)
+}
+
+func TestBotsFillMatchAndSpectators(t *testing.T) {
+	url := startServer(t, Options{Seats: 3, BotFill: 10 * time.Millisecond, BotDelay: time.Millisecond, HandDelay: time.Hour})
+	solo := connect(t, url, "solo-id", "Solo")
+	watcher := connect(t, url, "watcher-id", "Watcher")
+
+	solo.JoinRandomMatch()
+	soloIn := autoplay(solo)
+	game := await(t, soloIn, protocol.ServerGameStarted).Payload.(protocol.GameStarted)
+	if len(game.Players) != 3 {
+		t.Fatalf("expected bots to fill 3 seats, got %d", len(game.Players))
+	}
+
+	watcher.SendPayload(protocol.ListTables{})
+	list := await(t, watcher.Receive(), protocol.ServerTableList).Payload.(protocol.TableList)
+	if len(list.Tables) != 1 || list.Tables[0].GameID != game.GameID {
+		t.Fatalf("unexpected tables: %+v", list.Tables)
+	}
+
+	watcher.SendPayload(protocol.SpectateGame{GameID: game.GameID})
+	await(t, watcher.Receive(), protocol.ServerSpectateStarted)
+	snapshot := await(t, watcher.Receive(), protocol.ServerGameStateUpdate).Payload.(protocol.GameStateUpdate)
+	for _, p := range snapshot.Players {
+		if len(p.HoleCards) > 0 && snapshot.Round != "SHOWDOWN" {
+			t.Errorf("spectator sees %s's cards", p.Nickname)
+		}
+	}
+	if changed := await(t, soloIn, protocol.ServerSpectatorsChanged).Payload.(protocol.SpectatorsChanged); changed.Spectators != 1 {
+		t.Errorf("spectators = %d, want 1", changed.Spectators)
+	}
+
+	await(t, soloIn, protocol.ServerRoundCompleted)
+}
+
+func TestProtocolVersion(t *testing.T) {
+	t.Run("current client", func(t *testing.T) {
+		c := connect(t, startServer(t, Options{MinVersion: protocol.Version}), "id", "Nick")
+		if c.ServerVersion() != protocol.Version || !c.Supports(protocol.CapHostTools) {
+			t.Errorf("server declared v%d, host tools %v", c.ServerVersion(), c.Supports(protocol.CapHostTools))
+		}
+	})
+
+	t.Run("outdated client", func(t *testing.T) {
+		c := network.NewClient(startServer(t, Options{MinVersion: protocol.Version + 1}), "id", "Nick")
+		defer c.Close()
+		if err := c.ConnectWithTimeout(time.Second); err != nil {
+			t.Fatal(err)
+		}
+		failure := await(t, c.Receive(), protocol.ServerRegisterFailure).Payload.(protocol.RegisterFailure)
+		if failure.Code != protocol.RegisterUpgradeRequired || failure.MinVersion != protocol.Version+1 {
+			t.Errorf("failure = %+v", failure)
+		}
+
+		var upgrade *network.UpgradeError
+		for change := range c.States() {
+			if change.State == network.StateFailed {
+				if !errors.As(change.Err, &upgrade) || upgrade.Required != protocol.Version+1 {
+					t.Fatalf("expected an upgrade error, got %v", change.Err)
+				}
+				break
+			}
+		}
+		if c.IsConnected() {
+			t.Error("an outdated client should give up the connection")
+		}
+		if err := c.ConnectWithTimeout(time.Second); !errors.Is(err, network.ErrUpgradeRequired) {
+			t.Errorf("reconnecting to the same server should fail, got %v", err)
+		}
+	})
+
+	t.Run("legacy client", func(t *testing.T) {
+		conn, err := network.WebSocketDialer{}.Dial(t.Context(), startServer(t, Options{MinVersion: 2}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"REGISTER","timestamp":1,"payload":{"uuid":"old","nickname":"Old"}}`))
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg, err := protocol.DecodeServer(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f, ok := msg.Payload.(protocol.RegisterFailure); !ok || f.Code != protocol.RegisterUpgradeRequired {
+			t.Errorf("expected a version-less REGISTER to be refused, got %+v", msg)
+		}
+	})
+}
+
+func TestTurnClock(t *testing.T) {
+	url := startServer(t, Options{BotFill: time.Millisecond, BotDelay: time.Hour, HandDelay: time.Hour, TurnTimeout: 50 * time.Millisecond})
+	c := connect(t, url, "id", "Nick")
+	c.JoinRandomMatch()
+
+	var turn protocol.TurnChanged
+	for turn.CurrentPlayer != "id" {
+		turn = await(t, c.Receive(), protocol.ServerTurnChanged).Payload.(protocol.TurnChanged)
+	}
+	if until := time.Until(time.UnixMilli(turn.Deadline)); until <= 0 || until > 50*time.Millisecond {
+		t.Fatalf("deadline %v away, want within the 50ms clock", until)
+	}
+
+	// Nobody acts, so the clock checks or folds for the player
+	acted := await(t, c.Receive(), protocol.ServerPlayerAction).Payload.(protocol.PlayerActed)
+	if acted.PlayerID != "id" || acted.Action != protocol.ClientCheck && acted.Action != protocol.ClientFold {
+		t.Errorf("expected the clock to act for the player, got %+v", acted)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	t.Run("reject register", func(t *testing.T) {
+		url := startServer(t, Options{Faults: Faults{RejectRegister: "maintenance"}})
+		c := network.NewClient(url, "id", "Nick")
+		defer c.Close()
+		if err := c.ConnectWithTimeout(time.Second); err != nil {
+			t.Fatal(err)
+		}
+		if f := await(t, c.Receive(), protocol.ServerRegisterFailure).Payload.(protocol.RegisterFailure); f.Reason != "maintenance" {
+			t.Errorf("reason = %q", f.Reason)
+		}
+	})
+
+	t.Run("reject actions", func(t *testing.T) {
+		url := startServer(t, Options{BotFill: time.Millisecond, HandDelay: time.Hour, Faults: Faults{RejectActions: []protocol.ClientMessageType{protocol.ClientFold}}})
+		c := connect(t, url, "id", "Nick")
+		c.JoinRandomMatch()
+		await(t, c.Receive(), protocol.ServerGameStarted)
+
+		id, err := c.Fold()
+		if err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case res := <-c.Results():
+			var reqErr *network.RequestError
+			if res.RequestID != id || !errors.As(res.Err, &reqErr) {
+				t.Errorf("expected rejected fold, got %+v", res)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("no result for fold")
+		}
+	})
+
+	t.Run("drop and resume", func(t *testing.T) {
+		// REGISTER, the first HEARTBEAT, JOIN_RANDOM_MATCH, then the chat drops it
+		url := startServer(t, Options{BotFill: time.Millisecond, HandDelay: time.Hour, Faults: Faults{DropAfter: 4}})
+		c := connect(t, url, "id", "Nick")
+		c.JoinRandomMatch()
+		game := await(t, c.Receive(), protocol.ServerGameStarted).Payload.(protocol.GameStarted)
+		c.SendPayload(protocol.SendChat{Message: "bye"})
+
+		reconnected := false
+		for deadline := time.After(3 * time.Second); !reconnected; {
+			select {
+			case change := <-c.States():
+				reconnected = change.State == network.StateConnected
+			case <-c.Receive():
+			case <-deadline:
+				t.Fatal("client did not reconnect")
+			}
+		}
+		if c.GameID() != game.GameID {
+			t.Fatalf("client forgot game %s", game.GameID)
+		}
+
+		// Only a resumed seat is answered with the player's own cards
+		time.Sleep(50 * time.Millisecond)
+		for len(c.Receive()) > 0 {
+			<-c.Receive()
+		}
+		c.SendPayload(protocol.RequestSync{GameID: game.GameID})
+		snapshot := await(t, c.Receive(), protocol.ServerGameStateUpdate).Payload.(protocol.GameStateUpdate)
+		for _, p := range snapshot.Players {
+			if p.ID == "id" && len(p.HoleCards) != 2 {
+				t.Errorf("resumed seat has no hole cards: %+v", p)
+			}
+		}
+	})
+
+	t.Run("lose updates", func(t *testing.T) {
+		url := startServer(t, Options{BotFill: time.Millisecond, BotDelay: time.Millisecond, HandDelay: time.Hour, Faults: Faults{LoseEvery: 3}})
+		c := connect(t, url, "id", "Nick")
+		// A lost sync reply or a lost last update is only noticed by the timers
+		c.SetSyncTimers(50*time.Millisecond, 200*time.Millisecond)
+		c.JoinRandomMatch()
+
+		// Play from the client's own table, asking for it whenever a gap shows
+		table := state.NewGameState()
+		gaps, acted := 0, int64(-1)
+		for deadline := time.After(3 * time.Second); gaps == 0 || table.Syncing() || len(table.GetSnapshot().Winners) == 0; {
+			select {
+			case msg := <-c.Receive():
+				if table.Update(msg) == state.Gap {
+					gaps++
+					c.SendPayload(protocol.RequestSync{GameID: table.GetSnapshot().GameID})
+				}
+			case <-deadline:
+				t.Fatalf("table did not settle: %d gaps, syncing %v at seq %d", gaps, table.Syncing(), table.Seq())
+			}
+
+			if snap := table.GetSnapshot(); snap.CurrentPlayer == "id" && !table.Syncing() && acted != table.Seq() {
+				acted = table.Seq()
+				action := protocol.ClientCall
+				if slices.Contains(snap.ValidActions, string(protocol.ClientCheck)) {
+					action = protocol.ClientCheck
+				}
+				c.SendGameAction(action, 0)
+			}
+		}
+	})
+
+	t.Run("garble", func(t *testing.T) {
+		url := startServer(t, Options{Faults: Faults{GarbleEvery: 1}})
+		c := network.NewClient(url, "id", "Nick")
+		defer c.Close()
+		if err := c.ConnectWithTimeout(time.Second); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case err := <-c.Errors():
+			if !errors.Is(err, protocol.ErrInvalidPayload) {
+				t.Errorf("expected validation error, got %v", err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("garbled message not surfaced")
+		}
+	})
+}
+
+// dialRaw registers id on a bare connection, for tests that need what the
+// client library keeps to itself. Messages arrive on the returned channel,
+// which closes with the connection.
+func dialRaw(t *testing.T, url, id string) (network.Transport, <-chan protocol.ServerMessage, protocol.RegisterSuccess) {
+	t.Helper()
+	conn, err := network.WebSocketDialer{}.Dial(t.Context(), url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	in := make(chan protocol.ServerMessage, 256)
+	go func() {
+		defer close(in)
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if msg, err := protocol.DecodeServer(data); err == nil {
+				in <- msg
+			}
+		}
+	}()
+	sendRaw(t, conn, protocol.Register{UUID: id, Nickname: "Raw", ProtocolVersion: protocol.Version})
+	registered := await(t, in, protocol.ServerRegisterSuccess).Payload.(protocol.RegisterSuccess)
+	return conn, in, registered
+}
+
+func sendRaw(t *testing.T, conn network.Transport, p protocol.ClientPayload) {
+	t.Helper()
+	data, err := protocol.EncodeClient(protocol.NewClientMessage(p))
+	if err == nil {
+		err = conn.WriteMessage(websocket.TextMessage, data)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResumeNeedsToken(t *testing.T) {
+	url := startServer(t, Options{BotFill: time.Millisecond, BotDelay: time.Hour, HandDelay: time.Hour})
+	owner, ownerIn, registered := dialRaw(t, url, "id")
+	if registered.ResumeToken == "" {
+		t.Fatal("REGISTER_SUCCESS carries no resume token")
+	}
+	sendRaw(t, owner, protocol.JoinRandomMatch{})
+	game := await(t, ownerIn, protocol.ServerGameStarted).Payload.(protocol.GameStarted)
+
+	// The UUID is public; without the token the seat stays where it is
+	for _, token := range []string{"", "guess"} {
+		thief, thiefIn, _ := dialRaw(t, url, "id")
+		sendRaw(t, thief, protocol.ResumeGame{GameID: game.GameID, Token: token})
+		if ended := await(t, thiefIn, protocol.ServerGameEnded).Payload.(protocol.GameEnded); ended.GameID != game.GameID {
+			t.Errorf("token %q: ended %+v", token, ended)
+		}
+	}
+	sendRaw(t, owner, protocol.RequestSync{GameID: game.GameID})
+	await(t, ownerIn, protocol.ServerGameStateUpdate)
+
+	// The owner's next connection takes over and the open one is told why
+	next, nextIn, _ := dialRaw(t, url, "id")
+	sendRaw(t, next, protocol.ResumeGame{GameID: game.GameID, Token: registered.ResumeToken})
+	if ended := await(t, ownerIn, protocol.ServerGameEnded).Payload.(protocol.GameEnded); ended.Reason == "" {
+		t.Errorf("replaced connection got no reason: %+v", ended)
+	}
+	for deadline := time.After(3 * time.Second); ; {
+		select {
+		case _, open := <-ownerIn:
+			if open {
+				continue
+			}
+		case <-deadline:
+			t.Fatal("replaced connection was left open")
+		}
+		break
+	}
+
+	sendRaw(t, next, protocol.RequestSync{GameID: game.GameID})
+	snapshot := await(t, nextIn, protocol.ServerGameStateUpdate).Payload.(protocol.GameStateUpdate)
+	for _, p := range snapshot.Players {
+		if p.ID == "id" && len(p.HoleCards) != 2 {
+			t.Errorf("resumed seat has no hole cards: %+v", p)
+		}
+	}
+}
+
+func TestHostListen(t *testing.T) {
+	host, err := Listen("127.0.0.1:0", Options{Logf: t.Logf})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(host.LocalURL(), Path) || host.Port() == 0 {
+		t.Fatalf("unexpected local URL %s", host.LocalURL())
+	}
+
+	c := connect(t, host.LocalURL(), "guest-id", "Guest")
+	c.CreateRoom(10, 20, 2)
+	await(t, c.Receive(), protocol.ServerRoomCreated)
+
+	if err := host.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	again := network.NewClient(host.LocalURL(), "late-id", "Late")
+	defer again.Close()
+	if err := again.ConnectWithTimeout(200 * time.Millisecond); err == nil {
+		t.Fatal("closed host still accepts connections")
+	}
+}
+
+// awaitState reads state updates until one matches
+func awaitState(t *testing.T, in <-chan protocol.ServerMessage, what string, match func(protocol.GameState) bool) protocol.GameState {
+	t.Helper()
+	deadline := time.After(3 * time.Second)
+	for {
+		select {
+		case msg := <-in:
+			if update, ok := msg.Payload.(protocol.GameStateUpdate); ok && match(update.GameState) {
+				return update.GameState
+			}
+		case <-deadline:
+			t.Fatalf("no state with %s", what)
+			return protocol.GameState{}
+		}
+	}
+}
+
+func TestHostTools(t *testing.T) {
+	url := startServer(t, Options{HandDelay: 20 * time.Millisecond})
+	host := connect(t, url, "host-id", "Host")
+	guest := connect(t, url, "guest-id", "Guest")
+	rude := connect(t, url, "rude-id", "Rude")
+
+	host.SendPayload(protocol.CreateRoom{SmallBlind: 5, BigBlind: 10, Seats: 2, ApproveSeats: true})
+	room := await(t, host.Receive(), protocol.ServerRoomCreated).Payload.(protocol.RoomCreated)
+
+	// Joins wait for the host, who can turn them down
+	rude.JoinCodeMatch(room.Code)
+	await(t, rude.Receive(), protocol.ServerMatchingStarted)
+	if req := await(t, host.Receive(), protocol.ServerSeatRequested).Payload.(protocol.SeatRequested); req.PlayerID != "rude-id" || req.Nickname != "Rude" {
+		t.Fatalf("unexpected seat request: %+v", req)
+	}
+	rude.SendPayload(protocol.HostPause{})
+	if e := await(t, rude.Receive(), protocol.ServerError).Payload.(protocol.Error); e.Code != protocol.ErrorNotHost {
+		t.Errorf("non-host command error = %q", e.Code)
+	}
+	host.SendPayload(protocol.HostSeatDecision{PlayerID: "rude-id"})
+	if e := await(t, rude.Receive(), protocol.ServerError).Payload.(protocol.Error); e.Code != protocol.ErrorSeatDenied {
+		t.Errorf("denied seat error = %q", e.Code)
+	}
+
+	guest.JoinCodeMatch(room.Code)
+	await(t, host.Receive(), protocol.ServerSeatRequested)
+	host.SendPayload(protocol.HostSeatDecision{PlayerID: "guest-id", Approve: true})
+	started := await(t, guest.Receive(), protocol.ServerGameStarted).Payload.(protocol.GameStarted)
+	if started.HostID != "host-id" {
+		t.Errorf("host id = %q", started.HostID)
+	}
+
+	// Nobody can act while the game is paused
+	host.SendPayload(protocol.HostPause{})
+	awaitState(t, guest.Receive(), "pause", func(s protocol.GameState) bool { return s.Paused && len(s.ValidActions) == 0 })
+	current := host
+	if started.CurrentPlayer == "guest-id" {
+		current = guest
+	}
+	id, _ := current.Fold()
+	select {
+	case res := <-current.Results():
+		var reqErr *network.RequestError
+		if res.RequestID != id || !errors.As(res.Err, &reqErr) || !strings.Contains(reqErr.Reason, "일시정지") {
+			t.Errorf("expected fold refused while paused, got %+v", res)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no result for fold")
+	}
+
+	// Stack adjustments are announced to the whole table
+	host.SendPayload(protocol.HostAdjustStack{PlayerID: "guest-id", Amount: 500, Reason: "리바이"})
+	adjusted := await(t, guest.Receive(), protocol.ServerHostAction).Payload.(protocol.HostActed)
+	for adjusted.Action != protocol.ClientHostAdjustStack {
+		adjusted = await(t, guest.Receive(), protocol.ServerHostAction).Payload.(protocol.HostActed)
+	}
+	if adjusted.PlayerID != "guest-id" || adjusted.Amount != 500 || adjusted.Reason != "리바이" || adjusted.Chips < 1490 {
+		t.Errorf("unexpected adjustment: %+v", adjusted)
+	}
+	// The host's UUID is public; its commands also need the host token
+	thief, thiefIn, _ := dialRaw(t, url, "host-id")
+	sendRaw(t, thief, protocol.ResumeGame{GameID: started.GameID})
+	sendRaw(t, thief, protocol.HostAdjustStack{PlayerID: "host-id", Amount: 100000, Reason: "몰래"})
+	if e := await(t, thiefIn, protocol.ServerError).Payload.(protocol.Error); e.Code != protocol.ErrorNotHost {
+		t.Errorf("second connection with the host's UUID: error = %q", e.Code)
+	}
+	forged := protocol.NewClientMessage(protocol.HostAdjustStack{PlayerID: "host-id", Amount: 100000, Reason: "몰래"})
+	forged.HostToken = "guess"
+	host.Send(forged)
+	if e := await(t, host.Receive(), protocol.ServerError).Payload.(protocol.Error); e.Code != protocol.ErrorNotHost {
+		t.Errorf("forged host token: error = %q", e.Code)
+	}
+
+	host.SendPayload(protocol.HostAdjustStack{PlayerID: "guest-id", Amount: -100000, Reason: "실수"})
+	if e := await(t, host.Receive(), protocol.ServerError).Payload.(protocol.Error); !strings.Contains(e.Message, "칩이 부족") {
+		t.Errorf("oversized removal error = %q", e.Message)
+	}
+
+	// Blinds and sit-outs wait for the next hand; with the guest sitting
+	// out the table pauses instead of ending
+	host.SendPayload(protocol.HostSetBlinds{SmallBlind: 25, BigBlind: 50})
+	host.SendPayload(protocol.HostSitOut{PlayerID: "guest-id", SitOut: true})
+	host.SendPayload(protocol.HostResume{})
+	awaitState(t, host.Receive(), "resume", func(s protocol.GameState) bool { return !s.Paused && s.CurrentPlayer == started.CurrentPlayer })
+	current.Fold()
+	awaitState(t, host.Receive(), "waiting for players", func(s protocol.GameState) bool { return s.Paused && s.CurrentPlayer == "" })
+
+	host.SendPayload(protocol.HostSitOut{PlayerID: "guest-id"})
+	host.SendPayload(protocol.HostResume{})
+	next := awaitState(t, host.Receive(), "next hand", func(s protocol.GameState) bool { return !s.Paused && s.CurrentPlayer != "" })
+	if next.Pot != 75 {
+		t.Errorf("new blinds not used, pot = %d", next.Pot)
+	}
+}
+
+func TestHostKicksAndHandsOver(t *testing.T) {
+	url := startServer(t, Options{HandDelay: time.Hour})
+	host := connect(t, url, "host-id", "Host")
+	guest := connect(t, url, "guest-id", "Guest")
+	other := connect(t, url, "other-id", "Other")
+
+	host.CreateRoom(5, 10, 3)
+	room := await(t, host.Receive(), protocol.ServerRoomCreated).Payload.(protocol.RoomCreated)
+	guest.JoinCodeMatch(room.Code)
+	await(t, guest.Receive(), protocol.ServerMatchingStarted)
+
+	host.SendPayload(protocol.HostKick{PlayerID: "guest-id"})
+	if e := await(t, guest.Receive(), protocol.ServerError).Payload.(protocol.Error); e.Code != protocol.ErrorKicked {
+		t.Errorf("kick error = %q", e.Code)
+	}
+
+	// The room keeps going under the next player when the host leaves
+	other.JoinCodeMatch(room.Code)
+	await(t, other.Receive(), protocol.ServerMatchingStarted)
+	host.SendPayload(protocol.CancelMatching{})
+	await(t, host.Receive(), protocol.ServerMatchingCancelled)
+	await(t, other.Receive(), protocol.ServerHostGranted)
+	other.SendPayload(protocol.HostSetBlinds{SmallBlind: 10, BigBlind: 20})
+	if acted := await(t, other.Receive(), protocol.ServerHostAction).Payload.(protocol.HostActed); acted.BigBlind != 20 {
+		t.Errorf("unexpected host action: %+v", acted)
+	}
+}
@@ -0,0 +1,770 @@
+package localserver
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/bunnyholes/pokerhole/client/internal/adapter/out/deck"
+	"github.com/bunnyholes/pokerhole/client/internal/core/application/service"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/card"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/game"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/game/vo"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/player"
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+)
+
+// seat is one player at a table. Session is nil for bots and for players
+// whose connection dropped.
+type seat struct {
+	table       *table
+	id          string
+	player      *player.Player
+	session     *session
+	token       string // resume token of the connection that holds the seat
+	bot         bool
+	left        bool
+	sitOut      bool // the host benched this seat from the next hand
+	contributed int  // chips put in during the current hand
+}
+
+// table runs no-limit hold'em hands on the domain engine. Every method
+// expects the server mutex to be held.
+type table struct {
+	srv        *Server
+	id         string
+	code       string
+	smallBlind int
+	bigBlind   int
+	size       int
+	created    time.Time
+
+	seats      []*seat
+	spectators map[*session]bool
+	started    bool
+	over       bool
+
+	// Host tools for code rooms
+	hostID         string
+	hostToken      string     // secret the host's commands must carry
+	grantDue       bool       // the host got the tools while disconnected
+	approve        bool       // joins wait for the host's decision
+	requests       []*session // joins waiting for approval
+	paused         bool
+	handDue        bool // the next hand was due while paused
+	nextSmallBlind int  // blinds for the next hand, 0 when unchanged
+	nextBigBlind   int
+	log            []protocol.HostActed
+
+	deck     *deck.LocalDeck
+	engine   *service.GameService
+	resolver *game.WinnerResolver
+
+	hand       int
+	dealer     int
+	round      vo.BettingRound
+	board      []card.Card
+	pot        int // chips from finished betting rounds
+	currentBet int
+	minRaise   int
+	turn       int       // seat index to act, -1 between hands
+	deadline   time.Time // when the turn clock runs out, zero without one
+	seq        int64     // number of the last game update sent
+	acted      map[int]bool
+	showdown   bool
+	result     []protocol.Winner // of the hand just finished, until the next is dealt
+}
+
+func newTable(srv *Server, id, code string, smallBlind, bigBlind, size int) *table {
+	d := deck.NewLocalDeck()
+	evaluator := game.NewHandEvaluator()
+	return &table{
+		srv:        srv,
+		id:         id,
+		code:       code,
+		smallBlind: smallBlind,
+		bigBlind:   bigBlind,
+		size:       size,
+		created:    time.Now(),
+		spectators: make(map[*session]bool),
+		deck:       d,
+		engine:     service.NewGameService(d, evaluator),
+		resolver:   game.NewWinnerResolver(evaluator),
+		dealer:     -1,
+		turn:       -1,
+	}
+}
+
+// sit adds a player; sess is nil for a bot
+func (t *table) sit(id, nickname string, sess *session) {
+	name, err := player.NewNickname(nickname)
+	if err != nil {
+		name, _ = player.NewNickname("Player")
+	}
+	p, _ := player.NewPlayer(player.GeneratePlayerId(), name, t.srv.opts.StartChips)
+	p.SetPosition(len(t.seats))
+
+	st := &seat{table: t, id: id, player: p, session: sess, bot: sess == nil}
+	t.seats = append(t.seats, st)
+	if sess != nil {
+		st.token = sess.token
+		sess.seat = st
+	}
+}
+
+// unseat removes a player from a table that has not started yet
+func (t *table) unseat(st *seat) {
+	t.passHost(st)
+	t.seats = slices.DeleteFunc(t.seats, func(s *seat) bool { return s == st })
+	if st.session != nil && st.session.seat == st {
+		st.session.seat = nil
+	}
+	if len(t.seats) == 0 {
+		t.srv.removeTable(t)
+		return
+	}
+	t.roomProgress()
+}
+
+func (t *table) seatOf(id string) *seat {
+	for _, st := range t.seats {
+		if st.id == id {
+			return st
+		}
+	}
+	return nil
+}
+
+// roomProgress tells everyone waiting in a room how full it is
+func (t *table) roomProgress() {
+	for _, st := range t.seats {
+		if st.session != nil {
+			st.session.send(protocol.MatchingProgress{CurrentPlayers: len(t.seats), RequiredPlayers: t.size})
+		}
+	}
+}
+
+// start announces the match and deals the first hand
+func (t *table) start() {
+	t.started = true
+	t.denyRequests(protocol.Error{Code: protocol.ErrorRoomFull, Message: "room is full"})
+	for _, st := range t.seats {
+		if st.session != nil {
+			st.session.send(protocol.MatchingCompleted{GameID: t.id})
+		}
+	}
+	t.srv.opts.Logf("localserver: table %s started with %d players", t.id, len(t.seats))
+	t.startHand()
+}
+
+func (t *table) startHand() {
+	var live []*player.Player
+	for _, st := range t.seats {
+		p := st.player
+		p.ResetBet()
+		p.SetHand(card.NewHand(nil))
+		st.contributed = 0
+		if st.sitsOut() {
+			p.SetStatus(player.SitOut)
+			continue
+		}
+		p.SetStatus(player.Active)
+		live = append(live, p)
+	}
+	if len(live) < 2 {
+		if !t.waitForPlayers() {
+			t.close("승자가 결정되었습니다.")
+		}
+		return
+	}
+	if t.nextBigBlind > 0 {
+		t.smallBlind, t.bigBlind = t.nextSmallBlind, t.nextBigBlind
+		t.nextSmallBlind, t.nextBigBlind = 0, 0
+	}
+
+	t.hand++
+	t.deck.Reset()
+	t.deck.Shuffle(t.srv.opts.Seed + int64(t.hand))
+	t.board = nil
+	t.pot = 0
+	t.round = vo.PreFlop
+	t.showdown = false
+	t.result = nil
+	t.acted = make(map[int]bool)
+
+	// Heads-up the dealer posts the small blind
+	t.dealer = t.next(t.dealer, inHand)
+	sb := t.dealer
+	if len(live) > 2 {
+		sb = t.next(sb, inHand)
+	}
+	bb := t.next(sb, inHand)
+	t.post(sb, t.smallBlind)
+	t.post(bb, t.bigBlind)
+	t.currentBet = max(t.seats[sb].player.Bet(), t.seats[bb].player.Bet())
+	t.minRaise = t.bigBlind
+
+	if err := t.engine.DealHoleCards(live); err != nil {
+		t.close("카드를 나눠 줄 수 없습니다.")
+		return
+	}
+
+	t.turn = (bb + 1) % len(t.seats)
+	if t.hand == 1 {
+		t.publish(func(c *session) protocol.ServerPayload { return protocol.GameStarted{GameState: t.state(c)} })
+	}
+	t.advance()
+}
+
+func inHand(p *player.Player) bool {
+	return p.Status() != player.Folded && p.Status() != player.SitOut
+}
+
+func canAct(p *player.Player) bool {
+	return p.Status() == player.Active
+}
+
+// next returns the first seat after from that matches, or from itself
+func (t *table) next(from int, match func(*player.Player) bool) int {
+	for i := 1; i <= len(t.seats); i++ {
+		idx := (from + i + len(t.seats)) % len(t.seats)
+		if match(t.seats[idx].player) {
+			return idx
+		}
+	}
+	return from
+}
+
+func (t *table) count(match func(*player.Player) bool) int {
+	n := 0
+	for _, st := range t.seats {
+		if match(st.player) {
+			n++
+		}
+	}
+	return n
+}
+
+// post moves chips from a player into their bet, going all-in when short
+func (t *table) post(idx, amount int) {
+	st := t.seats[idx]
+	if amount >= st.player.Chips() {
+		st.contributed += st.player.Chips()
+		st.player.AllIn()
+		return
+	}
+	st.contributed += amount
+	st.player.PlaceBet(amount)
+}
+
+var (
+	errNotYourTurn = errors.New("차례가 아닙니다.")
+	errMustCall    = errors.New("베팅이 있어 체크할 수 없습니다.")
+	errNothingCall = errors.New("콜할 베팅이 없습니다.")
+	errNoChips     = errors.New("남은 칩이 없습니다.")
+)
+
+// act applies a betting action for the seat whose turn it is
+func (t *table) act(st *seat, action protocol.ClientMessageType, amount int) error {
+	idx := slices.Index(t.seats, st)
+	if t.over || idx != t.turn {
+		return errNotYourTurn
+	}
+	if t.paused {
+		return errPaused
+	}
+
+	p := st.player
+	owed := t.currentBet - p.Bet()
+	switch action {
+	case protocol.ClientFold:
+		p.Fold()
+	case protocol.ClientCheck:
+		if owed > 0 {
+			return errMustCall
+		}
+	case protocol.ClientCall:
+		if owed <= 0 {
+			return errNothingCall
+		}
+		t.post(idx, owed)
+		amount = p.Bet()
+	case protocol.ClientRaise:
+		add := amount - p.Bet()
+		switch {
+		case amount <= t.currentBet:
+			return fmt.Errorf("레이즈는 %d보다 커야 합니다.", t.currentBet)
+		case add > p.Chips():
+			return fmt.Errorf("칩이 부족합니다 (최대 %d).", p.Bet()+p.Chips())
+		case add < p.Chips() && amount < t.currentBet+t.minRaise:
+			return fmt.Errorf("최소 레이즈는 %d입니다.", t.currentBet+t.minRaise)
+		}
+		t.post(idx, add)
+		t.raiseTo(amount)
+	case protocol.ClientAllIn:
+		if p.Chips() == 0 {
+			return errNoChips
+		}
+		t.post(idx, p.Chips())
+		amount = p.Bet()
+		if amount > t.currentBet {
+			t.raiseTo(amount)
+		}
+	default:
+		return fmt.Errorf("알 수 없는 액션 %s", action)
+	}
+	t.acted[idx] = true
+
+	t.broadcast(protocol.PlayerActed{GameID: t.id, PlayerID: st.id, Action: action, Amount: amount})
+	t.turn = (idx + 1) % len(t.seats)
+	t.advance()
+	return nil
+}
+
+// raiseTo sets a new bet to match; only a full raise reopens the action
+func (t *table) raiseTo(total int) {
+	if raise := total - t.currentBet; raise >= t.minRaise {
+		t.minRaise = raise
+		t.acted = make(map[int]bool)
+	}
+	t.currentBet = total
+}
+
+// needsAction reports whether a seat still has to act this betting round
+func (t *table) needsAction(idx int) bool {
+	p := t.seats[idx].player
+	if !canAct(p) {
+		return false
+	}
+	return p.Bet() < t.currentBet || !t.acted[idx] && t.count(canAct) > 1
+}
+
+// advance hands the turn to the next player, or moves the hand on when
+// the betting round is over
+func (t *table) advance() {
+	if t.count(inHand) == 1 {
+		t.finishHand()
+		return
+	}
+
+	for i := 0; i < len(t.seats); i++ {
+		idx := (t.turn + i) % len(t.seats)
+		if t.needsAction(idx) {
+			t.turn = idx
+			t.broadcastState()
+			t.announceTurn()
+			return
+		}
+	}
+
+	t.collect()
+	for t.round != vo.River {
+		if err := t.dealStreet(); err != nil {
+			t.close("카드를 나눠 줄 수 없습니다.")
+			return
+		}
+		// Betting resumes only while two players can still act
+		if t.count(canAct) > 1 {
+			t.turn = t.next(t.dealer, canAct)
+			t.broadcastState()
+			t.announceTurn()
+			return
+		}
+	}
+	t.showdown = true
+	t.finishHand()
+}
+
+// collect moves the round's bets into the pot
+func (t *table) collect() {
+	for _, st := range t.seats {
+		t.pot += st.player.Bet()
+		st.player.ResetBet()
+	}
+	t.currentBet = 0
+	t.minRaise = t.bigBlind
+	t.acted = make(map[int]bool)
+}
+
+func (t *table) dealStreet() error {
+	switch t.round {
+	case vo.PreFlop:
+		flop, err := t.engine.DealFlop()
+		if err != nil {
+			return err
+		}
+		t.board = append(t.board, flop...)
+		t.round = vo.Flop
+	case vo.Flop, vo.Turn:
+		deal := t.engine.DealTurn
+		next := vo.Turn
+		if t.round == vo.Turn {
+			deal, next = t.engine.DealRiver, vo.River
+		}
+		c, err := deal()
+		if err != nil {
+			return err
+		}
+		t.board = append(t.board, c)
+		t.round = next
+	}
+
+	t.broadcast(protocol.RoundProgressed{GameID: t.id, Round: t.round.String(), CommunityCards: cardStrings(t.board)})
+	return nil
+}
+
+// finishHand pays the pot, layer by layer when someone is all-in for less
+func (t *table) finishHand() {
+	t.collect()
+	t.turn = -1
+	t.round = vo.Showdown
+
+	awards := make(map[*seat]int)
+	var order []*seat
+	award := func(st *seat, amount int) {
+		if _, ok := awards[st]; !ok {
+			order = append(order, st)
+		}
+		awards[st] += amount
+	}
+
+	var contenders []*seat
+	for _, st := range t.seats {
+		if inHand(st.player) {
+			contenders = append(contenders, st)
+		}
+	}
+
+	if len(contenders) == 1 {
+		award(contenders[0], t.pot)
+	} else {
+		levels := make([]int, 0, len(contenders))
+		for _, st := range contenders {
+			levels = append(levels, st.contributed)
+		}
+		slices.Sort(levels)
+		levels = slices.Compact(levels)
+
+		paid, floor := 0, 0
+		var last []*player.Player
+		for _, level := range levels {
+			layer := 0
+			var eligible []*player.Player
+			for _, st := range t.seats {
+				layer += min(st.contributed, level) - min(st.contributed, floor)
+				if inHand(st.player) && st.contributed >= level {
+					eligible = append(eligible, st.player)
+				}
+			}
+			floor = level
+
+			winners, err := t.resolver.DetermineWinners(eligible, t.board)
+			if err != nil || len(winners) == 0 {
+				winners = eligible
+			}
+			for i, w := range winners {
+				share := layer / len(winners)
+				if i == 0 {
+					share += layer % len(winners)
+				}
+				award(t.seatFor(w), share)
+			}
+			paid += layer
+			last = winners
+		}
+		// Bets folded above every contender's stack go to the top layer
+		if rest := t.pot - paid; rest > 0 && len(last) > 0 {
+			award(t.seatFor(last[0]), rest)
+		}
+	}
+
+	result := protocol.RoundCompleted{GameID: t.id, Pot: t.pot, Winners: []protocol.Winner{}}
+	for _, st := range order {
+		st.player.AddChips(awards[st])
+		w := protocol.Winner{PlayerID: st.id, Amount: awards[st]}
+		if t.showdown {
+			if hand, err := t.engine.EvaluateHand(st.player.Hand(), t.board); err == nil {
+				w.HandRank = hand.String()
+			}
+		}
+		result.Winners = append(result.Winners, w)
+	}
+
+	t.result = result.Winners
+	t.broadcastState()
+	t.broadcast(result)
+
+	hand := t.hand
+	time.AfterFunc(t.srv.opts.HandDelay, func() {
+		t.srv.mu.Lock()
+		defer t.srv.mu.Unlock()
+		switch {
+		case t.srv.closed || t.over || t.hand != hand:
+		case t.paused:
+			t.handDue = true
+		default:
+			t.startHand()
+		}
+	})
+}
+
+func (t *table) seatFor(p *player.Player) *seat {
+	for _, st := range t.seats {
+		if st.player == p {
+			return st
+		}
+	}
+	return nil
+}
+
+// announceTurn tells the table who acts; only that player gets the actions
+func (t *table) announceTurn() {
+	st := t.seats[t.turn]
+	actions := t.validActions(st)
+	t.deadline = time.Time{}
+	if timeout := t.srv.opts.TurnTimeout; timeout > 0 && !st.bot {
+		t.deadline = time.Now().Add(timeout)
+		t.startTurnClock(st, t.deadline)
+	}
+	t.publish(func(c *session) protocol.ServerPayload {
+		msg := protocol.TurnChanged{GameID: t.id, CurrentPlayer: st.id, ValidActions: []protocol.ClientMessageType{}, Deadline: t.turnDeadline()}
+		if c.seat == st {
+			msg.ValidActions = actions
+		}
+		return msg
+	})
+
+	if st.bot {
+		hand, turn := t.hand, t.turn
+		time.AfterFunc(t.srv.opts.BotDelay, func() {
+			t.srv.mu.Lock()
+			defer t.srv.mu.Unlock()
+			// A paused bot acts again when resume re-announces its turn
+			if !t.srv.closed && !t.over && !t.paused && t.hand == hand && t.turn == turn && t.seats[turn] == st {
+				t.botAct(st)
+			}
+		})
+	}
+}
+
+// startTurnClock checks or folds for st if the turn is still theirs at
+// the deadline. Resuming a pause re-announces the turn with a new clock.
+func (t *table) startTurnClock(st *seat, deadline time.Time) {
+	time.AfterFunc(time.Until(deadline), func() {
+		t.srv.mu.Lock()
+		defer t.srv.mu.Unlock()
+		if t.srv.closed || t.over || t.paused || !t.deadline.Equal(deadline) || t.turn < 0 || t.seats[t.turn] != st {
+			return
+		}
+		if t.act(st, protocol.ClientCheck, 0) != nil {
+			t.act(st, protocol.ClientFold, 0)
+		}
+	})
+}
+
+// turnDeadline returns the running turn clock for the wire
+func (t *table) turnDeadline() int64 {
+	if t.deadline.IsZero() || t.paused {
+		return 0
+	}
+	return t.deadline.UnixMilli()
+}
+
+// botAct plays a passive bot: check when free, call small bets, else fold
+func (t *table) botAct(st *seat) {
+	owed := t.currentBet - st.player.Bet()
+	action := protocol.ClientFold
+	switch {
+	case owed <= 0:
+		action = protocol.ClientCheck
+	case owed <= t.bigBlind*4 || owed*4 <= st.player.Chips():
+		action = protocol.ClientCall
+	}
+	if t.act(st, action, 0) != nil {
+		t.act(st, protocol.ClientFold, 0)
+	}
+}
+
+func (t *table) validActions(st *seat) []protocol.ClientMessageType {
+	p := st.player
+	owed := t.currentBet - p.Bet()
+	actions := []protocol.ClientMessageType{protocol.ClientFold}
+	if owed <= 0 {
+		actions = append(actions, protocol.ClientCheck)
+	} else {
+		actions = append(actions, protocol.ClientCall)
+	}
+	if p.Chips() > owed {
+		actions = append(actions, protocol.ClientRaise)
+	}
+	return append(actions, protocol.ClientAllIn)
+}
+
+// state renders the table as seen by c: hole cards are only shown to their
+// owner until the showdown
+func (t *table) state(c *session) protocol.GameState {
+	gs := protocol.GameState{
+		GameID:         t.id,
+		Round:          t.round.String(),
+		Pot:            t.pot,
+		CurrentBet:     t.currentBet,
+		CommunityCards: cardStrings(t.board),
+		Players:        make([]protocol.PlayerInfo, 0, len(t.seats)),
+		ValidActions:   []protocol.ClientMessageType{},
+		HostID:         t.hostID,
+		Paused:         t.paused,
+		Winners:        t.result,
+	}
+	if t.turn >= 0 {
+		gs.TurnDeadline = t.turnDeadline()
+	}
+	for i, st := range t.seats {
+		p := st.player
+		gs.Pot += p.Bet()
+		info := protocol.PlayerInfo{
+			ID:       st.id,
+			Nickname: p.Nickname().Value(),
+			Chips:    p.Chips(),
+			Bet:      p.Bet(),
+			Status:   p.Status().String(),
+			Position: i,
+		}
+		if c.seat == st || t.showdown && inHand(p) {
+			info.HoleCards = cardStrings(p.Hand().Cards())
+		}
+		gs.Players = append(gs.Players, info)
+	}
+	if t.turn >= 0 {
+		st := t.seats[t.turn]
+		gs.CurrentPlayer = st.id
+		if c.seat == st && !t.paused {
+			gs.ValidActions = t.validActions(st)
+		}
+	}
+	return gs
+}
+
+func (t *table) info() protocol.TableInfo {
+	info := protocol.TableInfo{
+		GameID:     t.id,
+		Players:    t.count(func(p *player.Player) bool { return p.Status() != player.SitOut }),
+		Seats:      t.size,
+		Spectators: len(t.spectators),
+		SmallBlind: t.smallBlind,
+		BigBlind:   t.bigBlind,
+	}
+	if t.hand > 0 {
+		info.Round = t.round.String()
+	}
+	return info
+}
+
+// each calls fn for every connected player and spectator
+func (t *table) each(fn func(*session)) {
+	for _, st := range t.seats {
+		if st.session != nil {
+			fn(st.session)
+		}
+	}
+	for c := range t.spectators {
+		fn(c)
+	}
+}
+
+// broadcast sends the next game update to everyone at the table
+func (t *table) broadcast(p protocol.ServerPayload) {
+	t.publish(func(*session) protocol.ServerPayload { return p })
+}
+
+// publish numbers the next game update and sends every player and
+// spectator their own view of it
+func (t *table) publish(view func(*session) protocol.ServerPayload) {
+	t.seq++
+	t.each(func(c *session) { c.sendSeq(t.seq, view(c)) })
+}
+
+func (t *table) broadcastState() {
+	t.publish(func(c *session) protocol.ServerPayload { return protocol.GameStateUpdate{GameState: t.state(c)} })
+}
+
+// sync sends c the whole table as of the latest numbered update
+func (t *table) sync(c *session) {
+	c.sendSeq(t.seq, protocol.GameStateUpdate{GameState: t.state(c)})
+}
+
+func (t *table) watch(c *session) {
+	t.spectators[c] = true
+	c.watching = t
+	c.send(protocol.SpectateStarted{GameID: t.id, Spectators: len(t.spectators)})
+	if t.hand > 0 {
+		t.sync(c)
+	}
+	t.broadcast(protocol.SpectatorsChanged{GameID: t.id, Spectators: len(t.spectators)})
+}
+
+func (t *table) unwatch(c *session) {
+	delete(t.spectators, c)
+	c.watching = nil
+	t.broadcast(protocol.SpectatorsChanged{GameID: t.id, Spectators: len(t.spectators)})
+}
+
+// leave gives up a seat for good; the player folds if a hand is running
+func (t *table) leave(st *seat, reason string) {
+	if c := st.session; c != nil {
+		c.send(protocol.GameEnded{GameID: t.id, Reason: reason})
+		c.seat = nil
+		st.session = nil
+	}
+	st.left = true
+	t.passHost(st)
+
+	if !t.started {
+		t.unseat(st)
+		return
+	}
+	if !slices.ContainsFunc(t.seats, func(other *seat) bool { return !other.left && !other.bot }) {
+		t.close("모든 플레이어가 나갔습니다.")
+		return
+	}
+
+	switch idx := slices.Index(t.seats, st); {
+	case t.turn == idx && !t.paused:
+		t.act(st, protocol.ClientFold, 0)
+	case t.turn >= 0 && inHand(st.player):
+		st.player.Fold()
+		if t.count(inHand) == 1 {
+			t.finishHand()
+			return
+		}
+		t.broadcastState()
+	}
+}
+
+// close ends the table for everyone still at it
+func (t *table) close(reason string) {
+	if t.over {
+		return
+	}
+	t.over = true
+	t.turn = -1
+	t.broadcast(protocol.GameEnded{GameID: t.id, Reason: reason})
+	for _, st := range t.seats {
+		if st.session != nil && st.session.seat == st {
+			st.session.seat = nil
+		}
+	}
+	for c := range t.spectators {
+		c.watching = nil
+	}
+	t.srv.removeTable(t)
+	t.srv.opts.Logf("localserver: table %s closed: %s", t.id, reason)
+}
+
+func cardStrings(cards []card.Card) []string {
+	out := make([]string, len(cards))
+	for i, c := range cards {
+		out[i] = c.String()
+	}
+	return out
+}
@@ -0,0 +1,49 @@
+package network
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Backoff configures the delay between reconnect attempts
+type Backoff struct {
+	Initial     time.Duration // delay before the first retry
+	Max         time.Duration // upper bound for any delay
+	Multiplier  float64       // growth factor per attempt
+	Jitter      float64       // random spread as a fraction of the delay (0..1)
+	MaxAttempts int           // 0 retries forever
+}
+
+// DefaultBackoff retries quickly at first and settles at 30 seconds
+var DefaultBackoff = Backoff{
+	Initial:     500 * time.Millisecond,
+	Max:         30 * time.Second,
+	Multiplier:  2,
+	Jitter:      0.5,
+	MaxAttempts: 0,
+}
+
+// Delay returns the wait before the given attempt (starting at 1)
+func (b Backoff) Delay(attempt int, rng *rand.Rand) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	base := float64(b.Initial) * math.Pow(b.Multiplier, float64(attempt-1))
+	if b.Max > 0 && base > float64(b.Max) {
+		base = float64(b.Max)
+	}
+
+	// Spread the delay over [base*(1-jitter), base] so clients that dropped
+	// together do not retry in lockstep
+	if b.Jitter > 0 && rng != nil {
+		base -= base * b.Jitter * rng.Float64()
+	}
+	return time.Duration(base)
+}
+
+// Exhausted reports whether no attempts remain after the given attempt
+func (b Backoff) Exhausted(attempt int) bool {
+	return b.MaxAttempts > 0 && attempt >= b.MaxAttempts
+}
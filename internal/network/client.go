@@ -2,169 +2,366 @@ package network
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
+
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
 )
 
-// MessageType represents client and server message types
-type ClientMessageType string
-type ServerMessageType string
-
-const (
-	// Client -> Server
-	ClientRegister    ClientMessageType = "REGISTER"
-	ClientHeartbeat   ClientMessageType = "HEARTBEAT"
-	ClientJoinRandom  ClientMessageType = "JOIN_RANDOM_MATCH"
-	ClientJoinCode    ClientMessageType = "JOIN_CODE_MATCH"
-	ClientCancelMatch ClientMessageType = "CANCEL_MATCHING"
-	ClientCall        ClientMessageType = "CALL"
-	ClientRaise       ClientMessageType = "RAISE"
-	ClientFold        ClientMessageType = "FOLD"
-	ClientCheck       ClientMessageType = "CHECK"
-	ClientAllIn       ClientMessageType = "ALL_IN"
-	ClientLeaveGame   ClientMessageType = "LEAVE_GAME"
-	ClientChatMessage ClientMessageType = "CHAT_MESSAGE"
-
-	// Server -> Client
-	ServerRegisterSuccess   ServerMessageType = "REGISTER_SUCCESS"
-	ServerRegisterFailure   ServerMessageType = "REGISTER_FAILURE"
-	ServerMatchingStarted   ServerMessageType = "MATCHING_STARTED"
-	ServerMatchingProgress  ServerMessageType = "MATCHING_PROGRESS"
-	ServerMatchingCompleted ServerMessageType = "MATCHING_COMPLETED"
-	ServerMatchingCancelled ServerMessageType = "MATCHING_CANCELLED"
-	ServerGameStarted       ServerMessageType = "GAME_STARTED"
-	ServerGameStateUpdate   ServerMessageType = "GAME_STATE_UPDATE"
-	ServerPlayerAction      ServerMessageType = "PLAYER_ACTION"
-	ServerTurnChanged       ServerMessageType = "TURN_CHANGED"     // ADDED: Turn changed notification
-	ServerRoundProgressed   ServerMessageType = "ROUND_PROGRESSED" // ADDED: Round progression (FLOP/TURN/RIVER)
-	ServerRoundCompleted    ServerMessageType = "ROUND_COMPLETED"
-	ServerGameEnded         ServerMessageType = "GAME_ENDED"
-	ServerChatMessage       ServerMessageType = "CHAT_MESSAGE" // ADDED: Chat message
-	ServerError             ServerMessageType = "ERROR"
-	ServerInvalidAction     ServerMessageType = "INVALID_ACTION"
+// ClientMessage and ServerMessage are the typed protocol messages
+type (
+	ClientMessage = protocol.ClientMessage
+	ServerMessage = protocol.ServerMessage
 )
 
-// ClientMessage represents a message from client to server
-type ClientMessage struct {
-	Type      ClientMessageType      `json:"type"`
-	Timestamp int64                  `json:"timestamp"`
-	Payload   map[string]interface{} `json:"payload,omitempty"`
-}
+var (
+	// ErrClosed is returned when connecting a client that was closed
+	ErrClosed = errors.New("client closed")
+	// ErrConnectInProgress is returned while another connection attempt is running
+	ErrConnectInProgress = errors.New("connection attempt already in progress")
+	// ErrAlreadyConnected is returned when changing the server while connected
+	ErrAlreadyConnected = errors.New("already connected")
+)
 
-// ServerMessage represents a message from server to client
-type ServerMessage struct {
-	Type      ServerMessageType      `json:"type"`
-	Timestamp int64                  `json:"timestamp"`
-	Payload   map[string]interface{} `json:"payload,omitempty"`
-}
+// defaultDialTimeout bounds Connect when no timeout is given
+const defaultDialTimeout = 45 * time.Second
+
+// defaultHeartbeatInterval is how often an idle connection sends HEARTBEAT
+const defaultHeartbeatInterval = 30 * time.Second
 
 // Client represents a WebSocket client
 type Client struct {
-	conn      *websocket.Conn
-	serverURL string
-	uuid      string
-	nickname  string
-	inbound   chan ServerMessage
-	outbound  chan ClientMessage
-	done      chan struct{}
-	connected bool
-	mu        sync.RWMutex
+	conn         Transport
+	connStop     chan struct{} // closed when the current connection is torn down
+	serverURL    string
+	uuid         string
+	nickname     string
+	inbound      chan ServerMessage
+	outbound     chan ClientMessage
+	errors       chan error
+	states       chan StateChange
+	results      chan Result
+	done         chan struct{}
+	connected    bool
+	closed       bool
+	dialing      bool
+	reconnecting bool
+	state        ConnState
+	gameID       string // game to resume after a reconnect
+	resumeToken  string // proves the seat in gameID is ours
+	hostToken    string // stamped on host commands
+	backoff      Backoff
+	dialTimeout  time.Duration
+	rng          *rand.Rand
+	dialer       Dialer
+	recorder     *Recorder // tees every frame when set
+	heartbeat    time.Duration
+	probeEvery   time.Duration // heartbeat interval on echoing servers, 0 to keep heartbeat
+	probe        chan struct{} // asks the write pump for a heartbeat now
+	link         *linkMeter    // times heartbeat echoes
+	qualities    chan LinkQuality
+
+	syncing   bool          // a REQUEST_SYNC waits for its snapshot
+	syncTimer *time.Timer   // resends REQUEST_SYNC, or asks after a quiet spell
+	syncRetry time.Duration // see SetSyncTimers
+	syncQuiet time.Duration
+
+	serverVersion int           // declared at registration
+	serverCaps    []string      // declared at registration
+	upgrade       *UpgradeError // set once the server refuses this version
+
+	pending        map[string]*pendingRequest // game actions awaiting a reply
+	requestTimeout time.Duration
+	queued         resultQueue // settled results not yet on the results channel
+	forwarding     sync.Once
+
+	mu sync.RWMutex
 }
 
 // NewClient creates a new WebSocket client
 func NewClient(serverURL, uuid, nickname string) *Client {
 	return &Client{
-		serverURL: serverURL,
-		uuid:      uuid,
-		nickname:  nickname,
-		inbound:   make(chan ServerMessage, 100),
-		outbound:  make(chan ClientMessage, 100),
-		done:      make(chan struct{}),
+		serverURL:   serverURL,
+		uuid:        uuid,
+		nickname:    nickname,
+		inbound:     make(chan ServerMessage, 100),
+		outbound:    make(chan ClientMessage, 100),
+		errors:      make(chan error, 16),
+		states:      make(chan StateChange, 16),
+		results:     make(chan Result, 64),
+		done:        make(chan struct{}),
+		backoff:     DefaultBackoff,
+		dialTimeout: 3 * time.Second,
+		dialer:      WebSocketDialer{},
+		heartbeat:   defaultHeartbeatInterval,
+		syncRetry:   defaultSyncRetry,
+		syncQuiet:   defaultSyncQuiet,
+		probe:       make(chan struct{}, 1),
+		link:        newLinkMeter(),
+		qualities:   make(chan LinkQuality, 16),
+		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
+
+		pending:        make(map[string]*pendingRequest),
+		requestTimeout: defaultRequestTimeout,
 	}
 }
 
-// Connect establishes WebSocket connection
-func (c *Client) Connect() error {
-	conn, _, err := websocket.DefaultDialer.Dial(c.serverURL, nil)
-	if err != nil {
-		return fmt.Errorf("failed to connect: %w", err)
+// UUID returns the player ID the client registers with
+func (c *Client) UUID() string {
+	return c.uuid
+}
+
+// ServerURL returns the server the client connects to
+func (c *Client) ServerURL() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.serverURL
+}
+
+// SetServerURL changes the server for the next connection attempt
+func (c *Client) SetServerURL(url string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.connected {
+		return ErrAlreadyConnected
+	}
+	if c.dialing || c.reconnecting {
+		return ErrConnectInProgress
 	}
+	c.serverURL = url
+	c.upgrade = nil
+	return nil
+}
 
+// SetBackoff replaces the reconnect backoff policy
+func (c *Client) SetBackoff(b Backoff) {
 	c.mu.Lock()
-	c.conn = conn
-	c.connected = true
-	c.mu.Unlock()
+	defer c.mu.Unlock()
+	c.backoff = b
+}
 
-	// Start read/write goroutines
-	go c.readPump()
-	go c.writePump()
+// Record writes every frame sent and received from the next connection
+// on; nil stops recording
+func (c *Client) Record(r *Recorder) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.recorder = r
+}
+
+// SetDialer replaces how the client reaches the server, for example with
+// a Replay or a FaultDialer. It applies from the next connection on.
+func (c *Client) SetDialer(d Dialer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dialer = d
+}
+
+// SetHeartbeatInterval changes how often HEARTBEAT is sent
+func (c *Client) SetHeartbeatInterval(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.heartbeat = d
+}
+
+// SetProbeInterval sends HEARTBEAT every d instead on connections to
+// servers that echo it, measuring the connection more often. Zero, the
+// default, keeps the heartbeat interval.
+func (c *Client) SetProbeInterval(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.probeEvery = d
+}
 
-	// Send REGISTER message
-	return c.Send(ClientMessage{
-		Type:      ClientRegister,
-		Timestamp: time.Now().UnixMilli(),
-		Payload: map[string]interface{}{
-			"uuid":     c.uuid,
-			"nickname": c.nickname,
-		},
-	})
+// Connect establishes WebSocket connection
+func (c *Client) Connect() error {
+	return c.ConnectWithTimeout(defaultDialTimeout)
 }
 
 // ConnectWithTimeout establishes WebSocket connection with timeout
 func (c *Client) ConnectWithTimeout(timeout time.Duration) error {
-	// Create dialer with handshake timeout
-	dialer := websocket.Dialer{
-		HandshakeTimeout: timeout,
+	c.mu.Lock()
+	switch {
+	case c.closed:
+		c.mu.Unlock()
+		return ErrClosed
+	case c.connected:
+		c.mu.Unlock()
+		return nil
+	case c.dialing || c.reconnecting:
+		c.mu.Unlock()
+		return ErrConnectInProgress
+	case c.upgrade != nil:
+		err := c.upgrade
+		c.mu.Unlock()
+		c.setState(StateChange{State: StateFailed, Err: err})
+		return err
+	}
+	c.dialing = true
+	c.dialTimeout = timeout
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		c.dialing = false
+		c.mu.Unlock()
+	}()
+
+	c.setState(StateChange{State: StateConnecting})
+
+	conn, err := c.dial(timeout)
+	if err != nil {
+		err = fmt.Errorf("failed to connect within %v: %w", timeout, err)
+		c.setState(StateChange{State: StateFailed, Err: err})
+		return err
 	}
 
-	// Create context with timeout
+	if err := c.attach(conn); err != nil {
+		c.setState(StateChange{State: StateFailed, Err: err})
+		return err
+	}
+	return nil
+}
+
+// dial opens a connection to the server through the client's dialer
+func (c *Client) dial(timeout time.Duration) (Transport, error) {
+	c.mu.RLock()
+	dialer, recorder, url := c.dialer, c.recorder, c.serverURL
+	c.mu.RUnlock()
+
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	// Dial with context
-	conn, _, err := dialer.DialContext(ctx, c.serverURL, nil)
+	conn, err := dialer.Dial(ctx, url)
 	if err != nil {
-		return fmt.Errorf("failed to connect within %v: %w", timeout, err)
+		return nil, err
+	}
+	if recorder != nil {
+		conn = &recordingConn{Transport: conn, rec: recorder}
 	}
+	return conn, nil
+}
 
+// attach registers on a fresh connection and starts its pumps. REGISTER,
+// and when a game is in progress on a server that resumes games
+// RESUME_GAME and REQUEST_SYNC, are written before anything queued so the
+// server sees them first.
+func (c *Client) attach(conn Transport) error {
+	c.mu.RLock()
+	gameID, token := c.gameID, c.resumeToken
+	resumes := slices.Contains(c.serverCaps, protocol.CapResume)
+	c.mu.RUnlock()
+
+	handshake := []protocol.ClientPayload{protocol.Register{
+		UUID:            c.uuid,
+		Nickname:        c.nickname,
+		ProtocolVersion: protocol.Version,
+		Capabilities:    protocol.Capabilities(),
+	}}
+	if gameID != "" && resumes {
+		handshake = append(handshake, protocol.ResumeGame{GameID: gameID, Token: token}, protocol.RequestSync{GameID: gameID})
+	}
+	for _, p := range handshake {
+		data, err := protocol.EncodeClient(protocol.NewClientMessage(p))
+		if err == nil {
+			err = conn.WriteMessage(websocket.TextMessage, data)
+		}
+		if err != nil {
+			conn.Close()
+			return fmt.Errorf("failed to register: %w", err)
+		}
+	}
+	if len(handshake) > 1 {
+		c.syncRequested()
+	}
+
+	c.publishQuality(c.link.reset())
+
+	stop := make(chan struct{})
 	c.mu.Lock()
 	c.conn = conn
+	c.connStop = stop
 	c.connected = true
 	c.mu.Unlock()
 
 	// Start read/write goroutines
-	go c.readPump()
-	go c.writePump()
+	go c.readPump(conn, stop)
+	go c.writePump(conn, stop)
 
-	// Send REGISTER message
-	return c.Send(ClientMessage{
-		Type:      ClientRegister,
-		Timestamp: time.Now().UnixMilli(),
-		Payload: map[string]interface{}{
-			"uuid":     c.uuid,
-			"nickname": c.nickname,
-		},
-	})
+	c.setState(StateChange{State: StateConnected})
+	return nil
 }
 
-// Send sends a message to the server
+// Send validates and sends a message to the server
 func (c *Client) Send(msg ClientMessage) error {
+	_, err := c.send(msg)
+	return err
+}
+
+// SendPayload sends a payload stamped with its type and the current time
+func (c *Client) SendPayload(p protocol.ClientPayload) error {
+	return c.Send(protocol.NewClientMessage(p))
+}
+
+// Request sends a payload and returns its request ID. Game actions are
+// tracked until the server replies; the outcome arrives on Results.
+func (c *Client) Request(p protocol.ClientPayload) (string, error) {
+	return c.send(protocol.NewClientMessage(p))
+}
+
+func (c *Client) send(msg ClientMessage) (string, error) {
+	if msg.Payload == nil {
+		return "", fmt.Errorf("%w: %s has no payload", protocol.ErrMalformed, msg.Type)
+	}
+	if err := msg.Payload.Validate(); err != nil {
+		return "", err
+	}
+	if msg.RequestID == "" {
+		msg.RequestID = protocol.NewRequestID()
+	}
+
 	c.mu.RLock()
-	defer c.mu.RUnlock()
+	connected := c.connected
+	c.mu.RUnlock()
+	if !connected {
+		return "", fmt.Errorf("not connected")
+	}
+
+	switch msg.Type {
+	case protocol.ClientRequestSync:
+		c.syncRequested()
+	case protocol.ClientLeaveGame, protocol.ClientLeaveSpectate:
+		// Nothing to resume or resync once we are gone
+		c.mu.Lock()
+		c.gameID, c.syncing = "", false
+		c.mu.Unlock()
+	}
+	if protocol.IsHostCommand(msg.Type) && msg.HostToken == "" {
+		c.mu.RLock()
+		msg.HostToken = c.hostToken
+		c.mu.RUnlock()
+	}
 
-	if !c.connected {
-		return fmt.Errorf("not connected")
+	// Track before queueing so a fast reply finds its request
+	tracked := protocol.IsGameAction(msg.Type)
+	if tracked {
+		c.track(msg)
 	}
 
 	select {
 	case c.outbound <- msg:
-		return nil
+		return msg.RequestID, nil
 	case <-time.After(5 * time.Second):
-		return fmt.Errorf("send timeout")
+		if tracked {
+			c.untrack(msg.RequestID)
+		}
+		return "", fmt.Errorf("send timeout")
 	}
 }
 
@@ -173,26 +370,88 @@ func (c *Client) Receive() <-chan ServerMessage {
 	return c.inbound
 }
 
-// Close closes the WebSocket connection
+// Errors returns server messages that failed to decode or validate
+func (c *Client) Errors() <-chan error {
+	return c.errors
+}
+
+// States returns connection state changes. Changes are dropped when nobody
+// is listening; State always has the latest.
+func (c *Client) States() <-chan StateChange {
+	return c.states
+}
+
+// State returns the current connection state
+func (c *Client) State() ConnState {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.state
+}
+
+// GameID returns the game the client resumes after reconnecting
+func (c *Client) GameID() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.gameID
+}
+
+func (c *Client) setState(change StateChange) {
+	c.mu.Lock()
+	c.state = change.State
+	c.mu.Unlock()
+
+	select {
+	case c.states <- change:
+	default:
+	}
+}
+
+// Close closes the WebSocket connection and stops reconnecting
 func (c *Client) Close() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if !c.connected {
+	if c.closed {
 		return nil
 	}
-
+	c.closed = true
 	c.connected = false
 	close(c.done)
+	for id, req := range c.pending {
+		req.timer.Stop()
+		delete(c.pending, id)
+	}
+	if c.syncTimer != nil {
+		c.syncTimer.Stop()
+	}
 
 	if c.conn != nil {
-		err := c.conn.Close()
-		return err
+		return c.conn.Close()
 	}
 
 	return nil
 }
 
+// Disconnect closes the current connection without reconnecting, so the
+// client can be pointed at another server. The game is not resumed.
+func (c *Client) Disconnect() {
+	c.mu.Lock()
+	conn, stop := c.conn, c.connStop
+	if !c.connected || conn == nil {
+		c.mu.Unlock()
+		return
+	}
+	c.conn = nil
+	c.connected = false
+	c.gameID, c.hostToken = "", ""
+	close(stop)
+	c.mu.Unlock()
+
+	conn.Close()
+	c.failPending(ErrConnectionLost)
+	c.setState(StateChange{State: StateIdle})
+}
+
 // IsConnected returns connection status
 func (c *Client) IsConnected() bool {
 	c.mu.RLock()
@@ -202,66 +461,212 @@ func (c *Client) IsConnected() bool {
 
 // JoinRandomMatch sends a request to join random match
 func (c *Client) JoinRandomMatch() error {
-	return c.Send(ClientMessage{
-		Type:      ClientJoinRandom,
-		Timestamp: time.Now().UnixMilli(),
-		Payload:   map[string]interface{}{},
-	})
+	return c.SendPayload(protocol.JoinRandomMatch{})
 }
 
-// readPump reads messages from the WebSocket
-func (c *Client) readPump() {
-	defer func() {
-		c.mu.Lock()
-		c.connected = false
-		c.mu.Unlock()
-	}()
+// CreateRoom asks the server for a private table with the given blinds
+func (c *Client) CreateRoom(smallBlind, bigBlind, seats int) error {
+	return c.SendPayload(protocol.CreateRoom{SmallBlind: smallBlind, BigBlind: bigBlind, Seats: seats})
+}
+
+// JoinCodeMatch joins the private table with the given invite code
+func (c *Client) JoinCodeMatch(code string) error {
+	return c.SendPayload(protocol.JoinCodeMatch{Code: protocol.NormalizeRoomCode(code)})
+}
+
+// readPump reads messages from the WebSocket until it fails, then hands
+// over to the reconnect loop
+func (c *Client) readPump(conn Transport, stop chan struct{}) {
+	var readErr error
+	defer func() { c.dropped(conn, stop, readErr) }()
 
 	for {
-		_, message, err := c.conn.ReadMessage()
+		_, message, err := conn.ReadMessage()
 		if err != nil {
+			readErr = err
 			return
 		}
+		received := time.Now()
 
-		var serverMsg ServerMessage
-		if err := json.Unmarshal(message, &serverMsg); err != nil {
+		serverMsg, err := protocol.DecodeServer(message)
+		if err != nil {
+			// Surface protocol drift instead of silently dropping it
+			select {
+			case c.errors <- err:
+			default:
+			}
 			continue
 		}
+		if echo, ok := serverMsg.Payload.(protocol.HeartbeatEcho); ok {
+			if q, changed := c.link.echoed(echo, serverMsg.Timestamp, received); changed {
+				c.publishQuality(q)
+			}
+			continue
+		}
+		if c.resolve(serverMsg) {
+			continue
+		}
+		c.trackGame(serverMsg)
+		c.heard(serverMsg)
+		upgrade := c.negotiate(serverMsg)
 
 		select {
 		case c.inbound <- serverMsg:
 		case <-c.done:
 			return
 		}
+
+		if upgrade != nil {
+			c.outdated(upgrade)
+			return
+		}
+	}
+}
+
+// trackGame remembers the current game, the token to resume it with and
+// the token our host commands carry
+func (c *Client) trackGame(msg ServerMessage) {
+	var gameID string
+	switch p := msg.Payload.(type) {
+	case protocol.RegisterSuccess:
+		c.mu.Lock()
+		c.resumeToken = p.ResumeToken
+		c.mu.Unlock()
+		return
+	case protocol.RoomCreated:
+		c.mu.Lock()
+		c.hostToken = p.HostToken
+		c.mu.Unlock()
+		return
+	case protocol.HostGranted:
+		c.mu.Lock()
+		c.hostToken = p.HostToken
+		c.mu.Unlock()
+		return
+	case protocol.MatchingCompleted:
+		gameID = p.GameID
+	case protocol.GameStarted:
+		gameID = p.GameID
+	case protocol.GameStateUpdate:
+		gameID = p.GameID
+	case protocol.GameEnded:
+		c.mu.Lock()
+		if c.gameID == p.GameID {
+			c.gameID = ""
+		}
+		c.mu.Unlock()
+		return
+	default:
+		return
+	}
+
+	c.mu.Lock()
+	c.gameID = gameID
+	c.mu.Unlock()
+}
+
+// dropped tears down a failed connection and starts reconnecting unless
+// the client was closed on purpose
+func (c *Client) dropped(conn Transport, stop chan struct{}, cause error) {
+	c.mu.Lock()
+	if c.conn != conn {
+		c.mu.Unlock()
+		return
+	}
+	c.connected = false
+	close(stop)
+	conn.Close()
+	start := !c.closed && !c.reconnecting
+	if start {
+		c.reconnecting = true
+	}
+	c.mu.Unlock()
+
+	// Queued actions are dropped with their requests rather than replayed
+	// against a game state the player has not seen
+	c.failPending(ErrConnectionLost)
+
+	if start {
+		go c.reconnect(cause)
+	}
+}
+
+// reconnect retries with jittered exponential backoff until it succeeds,
+// runs out of attempts or the client is closed
+func (c *Client) reconnect(cause error) {
+	defer func() {
+		c.mu.Lock()
+		c.reconnecting = false
+		c.mu.Unlock()
+	}()
+
+	c.mu.RLock()
+	backoff, timeout := c.backoff, c.dialTimeout
+	c.mu.RUnlock()
+
+	for attempt := 1; ; attempt++ {
+		delay := backoff.Delay(attempt, c.rng)
+		c.setState(StateChange{State: StateReconnecting, Attempt: attempt, NextRetry: delay, Err: cause})
+
+		select {
+		case <-time.After(delay):
+		case <-c.done:
+			return
+		}
+
+		conn, err := c.dial(timeout)
+		if err == nil {
+			if err = c.attach(conn); err == nil {
+				return
+			}
+		}
+		cause = err
+
+		if backoff.Exhausted(attempt) {
+			c.setState(StateChange{State: StateFailed, Attempt: attempt, Err: cause})
+			return
+		}
 	}
 }
 
 // writePump writes messages to the WebSocket
-func (c *Client) writePump() {
-	ticker := time.NewTicker(30 * time.Second)
+func (c *Client) writePump(conn Transport, stop chan struct{}) {
+	c.mu.RLock()
+	interval, probeEvery := c.heartbeat, c.probeEvery
+	c.mu.RUnlock()
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case msg := <-c.outbound:
-			data, err := json.Marshal(msg)
+			if protocol.IsGameAction(msg.Type) && !c.isPending(msg.RequestID) {
+				continue
+			}
+			data, err := protocol.EncodeClient(msg)
 			if err != nil {
 				continue
 			}
-			if err := c.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
 				return
 			}
 
 		case <-ticker.C:
-			// Send heartbeat
-			heartbeat := ClientMessage{
-				Type:      ClientHeartbeat,
-				Timestamp: time.Now().UnixMilli(),
+			if err := c.sendHeartbeat(conn); err != nil {
+				return
 			}
-			data, _ := json.Marshal(heartbeat)
-			if err := c.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+
+		case <-c.probe:
+			if err := c.sendHeartbeat(conn); err != nil {
 				return
 			}
+			// Only servers that echo heartbeats are probed
+			if probeEvery > 0 && probeEvery < interval {
+				ticker.Reset(probeEvery)
+			}
+
+		case <-stop:
+			return
 
 		case <-c.done:
 			return
@@ -269,41 +674,50 @@ func (c *Client) writePump() {
 	}
 }
 
-// SendGameAction sends a game action (FOLD, CHECK, CALL, RAISE, ALL_IN)
-func (c *Client) SendGameAction(action ClientMessageType, amount int) error {
-	payload := map[string]interface{}{}
-	if action == ClientRaise && amount > 0 {
-		payload["amount"] = amount
+// sendHeartbeat sends HEARTBEAT and starts timing its echo
+func (c *Client) sendHeartbeat(conn Transport) error {
+	seq, q, changed := c.link.sent(time.Now(), c.Supports(protocol.CapHeartbeatEcho))
+	if changed {
+		c.publishQuality(q)
 	}
+	data, err := protocol.EncodeClient(protocol.NewClientMessage(protocol.Heartbeat{Seq: seq}))
+	if err != nil {
+		return err
+	}
+	return conn.WriteMessage(websocket.TextMessage, data)
+}
 
-	return c.Send(ClientMessage{
-		Type:      action,
-		Timestamp: time.Now().UnixMilli(),
-		Payload:   payload,
-	})
+// SendGameAction sends a game action (FOLD, CHECK, CALL, RAISE, ALL_IN)
+// and returns the request ID its Result will carry
+func (c *Client) SendGameAction(action protocol.ClientMessageType, amount int) (string, error) {
+	payload, err := protocol.NewGameAction(action, amount)
+	if err != nil {
+		return "", err
+	}
+	return c.Request(payload)
 }
 
 // Fold sends FOLD action
-func (c *Client) Fold() error {
-	return c.SendGameAction(ClientFold, 0)
+func (c *Client) Fold() (string, error) {
+	return c.SendGameAction(protocol.ClientFold, 0)
 }
 
 // Check sends CHECK action
-func (c *Client) Check() error {
-	return c.SendGameAction(ClientCheck, 0)
+func (c *Client) Check() (string, error) {
+	return c.SendGameAction(protocol.ClientCheck, 0)
 }
 
 // Call sends CALL action
-func (c *Client) Call() error {
-	return c.SendGameAction(ClientCall, 0)
+func (c *Client) Call() (string, error) {
+	return c.SendGameAction(protocol.ClientCall, 0)
 }
 
 // Raise sends RAISE action with amount
-func (c *Client) Raise(amount int) error {
-	return c.SendGameAction(ClientRaise, amount)
+func (c *Client) Raise(amount int) (string, error) {
+	return c.SendGameAction(protocol.ClientRaise, amount)
 }
 
 // AllIn sends ALL_IN action
-func (c *Client) AllIn() error {
-	return c.SendGameAction(ClientAllIn, 0)
+func (c *Client) AllIn() (string, error) {
+	return c.SendGameAction(protocol.ClientAllIn, 0)
 }
@@ -1,39 +1,36 @@
 package network
 
 import (
+	"errors"
+	"math/rand"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/gorilla/websocket"
+
+	"github.com/bunnyholes/pokerhole/client/internal/localserver"
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
 )
 
+// startLocalServer serves the game protocol from an in-process server
+func startLocalServer(t *testing.T) string {
+	t.Helper()
+	srv := localserver.New(localserver.Options{Logf: t.Logf})
+	server := httptest.NewServer(srv)
+	t.Cleanup(func() {
+		srv.Close()
+		server.Close()
+	})
+	return "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/game"
+}
+
 // TestConnectWithTimeout_Success tests successful connection within timeout
 func TestConnectWithTimeout_Success(t *testing.T) {
-	// Create test WebSocket server
-	upgrader := websocket.Upgrader{}
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		conn, err := upgrader.Upgrade(w, r, nil)
-		if err != nil {
-			t.Fatalf("Failed to upgrade: %v", err)
-		}
-		defer conn.Close()
-
-		// Read REGISTER message
-		_, _, err = conn.ReadMessage()
-		if err != nil {
-			t.Logf("Read error (expected during test cleanup): %v", err)
-		}
-	}))
-	defer server.Close()
-
-	// Convert http:// to ws://
-	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
-
-	// Create client
-	client := NewClient(wsURL, "test-uuid", "test-nickname")
+	client := NewClient(startLocalServer(t), "test-uuid", "test-nickname")
 
 	// Connect with 3 second timeout
 	err := client.ConnectWithTimeout(3 * time.Second)
@@ -100,3 +97,234 @@ func TestConnectWithTimeout_InvalidURL(t *testing.T) {
 		t.Error("Client should not be connected")
 	}
 }
+
+// TestClient_TypedMessages checks REGISTER is sent typed and bad server messages surface as errors
+func TestClient_TypedMessages(t *testing.T) {
+	registered := make(chan protocol.ClientMessage, 1)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if msg, err := protocol.DecodeClient(data); err == nil {
+			registered <- msg
+		}
+
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"GAME_STATE_UPDATE","timestamp":1,"payload":{"pot":-1}}`))
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"MATCHING_COMPLETED","timestamp":2,"payload":{"gameId":"game-1"}}`))
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	client := NewClient("ws"+strings.TrimPrefix(server.URL, "http"), "test-uuid", "test-nickname")
+	if err := client.ConnectWithTimeout(time.Second); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer client.Close()
+
+	select {
+	case msg := <-registered:
+		if reg, ok := msg.Payload.(protocol.Register); !ok || reg.UUID != "test-uuid" {
+			t.Errorf("Unexpected REGISTER payload: %+v", msg.Payload)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("REGISTER not received")
+	}
+
+	select {
+	case err := <-client.Errors():
+		if !errors.Is(err, protocol.ErrInvalidPayload) {
+			t.Errorf("Expected validation error, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Validation error not surfaced")
+	}
+
+	select {
+	case msg := <-client.Receive():
+		if done, ok := msg.Payload.(protocol.MatchingCompleted); !ok || done.GameID != "game-1" {
+			t.Errorf("Unexpected message: %+v", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Valid message not delivered")
+	}
+
+	if _, err := client.Raise(0); !errors.Is(err, protocol.ErrInvalidPayload) {
+		t.Errorf("Expected invalid raise to be rejected locally, got %v", err)
+	}
+}
+
+// TestClient_ReconnectResumesGame drops the first connection mid-game and
+// checks the client re-registers with the same UUID and resumes the game
+func TestClient_ReconnectResumesGame(t *testing.T) {
+	t.Run("resume", func(t *testing.T) {
+		client, msgs := reconnectAfterDrop(t, []string{protocol.CapResume})
+		if len(msgs) != 3 {
+			t.Fatalf("expected REGISTER, RESUME_GAME and REQUEST_SYNC, got %+v", msgs)
+		}
+		if reg, ok := msgs[0].Payload.(protocol.Register); !ok || reg.UUID != "test-uuid" {
+			t.Errorf("expected REGISTER with same UUID first, got %+v", msgs[0])
+		}
+		if resume, ok := msgs[1].Payload.(protocol.ResumeGame); !ok || resume.GameID != "game-1" || resume.Token != "token-1" {
+			t.Errorf("expected RESUME_GAME game-1 with the first connection's token, got %+v", msgs[1])
+		}
+		if msgs[2].Type != protocol.ClientRequestSync {
+			t.Errorf("expected REQUEST_SYNC, got %s", msgs[2].Type)
+		}
+
+		seen := map[ConnState]bool{}
+		deadline := time.After(time.Second)
+		for !seen[StateReconnecting] || client.State() != StateConnected {
+			select {
+			case change := <-client.States():
+				seen[change.State] = true
+			case <-deadline:
+				t.Fatalf("expected RECONNECTING then CONNECTED, saw %v (now %s)", seen, client.State())
+			}
+		}
+	})
+
+	t.Run("no capabilities", func(t *testing.T) {
+		_, msgs := reconnectAfterDrop(t, nil)
+		if len(msgs) != 1 || msgs[0].Type != protocol.ClientRegister {
+			t.Errorf("a server without %q should only see REGISTER, got %+v", protocol.CapResume, msgs)
+		}
+	})
+}
+
+// reconnectAfterDrop seats the client in game-1 on a server declaring caps,
+// drops the connection and returns what the client sends on the next one
+func reconnectAfterDrop(t *testing.T, caps []string) (*Client, []protocol.ClientMessage) {
+	t.Helper()
+	resumed := make(chan []protocol.ClientMessage, 1)
+	var connections int32
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		if atomic.AddInt32(&connections, 1) == 1 {
+			conn.ReadMessage() // REGISTER
+			registered, _ := protocol.EncodeServer(protocol.NewServerMessage(protocol.RegisterSuccess{
+				UUID: "test-uuid", Nickname: "test-nickname", ProtocolVersion: protocol.Version, Capabilities: caps, ResumeToken: "token-1",
+			}))
+			conn.WriteMessage(websocket.TextMessage, registered)
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"MATCHING_COMPLETED","timestamp":1,"payload":{"gameId":"game-1"}}`))
+			time.Sleep(50 * time.Millisecond)
+			return // drop the connection
+		}
+
+		// Everything the client sends before it goes quiet
+		var msgs []protocol.ClientMessage
+		for {
+			conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				break
+			}
+			msg, err := protocol.DecodeClient(data)
+			if err != nil {
+				t.Errorf("DecodeClient failed: %v", err)
+				break
+			}
+			if msg.Type != protocol.ClientHeartbeat {
+				msgs = append(msgs, msg)
+			}
+		}
+		resumed <- msgs
+		conn.SetReadDeadline(time.Time{})
+		conn.ReadMessage()
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient("ws"+strings.TrimPrefix(server.URL, "http"), "test-uuid", "test-nickname")
+	client.SetBackoff(Backoff{Initial: 10 * time.Millisecond, Max: 50 * time.Millisecond, Multiplier: 2, Jitter: 0.5})
+	if err := client.ConnectWithTimeout(time.Second); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	select {
+	case msgs := <-resumed:
+		return client, msgs
+	case <-time.After(2 * time.Second):
+		t.Fatal("client did not reconnect")
+		return nil, nil
+	}
+}
+
+func TestBackoff_DelayGrowsWithJitter(t *testing.T) {
+	b := Backoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2, Jitter: 0.5, MaxAttempts: 3}
+	rng := rand.New(rand.NewSource(1))
+
+	for attempt, base := range map[int]time.Duration{1: 100 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second} {
+		d := b.Delay(attempt, rng)
+		if d > base || d < base/2 {
+			t.Errorf("attempt %d: delay %v outside [%v, %v]", attempt, d, base/2, base)
+		}
+	}
+
+	if b.Exhausted(2) || !b.Exhausted(3) {
+		t.Error("expected backoff to be exhausted after 3 attempts")
+	}
+}
+
+// TestClient_SetServerURL tests switching servers only while disconnected
+func TestClient_SetServerURL(t *testing.T) {
+	client := NewClient("ws://127.0.0.1:1/ws/game", "test-uuid", "test-nickname")
+	defer client.Close()
+
+	wsURL := startLocalServer(t)
+	if err := client.SetServerURL(wsURL); err != nil {
+		t.Fatalf("SetServerURL while idle: %v", err)
+	}
+	if err := client.ConnectWithTimeout(3 * time.Second); err != nil {
+		t.Fatalf("connect to new server: %v", err)
+	}
+	if client.State() != StateConnected {
+		t.Fatalf("expected connected state, got %v", client.State())
+	}
+	if err := client.SetServerURL("ws://elsewhere/ws/game"); !errors.Is(err, ErrAlreadyConnected) {
+		t.Fatalf("expected ErrAlreadyConnected, got %v", err)
+	}
+
+	client.Close()
+	if err := client.ConnectWithTimeout(time.Second); !errors.Is(err, ErrClosed) {
+		t.Fatalf("expected ErrClosed after Close, got %v", err)
+	}
+}
+
+// TestClient_Disconnect tests leaving a server without reconnecting
+func TestClient_Disconnect(t *testing.T) {
+	client := NewClient(startLocalServer(t), "test-uuid", "test-nickname")
+	defer client.Close()
+	if err := client.ConnectWithTimeout(time.Second); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+
+	client.Disconnect()
+	if client.IsConnected() || client.State() != StateIdle {
+		t.Fatalf("expected idle after Disconnect, got %s", client.State())
+	}
+	time.Sleep(50 * time.Millisecond)
+	if client.State() != StateIdle {
+		t.Fatalf("client reconnected on its own: %s", client.State())
+	}
+	if err := client.SetServerURL(startLocalServer(t)); err != nil {
+		t.Fatalf("SetServerURL after Disconnect: %v", err)
+	}
+	if err := client.ConnectWithTimeout(time.Second); err != nil {
+		t.Fatalf("connect to second server: %v", err)
+	}
+}
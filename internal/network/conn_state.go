@@ -0,0 +1,38 @@
+package network
+
+import "time"
+
+// ConnState is the lifecycle state of the server connection
+type ConnState int
+
+const (
+	StateIdle ConnState = iota
+	StateConnecting
+	StateConnected
+	StateReconnecting
+	StateFailed
+)
+
+var connStateNames = [...]string{
+	"IDLE",
+	"CONNECTING",
+	"CONNECTED",
+	"RECONNECTING",
+	"FAILED",
+}
+
+// String returns the string representation
+func (s ConnState) String() string {
+	if s < StateIdle || s > StateFailed {
+		return "UNKNOWN"
+	}
+	return connStateNames[s]
+}
+
+// StateChange describes a transition of the connection state
+type StateChange struct {
+	State     ConnState
+	Attempt   int           // reconnect attempt, 0 otherwise
+	NextRetry time.Duration // wait before the attempt when reconnecting
+	Err       error         // cause of a drop or failure
+}
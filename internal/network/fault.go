@@ -0,0 +1,289 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+var (
+	// ErrInjectedDisconnect is what a FaultDialer's connections fail with
+	// when they are cut
+	ErrInjectedDisconnect = errors.New("injected disconnect")
+	// ErrInjectedRefusal is returned by refused FaultDialer dials
+	ErrInjectedRefusal = errors.New("injected connection refusal")
+)
+
+// Faults is what happens to frames travelling one way. Chances are from
+// 0 (never) to 1 (every frame).
+type Faults struct {
+	Delay     time.Duration // added before each frame is delivered
+	Loss      float64       // chance a frame is dropped
+	Duplicate float64       // chance a frame is delivered twice
+	Reorder   float64       // chance a frame is held back behind the next one
+}
+
+// FaultDialer wraps another dialer and injects faults into the connections
+// it opens. Faults are drawn from a seeded source per direction, so a run
+// with the same seed and traffic drops the same frames. Settings may be
+// changed while connections are open.
+type FaultDialer struct {
+	dialer Dialer
+
+	mu        sync.Mutex
+	in, out   Faults
+	inRand    *rand.Rand
+	outRand   *rand.Rand
+	dropAfter int // frames received before each connection is cut; 0 never
+	refuse    int // dials left to refuse
+	dials     int
+	conns     []*faultConn
+}
+
+// NewFaultDialer injects faults into connections opened by d
+func NewFaultDialer(d Dialer, seed int64) *FaultDialer {
+	return &FaultDialer{
+		dialer:  d,
+		inRand:  rand.New(rand.NewSource(seed)),
+		outRand: rand.New(rand.NewSource(seed + 1)),
+	}
+}
+
+// SetFaults changes the faults for frames received (in) and sent (out)
+func (d *FaultDialer) SetFaults(in, out Faults) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.in, d.out = in, out
+}
+
+// DropAfter cuts every connection once it has received n frames; 0 never
+func (d *FaultDialer) DropAfter(n int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.dropAfter = n
+}
+
+// Refuse makes the next n dials fail
+func (d *FaultDialer) Refuse(n int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.refuse = n
+}
+
+// Dials returns how many connections have been attempted
+func (d *FaultDialer) Dials() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.dials
+}
+
+// Disconnect cuts every open connection
+func (d *FaultDialer) Disconnect() {
+	for _, c := range d.open() {
+		c.cut()
+	}
+}
+
+// Stall leaves every open connection half-open: nothing more is received
+// and whatever is sent is lost, but neither side sees an error
+func (d *FaultDialer) Stall() {
+	for _, c := range d.open() {
+		c.stall()
+	}
+}
+
+func (d *FaultDialer) open() []*faultConn {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]*faultConn(nil), d.conns...)
+}
+
+// Dial opens a connection through the wrapped dialer
+func (d *FaultDialer) Dial(ctx context.Context, url string) (Transport, error) {
+	d.mu.Lock()
+	d.dials++
+	if d.refuse > 0 {
+		d.refuse--
+		d.mu.Unlock()
+		return nil, ErrInjectedRefusal
+	}
+	d.mu.Unlock()
+
+	conn, err := d.dialer.Dial(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	fc := &faultConn{Transport: conn, dialer: d, stalled: make(chan struct{}), closed: make(chan struct{})}
+	d.mu.Lock()
+	d.conns = append(d.conns, fc)
+	d.mu.Unlock()
+	return fc, nil
+}
+
+// shape applies one direction's faults to a frame and returns the frames
+// to deliver in its place
+func (d *FaultDialer) shape(inbound bool, held *[]byte, data []byte) ([][]byte, time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	f, rng := d.out, d.outRand
+	if inbound {
+		f, rng = d.in, d.inRand
+	}
+
+	if rng.Float64() < f.Loss {
+		return nil, 0
+	}
+	if *held == nil && rng.Float64() < f.Reorder {
+		*held = data
+		return nil, 0
+	}
+	frames := [][]byte{data}
+	if rng.Float64() < f.Duplicate {
+		frames = append(frames, data)
+	}
+	if *held != nil {
+		frames = append(frames, *held)
+		*held = nil
+	}
+	return frames, f.Delay
+}
+
+func (d *FaultDialer) forget(c *faultConn) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i, open := range d.conns {
+		if open == c {
+			d.conns = append(d.conns[:i], d.conns[i+1:]...)
+			return
+		}
+	}
+}
+
+// faultConn is one connection opened by a FaultDialer
+type faultConn struct {
+	Transport
+	dialer *FaultDialer
+
+	// read side, used only by the reading goroutine
+	queue    [][]byte
+	received int
+	heldIn   []byte
+
+	writeMu sync.Mutex
+	heldOut []byte
+
+	stallOnce, closeOnce sync.Once
+	stalled, closed      chan struct{}
+	wasCut               atomic.Bool
+}
+
+func (c *faultConn) ReadMessage() (int, []byte, error) {
+	for len(c.queue) == 0 {
+		kind, data, err := c.Transport.ReadMessage()
+		if err != nil {
+			if c.isCut() {
+				return 0, nil, ErrInjectedDisconnect
+			}
+			return kind, nil, err
+		}
+
+		c.received++
+		c.dialer.mu.Lock()
+		limit := c.dialer.dropAfter
+		c.dialer.mu.Unlock()
+		if limit > 0 && c.received > limit {
+			c.cut()
+			return 0, nil, ErrInjectedDisconnect
+		}
+
+		frames, delay := c.dialer.shape(true, &c.heldIn, data)
+		if len(frames) > 0 && !c.wait(delay) {
+			return 0, nil, c.closedErr()
+		}
+		c.queue = frames
+	}
+
+	select {
+	case <-c.stalled:
+		<-c.closed
+		return 0, nil, c.closedErr()
+	default:
+	}
+
+	data := c.queue[0]
+	c.queue = c.queue[1:]
+	return websocket.TextMessage, data, nil
+}
+
+func (c *faultConn) WriteMessage(kind int, data []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	select {
+	case <-c.closed:
+		return c.closedErr()
+	case <-c.stalled:
+		return nil
+	default:
+	}
+
+	frames, delay := c.dialer.shape(false, &c.heldOut, data)
+	if len(frames) > 0 && !c.wait(delay) {
+		return c.closedErr()
+	}
+	for _, frame := range frames {
+		if err := c.Transport.WriteMessage(kind, frame); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *faultConn) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	c.dialer.forget(c)
+	return c.Transport.Close()
+}
+
+// wait sleeps for delay and reports false if the connection closed first
+func (c *faultConn) wait(delay time.Duration) bool {
+	if delay <= 0 {
+		return true
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-c.closed:
+		return false
+	}
+}
+
+func (c *faultConn) cut() {
+	c.wasCut.Store(true)
+	c.Close()
+}
+
+func (c *faultConn) isCut() bool {
+	return c.wasCut.Load()
+}
+
+func (c *faultConn) closedErr() error {
+	if c.isCut() {
+		return ErrInjectedDisconnect
+	}
+	return net.ErrClosed
+}
+
+func (c *faultConn) stall() {
+	c.stallOnce.Do(func() { close(c.stalled) })
+}
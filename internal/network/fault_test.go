@@ -0,0 +1,130 @@
+package network
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// faultyClient connects to the reply server through a FaultDialer
+func faultyClient(t *testing.T) (*Client, *FaultDialer) {
+	t.Helper()
+	server := replyServer(t)
+	t.Cleanup(server.Close)
+
+	faults := NewFaultDialer(WebSocketDialer{}, 1)
+	client := NewClient("ws"+strings.TrimPrefix(server.URL, "http"), "test-uuid", "test-nickname")
+	client.SetDialer(faults)
+	client.SetBackoff(Backoff{Initial: 10 * time.Millisecond, Max: 10 * time.Millisecond, Multiplier: 1})
+	client.SetRequestTimeout(100 * time.Millisecond)
+	t.Cleanup(func() { client.Close() })
+	return client, faults
+}
+
+func TestFaultDialer_ReconnectsAfterRefusals(t *testing.T) {
+	client, faults := faultyClient(t)
+	if err := client.ConnectWithTimeout(time.Second); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	<-client.States() // CONNECTING
+	<-client.States() // CONNECTED
+
+	faults.Refuse(2)
+	faults.Disconnect()
+
+	var attempts []int
+	deadline := time.After(2 * time.Second)
+	for client.State() != StateConnected || len(attempts) < 3 {
+		select {
+		case change := <-client.States():
+			if change.State == StateReconnecting {
+				attempts = append(attempts, change.Attempt)
+			}
+		case <-deadline:
+			t.Fatalf("expected three reconnect attempts, saw %v (now %s)", attempts, client.State())
+		}
+	}
+	if len(attempts) != 3 || attempts[2] != 3 || faults.Dials() != 4 {
+		t.Errorf("attempts %v, dials %d", attempts, faults.Dials())
+	}
+}
+
+func TestFaultDialer_ActionReplies(t *testing.T) {
+	client, faults := faultyClient(t)
+	if err := client.ConnectWithTimeout(time.Second); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	// A duplicated ACK settles the action once and is not surfaced
+	faults.SetFaults(Faults{Duplicate: 1}, Faults{Delay: 20 * time.Millisecond})
+	id, _ := client.Call()
+	if res := nextResult(t, client); res.RequestID != id || res.Err != nil {
+		t.Fatalf("expected CALL acknowledged, got %+v", res)
+	}
+	select {
+	case res := <-client.Results():
+		t.Errorf("duplicate ACK settled again: %+v", res)
+	case msg := <-client.Receive():
+		t.Errorf("duplicate ACK surfaced as %s", msg.Type)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Reordered ACKs still settle their own requests
+	faults.SetFaults(Faults{Reorder: 1}, Faults{})
+	first, _ := client.Call()
+	second, _ := client.Call()
+	if a, b := nextResult(t, client), nextResult(t, client); a.RequestID != second || b.RequestID != first {
+		t.Errorf("expected %s then %s, got %s then %s", second, first, a.RequestID, b.RequestID)
+	}
+
+	// A lost ACK times out
+	faults.SetFaults(Faults{Loss: 1}, Faults{})
+	id, _ = client.Call()
+	if res := nextResult(t, client); res.RequestID != id || !errors.Is(res.Err, ErrRequestTimeout) {
+		t.Errorf("expected CALL to time out, got %+v", res)
+	}
+}
+
+func TestFaultDialer_HeartbeatOnStalledConnection(t *testing.T) {
+	client, faults := faultyClient(t)
+	var sent lockedBuffer
+	client.Record(NewRecorder(&sent))
+	client.SetHeartbeatInterval(10 * time.Millisecond)
+	if err := client.ConnectWithTimeout(time.Second); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	// A half-open connection swallows heartbeats without failing
+	faults.Stall()
+	deadline := time.Now().Add(2 * time.Second)
+	for strings.Count(sent.String(), `"HEARTBEAT"`) < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected heartbeats every 10ms:\n%s", sent.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if !client.IsConnected() {
+		t.Error("a stalled connection should not look dropped")
+	}
+}
+
+// lockedBuffer is a bytes.Buffer safe to read while a recorder writes
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
@@ -0,0 +1,176 @@
+package network
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+)
+
+const (
+	// linkSamples is how many round trips the clock offset is chosen from
+	linkSamples = 8
+	// degradedRTT and degradedJitter mark a connection too slow to play on
+	degradedRTT    = 400 * time.Millisecond
+	degradedJitter = 150 * time.Millisecond
+	// degradedMissed is how many unanswered heartbeats mark a connection
+	// as not responding
+	degradedMissed = 2
+	// maxUnanswered is how many unanswered heartbeats are kept for their
+	// echo; older ones are forgotten but still count as missed
+	maxUnanswered = 8
+)
+
+// LinkQuality summarises the heartbeat round trips of the current
+// connection. It is only measured against servers that echo heartbeats.
+type LinkQuality struct {
+	RTT     time.Duration // latest round trip, without the server's own delay
+	Jitter  time.Duration // smoothed change between consecutive round trips
+	Offset  time.Duration // server clock minus client clock
+	Samples int           // round trips measured on this connection
+	Missed  int           // heartbeats sent since the last echo
+}
+
+// Measured reports whether at least one round trip completed
+func (q LinkQuality) Measured() bool {
+	return q.Samples > 0
+}
+
+// Degraded reports whether the connection is slow, unsteady or silent
+func (q LinkQuality) Degraded() bool {
+	return q.Missed >= degradedMissed || q.RTT >= degradedRTT || q.Jitter >= degradedJitter
+}
+
+// LocalTime converts a server clock time in Unix milliseconds, such as a
+// turn deadline, to the client clock
+func (q LinkQuality) LocalTime(serverMillis int64) time.Time {
+	return time.UnixMilli(serverMillis).Add(-q.Offset)
+}
+
+// linkSample is one completed round trip
+type linkSample struct {
+	rtt    time.Duration
+	offset time.Duration
+}
+
+// sentHeartbeat is a heartbeat waiting for its echo
+type sentHeartbeat struct {
+	seq int64
+	at  time.Time
+}
+
+// linkMeter times heartbeats against their echoes
+type linkMeter struct {
+	mu      sync.Mutex
+	seq     int64           // last heartbeat numbered
+	pending []sentHeartbeat // unanswered, oldest first
+	samples []linkSample    // newest last
+	quality LinkQuality
+}
+
+func newLinkMeter() *linkMeter {
+	return &linkMeter{}
+}
+
+// reset forgets the previous connection; a new route has a new latency
+func (l *linkMeter) reset() LinkQuality {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pending = nil
+	l.samples = nil
+	l.quality = LinkQuality{}
+	return l.quality
+}
+
+// sent numbers a heartbeat leaving at the given time when the server is
+// expected to echo it, and returns 0 otherwise. Heartbeats sent since the
+// last echo count as missed.
+func (l *linkMeter) sent(at time.Time, echoed bool) (int64, LinkQuality, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !echoed {
+		return 0, l.quality, false
+	}
+
+	l.seq++
+	l.pending = append(l.pending, sentHeartbeat{seq: l.seq, at: at})
+	if len(l.pending) > maxUnanswered {
+		l.pending = slices.Delete(l.pending, 0, 1)
+	}
+	if l.pending[0].seq != l.seq {
+		l.quality.Missed++
+		return l.seq, l.quality, true
+	}
+	return l.seq, l.quality, false
+}
+
+// echoed completes the round trip of the heartbeat the echo answers.
+// serverSent is the echo's own timestamp and at is when it arrived.
+// Echoes of unknown or already answered heartbeats are ignored.
+func (l *linkMeter) echoed(echo protocol.HeartbeatEcho, serverSent int64, at time.Time) (LinkQuality, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	i := slices.IndexFunc(l.pending, func(h sentHeartbeat) bool { return h.seq == echo.Seq })
+	if i < 0 {
+		return l.quality, false
+	}
+	sentAt := l.pending[i].at
+	// An answer to a heartbeat answers every older one too
+	l.pending = slices.Delete(l.pending, 0, i+1)
+
+	// NTP style: t0 client send, t1 server receive, t2 server send, t3
+	// client receive. The round trip uses the monotonic clock, the offset
+	// the wall clocks of both sides.
+	held := time.Duration(serverSent-echo.ReceivedAt) * time.Millisecond
+	rtt := max(at.Sub(sentAt)-max(held, 0), 0)
+	t0, t1, t2, t3 := echo.SentAt, echo.ReceivedAt, serverSent, at.UnixMilli()
+	offset := time.Duration((t1-t0)+(t2-t3)) * time.Millisecond / 2
+
+	q := &l.quality
+	if q.Samples > 0 {
+		q.Jitter += (absDuration(rtt-q.RTT) - q.Jitter) / 4
+	}
+	q.RTT = rtt
+	q.Samples++
+	q.Missed = len(l.pending)
+
+	l.samples = append(l.samples, linkSample{rtt: rtt, offset: offset})
+	if len(l.samples) > linkSamples {
+		l.samples = l.samples[1:]
+	}
+	// The quickest round trip was the least skewed by queueing
+	q.Offset = slices.MinFunc(l.samples, func(a, b linkSample) int {
+		return cmp.Compare(a.rtt, b.rtt)
+	}).offset
+	return *q, true
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// Qualities returns connection quality updates. Updates are dropped when
+// nobody is listening; Quality always has the latest.
+func (c *Client) Qualities() <-chan LinkQuality {
+	return c.qualities
+}
+
+// Quality returns the latest measurement of the current connection
+func (c *Client) Quality() LinkQuality {
+	c.link.mu.Lock()
+	defer c.link.mu.Unlock()
+	return c.link.quality
+}
+
+func (c *Client) publishQuality(q LinkQuality) {
+	select {
+	case c.qualities <- q:
+	default:
+	}
+}
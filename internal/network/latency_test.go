@@ -0,0 +1,144 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+)
+
+func TestLinkMeter_RoundTrips(t *testing.T) {
+	l := newLinkMeter()
+	// The server clock runs 480ms ahead and holds each echo for 10ms
+	roundTrip := func(sent int64, rtt time.Duration) LinkQuality {
+		t.Helper()
+		seq, _, _ := l.sent(time.UnixMilli(sent), true)
+		oneWay := rtt.Milliseconds() / 2
+		echo := protocol.HeartbeatEcho{Seq: seq, SentAt: sent, ReceivedAt: sent + oneWay + 480}
+		q, ok := l.echoed(echo, echo.ReceivedAt+10, time.UnixMilli(sent+oneWay*2+10))
+		if !ok {
+			t.Fatalf("echo of %d not matched", sent)
+		}
+		return q
+	}
+
+	q := roundTrip(1000, 40*time.Millisecond)
+	if q.RTT != 40*time.Millisecond || q.Offset != 480*time.Millisecond || q.Jitter != 0 || q.Degraded() {
+		t.Fatalf("first round trip = %+v", q)
+	}
+
+	// A queued round trip is slower and skews the offset; the quicker
+	// sample keeps deciding it
+	seq, _, _ := l.sent(time.UnixMilli(2000), true)
+	echo := protocol.HeartbeatEcho{Seq: seq, SentAt: 2000, ReceivedAt: 2000 + 300 + 480}
+	q, _ = l.echoed(echo, echo.ReceivedAt, time.UnixMilli(2000+320))
+	if q.RTT != 320*time.Millisecond || q.Offset != 480*time.Millisecond || q.Jitter != 70*time.Millisecond {
+		t.Fatalf("queued round trip = %+v", q)
+	}
+	if _, ok := l.echoed(echo, echo.ReceivedAt, time.UnixMilli(2400)); ok {
+		t.Error("a duplicated echo should be ignored")
+	}
+
+	// Heartbeats that go unanswered mark the connection as silent
+	for _, ts := range []int64{3000, 4000, 5000} {
+		_, q, _ = l.sent(time.UnixMilli(ts), true)
+	}
+	if q.Missed != 2 || !q.Degraded() {
+		t.Fatalf("expected two missed heartbeats, got %+v", q)
+	}
+	if q = roundTrip(6000, 20*time.Millisecond); q.Missed != 0 {
+		t.Errorf("an echo should clear older heartbeats, got %+v", q)
+	}
+
+	if got := q.LocalTime(10480); !got.Equal(time.UnixMilli(10000)) {
+		t.Errorf("LocalTime = %v", got.UnixMilli())
+	}
+	if q := l.reset(); q.Measured() {
+		t.Errorf("reset kept %+v", q)
+	}
+}
+
+func TestLinkMeter_UnansweredHeartbeats(t *testing.T) {
+	l := newLinkMeter()
+	// Heartbeats sent within the same millisecond are told apart
+	at := time.UnixMilli(1000)
+	first, _, _ := l.sent(at, true)
+	second, _, _ := l.sent(at, true)
+	if first == second {
+		t.Fatalf("both heartbeats numbered %d", first)
+	}
+	q, ok := l.echoed(protocol.HeartbeatEcho{Seq: first, SentAt: 1000, ReceivedAt: 1010}, 1010, at.Add(20*time.Millisecond))
+	if !ok || q.Missed != 1 {
+		t.Fatalf("echo of the first heartbeat = %+v, %v", q, ok)
+	}
+
+	// A silent server keeps counting misses without keeping every heartbeat
+	for i := 0; i < 3*maxUnanswered; i++ {
+		_, q, _ = l.sent(at, true)
+	}
+	if want := 1 + 3*maxUnanswered; q.Missed != want {
+		t.Errorf("expected %d missed heartbeats, got %+v", want, q)
+	}
+	if len(l.pending) != maxUnanswered {
+		t.Errorf("kept %d unanswered heartbeats, want %d", len(l.pending), maxUnanswered)
+	}
+	if _, ok := l.echoed(protocol.HeartbeatEcho{Seq: second, SentAt: 1000, ReceivedAt: 1010}, 1010, at); ok {
+		t.Error("an echo of a forgotten heartbeat should be ignored")
+	}
+	if _, ok := l.echoed(protocol.HeartbeatEcho{Seq: l.seq, SentAt: 1000, ReceivedAt: 1010}, 1010, at); !ok || len(l.pending) != 0 {
+		t.Error("an echo of the latest heartbeat should answer every one kept")
+	}
+}
+
+func TestClient_MeasuresHeartbeatEchoes(t *testing.T) {
+	faults := NewFaultDialer(WebSocketDialer{}, 1)
+	faults.SetFaults(Faults{Delay: 30 * time.Millisecond}, Faults{})
+	client := NewClient(startLocalServer(t), "test-uuid", "test-nickname")
+	client.SetDialer(faults)
+	if err := client.ConnectWithTimeout(time.Second); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer client.Close()
+
+	// Registration triggers a heartbeat, so the first sample comes at once
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case q := <-client.Qualities():
+			if !q.Measured() {
+				continue
+			}
+			if q.RTT < 30*time.Millisecond || q.Missed != 0 {
+				t.Errorf("expected the injected delay in the round trip, got %+v", q)
+			}
+			if client.Quality() != q {
+				t.Errorf("Quality() = %+v, want %+v", client.Quality(), q)
+			}
+			return
+		case <-deadline:
+			t.Fatal("no round trip measured")
+		}
+	}
+}
+
+func TestClient_ProbeInterval(t *testing.T) {
+	samples := func(probe time.Duration) int {
+		t.Helper()
+		client := NewClient(startLocalServer(t), "test-uuid", "test-nickname")
+		client.SetProbeInterval(probe)
+		if err := client.ConnectWithTimeout(time.Second); err != nil {
+			t.Fatalf("Connect failed: %v", err)
+		}
+		defer client.Close()
+		time.Sleep(300 * time.Millisecond)
+		return client.Quality().Samples
+	}
+
+	// Without opting in only the probe after registration measures
+	if n := samples(0); n != 1 {
+		t.Errorf("expected one round trip at the heartbeat interval, got %d", n)
+	}
+	if n := samples(20 * time.Millisecond); n < 3 {
+		t.Errorf("expected round trips every 20ms, got %d", n)
+	}
+}
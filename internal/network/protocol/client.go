@@ -0,0 +1,334 @@
+package protocol
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Register identifies the client after connecting
+type Register struct {
+	UUID            string   `json:"uuid"`
+	Nickname        string   `json:"nickname"`
+	ProtocolVersion int      `json:"protocolVersion,omitempty"` // missing from legacy clients
+	Capabilities    []string `json:"capabilities,omitempty"`
+}
+
+func (Register) ClientType() ClientMessageType { return ClientRegister }
+
+func (p Register) Validate() error {
+	if strings.TrimSpace(p.UUID) == "" {
+		return invalidClient(ClientRegister, "uuid", "required")
+	}
+	if strings.TrimSpace(p.Nickname) == "" {
+		return invalidClient(ClientRegister, "nickname", "required")
+	}
+	if p.ProtocolVersion < 0 {
+		return invalidClient(ClientRegister, "protocolVersion", "must not be negative")
+	}
+	return nil
+}
+
+// Heartbeat keeps the connection alive. Seq numbers the heartbeats sent
+// to servers that echo them, so each echo names the heartbeat it answers.
+type Heartbeat struct {
+	Seq int64 `json:"seq,omitempty"`
+}
+
+func (Heartbeat) ClientType() ClientMessageType { return ClientHeartbeat }
+
+func (p Heartbeat) Validate() error {
+	if p.Seq < 0 {
+		return invalidClient(ClientHeartbeat, "seq", "must not be negative")
+	}
+	return nil
+}
+
+// JoinRandomMatch asks to be matched with random players
+type JoinRandomMatch struct{}
+
+func (JoinRandomMatch) ClientType() ClientMessageType { return ClientJoinRandom }
+func (JoinRandomMatch) Validate() error               { return nil }
+
+// JoinCodeMatch asks to join a private room by its code
+type JoinCodeMatch struct {
+	Code string `json:"code"`
+}
+
+func (JoinCodeMatch) ClientType() ClientMessageType { return ClientJoinCode }
+
+func (p JoinCodeMatch) Validate() error {
+	if strings.TrimSpace(p.Code) == "" {
+		return invalidClient(ClientJoinCode, "code", "required")
+	}
+	if !ValidRoomCode(p.Code) {
+		return invalidClient(ClientJoinCode, "code", fmt.Sprintf("must be %d letters or digits", RoomCodeLength))
+	}
+	return nil
+}
+
+// CreateRoom asks for a private table; the server answers with
+// ROOM_CREATED. The creator becomes the room's host; with ApproveSeats
+// every join waits for the host's HOST_SEAT_DECISION.
+type CreateRoom struct {
+	SmallBlind   int  `json:"smallBlind"`
+	BigBlind     int  `json:"bigBlind"`
+	Seats        int  `json:"seats"`
+	ApproveSeats bool `json:"approveSeats,omitempty"`
+}
+
+func (CreateRoom) ClientType() ClientMessageType { return ClientCreateRoom }
+
+func (p CreateRoom) Validate() error {
+	if p.SmallBlind <= 0 {
+		return invalidClient(ClientCreateRoom, "smallBlind", "must be positive")
+	}
+	if p.BigBlind <= p.SmallBlind {
+		return invalidClient(ClientCreateRoom, "bigBlind", "must exceed smallBlind")
+	}
+	if p.Seats < MinSeats || p.Seats > MaxSeats {
+		return invalidClient(ClientCreateRoom, "seats", fmt.Sprintf("must be between %d and %d", MinSeats, MaxSeats))
+	}
+	return nil
+}
+
+// SpectateGame watches a table by game ID, or a private room by its code,
+// without taking a seat
+type SpectateGame struct {
+	GameID string `json:"gameId,omitempty"`
+	Code   string `json:"code,omitempty"`
+}
+
+func (SpectateGame) ClientType() ClientMessageType { return ClientSpectate }
+
+func (p SpectateGame) Validate() error {
+	switch {
+	case p.GameID == "" && p.Code == "":
+		return invalidClient(ClientSpectate, "gameId", "gameId or code required")
+	case p.GameID != "" && p.Code != "":
+		return invalidClient(ClientSpectate, "code", "only one of gameId and code")
+	case p.Code != "" && !ValidRoomCode(p.Code):
+		return invalidClient(ClientSpectate, "code", fmt.Sprintf("must be %d letters or digits", RoomCodeLength))
+	}
+	return nil
+}
+
+// LeaveSpectate stops watching the current table
+type LeaveSpectate struct{}
+
+func (LeaveSpectate) ClientType() ClientMessageType { return ClientLeaveSpectate }
+func (LeaveSpectate) Validate() error               { return nil }
+
+// ListTables asks for the tables that can be watched
+type ListTables struct{}
+
+func (ListTables) ClientType() ClientMessageType { return ClientListTables }
+func (ListTables) Validate() error               { return nil }
+
+// CancelMatching leaves the matching queue
+type CancelMatching struct{}
+
+func (CancelMatching) ClientType() ClientMessageType { return ClientCancelMatch }
+func (CancelMatching) Validate() error               { return nil }
+
+// Fold gives up the hand
+type Fold struct{}
+
+func (Fold) ClientType() ClientMessageType { return ClientFold }
+func (Fold) Validate() error               { return nil }
+
+// Check passes without betting
+type Check struct{}
+
+func (Check) ClientType() ClientMessageType { return ClientCheck }
+func (Check) Validate() error               { return nil }
+
+// Call matches the current bet
+type Call struct{}
+
+func (Call) ClientType() ClientMessageType { return ClientCall }
+func (Call) Validate() error               { return nil }
+
+// Raise raises the current bet to Amount
+type Raise struct {
+	Amount int `json:"amount"`
+}
+
+func (Raise) ClientType() ClientMessageType { return ClientRaise }
+
+func (p Raise) Validate() error {
+	if p.Amount <= 0 {
+		return invalidClient(ClientRaise, "amount", "must be positive")
+	}
+	return nil
+}
+
+// AllIn bets all remaining chips
+type AllIn struct{}
+
+func (AllIn) ClientType() ClientMessageType { return ClientAllIn }
+func (AllIn) Validate() error               { return nil }
+
+// LeaveGame leaves the current table
+type LeaveGame struct{}
+
+func (LeaveGame) ClientType() ClientMessageType { return ClientLeaveGame }
+func (LeaveGame) Validate() error               { return nil }
+
+// SendChat posts a chat message to the table
+type SendChat struct {
+	Message string `json:"message"`
+}
+
+func (SendChat) ClientType() ClientMessageType { return ClientChatMessage }
+
+func (p SendChat) Validate() error {
+	if strings.TrimSpace(p.Message) == "" {
+		return invalidClient(ClientChatMessage, "message", "required")
+	}
+	return nil
+}
+
+// ResumeGame resubscribes to a game after reconnecting. Token is the
+// resume token issued to the connection that held the seat.
+type ResumeGame struct {
+	GameID string `json:"gameId"`
+	Token  string `json:"resumeToken,omitempty"`
+}
+
+func (ResumeGame) ClientType() ClientMessageType { return ClientResumeGame }
+
+func (p ResumeGame) Validate() error {
+	if p.GameID == "" {
+		return invalidClient(ClientResumeGame, "gameId", "required")
+	}
+	return nil
+}
+
+// RequestSync asks the server for a full GAME_STATE_UPDATE
+type RequestSync struct {
+	GameID string `json:"gameId"`
+}
+
+func (RequestSync) ClientType() ClientMessageType { return ClientRequestSync }
+
+func (p RequestSync) Validate() error {
+	if p.GameID == "" {
+		return invalidClient(ClientRequestSync, "gameId", "required")
+	}
+	return nil
+}
+
+// NewGameAction returns the payload for a betting action
+func NewGameAction(action ClientMessageType, amount int) (ClientPayload, error) {
+	switch action {
+	case ClientFold:
+		return Fold{}, nil
+	case ClientCheck:
+		return Check{}, nil
+	case ClientCall:
+		return Call{}, nil
+	case ClientRaise:
+		return Raise{Amount: amount}, nil
+	case ClientAllIn:
+		return AllIn{}, nil
+	}
+	return nil, invalidClient(action, "type", "not a game action")
+}
+
+// HostPause stops the game: no actions are taken and no hand is dealt
+// until HOST_RESUME
+type HostPause struct{}
+
+func (HostPause) ClientType() ClientMessageType { return ClientHostPause }
+func (HostPause) Validate() error               { return nil }
+
+// HostResume continues a paused game
+type HostResume struct{}
+
+func (HostResume) ClientType() ClientMessageType { return ClientHostResume }
+func (HostResume) Validate() error               { return nil }
+
+// HostKick removes a player from the room; a hand in progress is folded
+type HostKick struct {
+	PlayerID string `json:"playerId"`
+}
+
+func (HostKick) ClientType() ClientMessageType { return ClientHostKick }
+
+func (p HostKick) Validate() error {
+	if p.PlayerID == "" {
+		return invalidClient(ClientHostKick, "playerId", "required")
+	}
+	return nil
+}
+
+// HostSitOut sits a player out from the next hand on, or back in
+type HostSitOut struct {
+	PlayerID string `json:"playerId"`
+	SitOut   bool   `json:"sitOut"`
+}
+
+func (HostSitOut) ClientType() ClientMessageType { return ClientHostSitOut }
+
+func (p HostSitOut) Validate() error {
+	if p.PlayerID == "" {
+		return invalidClient(ClientHostSitOut, "playerId", "required")
+	}
+	return nil
+}
+
+// HostAdjustStack adds chips to a player's stack, or removes them when
+// Amount is negative. Reason is kept in the table's audit trail.
+type HostAdjustStack struct {
+	PlayerID string `json:"playerId"`
+	Amount   int    `json:"amount"`
+	Reason   string `json:"reason"`
+}
+
+func (HostAdjustStack) ClientType() ClientMessageType { return ClientHostAdjustStack }
+
+func (p HostAdjustStack) Validate() error {
+	if p.PlayerID == "" {
+		return invalidClient(ClientHostAdjustStack, "playerId", "required")
+	}
+	if p.Amount == 0 {
+		return invalidClient(ClientHostAdjustStack, "amount", "must not be zero")
+	}
+	if strings.TrimSpace(p.Reason) == "" {
+		return invalidClient(ClientHostAdjustStack, "reason", "required")
+	}
+	return nil
+}
+
+// HostSetBlinds changes the blinds from the next hand on
+type HostSetBlinds struct {
+	SmallBlind int `json:"smallBlind"`
+	BigBlind   int `json:"bigBlind"`
+}
+
+func (HostSetBlinds) ClientType() ClientMessageType { return ClientHostSetBlinds }
+
+func (p HostSetBlinds) Validate() error {
+	if p.SmallBlind <= 0 {
+		return invalidClient(ClientHostSetBlinds, "smallBlind", "must be positive")
+	}
+	if p.BigBlind <= p.SmallBlind {
+		return invalidClient(ClientHostSetBlinds, "bigBlind", "must exceed smallBlind")
+	}
+	return nil
+}
+
+// HostSeatDecision answers a SEAT_REQUESTED
+type HostSeatDecision struct {
+	PlayerID string `json:"playerId"`
+	Approve  bool   `json:"approve"`
+}
+
+func (HostSeatDecision) ClientType() ClientMessageType { return ClientHostSeatDecision }
+
+func (p HostSeatDecision) Validate() error {
+	if p.PlayerID == "" {
+		return invalidClient(ClientHostSeatDecision, "playerId", "required")
+	}
+	return nil
+}
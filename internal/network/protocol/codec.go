@@ -0,0 +1,274 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// clientPayloads maps each client message type to the decoder for its payload
+var clientPayloads = map[ClientMessageType]func(envelope) (ClientPayload, error){
+	ClientRegister:      decodeClient[Register],
+	ClientHeartbeat:     decodeClient[Heartbeat],
+	ClientJoinRandom:    decodeClient[JoinRandomMatch],
+	ClientJoinCode:      decodeClient[JoinCodeMatch],
+	ClientCreateRoom:    decodeClient[CreateRoom],
+	ClientSpectate:      decodeClient[SpectateGame],
+	ClientLeaveSpectate: decodeClient[LeaveSpectate],
+	ClientListTables:    decodeClient[ListTables],
+	ClientCancelMatch:   decodeClient[CancelMatching],
+	ClientCall:          decodeClient[Call],
+	ClientRaise:         decodeClient[Raise],
+	ClientFold:          decodeClient[Fold],
+	ClientCheck:         decodeClient[Check],
+	ClientAllIn:         decodeClient[AllIn],
+	ClientLeaveGame:     decodeClient[LeaveGame],
+	ClientChatMessage:   decodeClient[SendChat],
+	ClientResumeGame:    decodeClient[ResumeGame],
+	ClientRequestSync:   decodeClient[RequestSync],
+
+	ClientHostPause:        decodeClient[HostPause],
+	ClientHostResume:       decodeClient[HostResume],
+	ClientHostKick:         decodeClient[HostKick],
+	ClientHostSitOut:       decodeClient[HostSitOut],
+	ClientHostAdjustStack:  decodeClient[HostAdjustStack],
+	ClientHostSetBlinds:    decodeClient[HostSetBlinds],
+	ClientHostSeatDecision: decodeClient[HostSeatDecision],
+}
+
+// serverPayloads maps each server message type to the decoder for its payload
+var serverPayloads = map[ServerMessageType]func(envelope) (ServerPayload, error){
+	ServerRegisterSuccess:   decodeServer[RegisterSuccess],
+	ServerRegisterFailure:   decodeServer[RegisterFailure],
+	ServerMatchingStarted:   decodeServer[MatchingStarted],
+	ServerMatchingProgress:  decodeServer[MatchingProgress],
+	ServerMatchingCompleted: decodeServer[MatchingCompleted],
+	ServerMatchingCancelled: decodeServer[MatchingCancelled],
+	ServerRoomCreated:       decodeServer[RoomCreated],
+	ServerSpectateStarted:   decodeServer[SpectateStarted],
+	ServerSpectatorsChanged: decodeServer[SpectatorsChanged],
+	ServerTableList:         decodeServer[TableList],
+	ServerGameStarted:       decodeServer[GameStarted],
+	ServerGameStateUpdate:   decodeServer[GameStateUpdate],
+	ServerPlayerAction:      decodeServer[PlayerActed],
+	ServerTurnChanged:       decodeServer[TurnChanged],
+	ServerRoundProgressed:   decodeServer[RoundProgressed],
+	ServerRoundCompleted:    decodeServer[RoundCompleted],
+	ServerGameEnded:         decodeServer[GameEnded],
+	ServerChatMessage:       decodeServer[ChatReceived],
+	ServerError:             decodeServer[Error],
+	ServerInvalidAction:     decodeServer[InvalidAction],
+	ServerAck:               decodeServer[Ack],
+	ServerHeartbeatEcho:     decodeServer[HeartbeatEcho],
+	ServerSeatRequested:     decodeServer[SeatRequested],
+	ServerHostAction:        decodeServer[HostActed],
+	ServerHostGranted:       decodeServer[HostGranted],
+}
+
+// ClientTypes returns every known client message type in sorted order
+func ClientTypes() []ClientMessageType {
+	types := make([]ClientMessageType, 0, len(clientPayloads))
+	for t := range clientPayloads {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// ServerTypes returns every known server message type in sorted order
+func ServerTypes() []ServerMessageType {
+	types := make([]ServerMessageType, 0, len(serverPayloads))
+	for t := range serverPayloads {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// envelope is the wire format shared by all messages
+type envelope struct {
+	Type      string          `json:"type"`
+	RequestID string          `json:"requestId,omitempty"`
+	Timestamp int64           `json:"timestamp"`
+	Seq       int64           `json:"seq,omitempty"`
+	HostToken string          `json:"hostToken,omitempty"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+}
+
+// NewClientMessage wraps a payload with its type, a fresh request ID and
+// the current time
+func NewClientMessage(p ClientPayload) ClientMessage {
+	return ClientMessage{Type: p.ClientType(), RequestID: NewRequestID(), Timestamp: time.Now().UnixMilli(), Payload: p}
+}
+
+// NewRequestID returns a unique request ID
+func NewRequestID() string {
+	return uuid.NewString()
+}
+
+// NewServerMessage wraps a payload with its type and the current time
+func NewServerMessage(p ServerPayload) ServerMessage {
+	return ServerMessage{Type: p.ServerType(), Timestamp: time.Now().UnixMilli(), Payload: p}
+}
+
+// MarshalJSON encodes the message in wire format
+func (m ClientMessage) MarshalJSON() ([]byte, error) {
+	if m.Payload == nil {
+		return nil, fmt.Errorf("%w: %s has no payload", ErrMalformed, m.Type)
+	}
+	return marshalEnvelope(envelope{Type: string(m.Type), RequestID: m.RequestID, Timestamp: m.Timestamp, HostToken: m.HostToken}, m.Payload)
+}
+
+// UnmarshalJSON decodes the payload struct registered for the message type
+func (m *ClientMessage) UnmarshalJSON(data []byte) error {
+	env, err := unmarshalEnvelope(data)
+	if err != nil {
+		return err
+	}
+
+	t := ClientMessageType(env.Type)
+	decode, ok := clientPayloads[t]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownType, env.Type)
+	}
+	p, err := decode(env)
+	if err != nil {
+		return err
+	}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	*m = ClientMessage{Type: t, RequestID: env.RequestID, Timestamp: env.Timestamp, HostToken: env.HostToken, Payload: p}
+	return nil
+}
+
+// MarshalJSON encodes the message in wire format
+func (m ServerMessage) MarshalJSON() ([]byte, error) {
+	if m.Payload == nil {
+		return nil, fmt.Errorf("%w: %s has no payload", ErrMalformed, m.Type)
+	}
+	return marshalEnvelope(envelope{Type: string(m.Type), RequestID: m.RequestID, Timestamp: m.Timestamp, Seq: m.Seq}, m.Payload)
+}
+
+// UnmarshalJSON decodes the payload struct registered for the message type
+func (m *ServerMessage) UnmarshalJSON(data []byte) error {
+	env, err := unmarshalEnvelope(data)
+	if err != nil {
+		return err
+	}
+
+	t := ServerMessageType(env.Type)
+	decode, ok := serverPayloads[t]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownType, env.Type)
+	}
+	p, err := decode(env)
+	if err != nil {
+		return err
+	}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if t == ServerAck && env.RequestID == "" {
+		return invalidServer(t, "requestId", "required")
+	}
+
+	if env.Seq < 0 {
+		return invalidServer(t, "seq", "must not be negative")
+	}
+
+	*m = ServerMessage{Type: t, RequestID: env.RequestID, Timestamp: env.Timestamp, Seq: env.Seq, Payload: p}
+	return nil
+}
+
+// EncodeClient validates and encodes a client message
+func EncodeClient(m ClientMessage) ([]byte, error) {
+	if m.Payload == nil {
+		return nil, fmt.Errorf("%w: %s has no payload", ErrMalformed, m.Type)
+	}
+	if m.Type != m.Payload.ClientType() {
+		return nil, fmt.Errorf("%w: type %s carries %s payload", ErrMalformed, m.Type, m.Payload.ClientType())
+	}
+	if err := m.Payload.Validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
+
+// DecodeClient decodes and validates a client message
+func DecodeClient(data []byte) (ClientMessage, error) {
+	var m ClientMessage
+	err := m.UnmarshalJSON(data)
+	return m, err
+}
+
+// EncodeServer validates and encodes a server message
+func EncodeServer(m ServerMessage) ([]byte, error) {
+	if m.Payload == nil {
+		return nil, fmt.Errorf("%w: %s has no payload", ErrMalformed, m.Type)
+	}
+	if m.Type != m.Payload.ServerType() {
+		return nil, fmt.Errorf("%w: type %s carries %s payload", ErrMalformed, m.Type, m.Payload.ServerType())
+	}
+	if err := m.Payload.Validate(); err != nil {
+		return nil, err
+	}
+	if m.Type == ServerAck && m.RequestID == "" {
+		return nil, invalidServer(m.Type, "requestId", "required")
+	}
+	return json.Marshal(m)
+}
+
+// DecodeServer decodes and validates a server message
+func DecodeServer(data []byte) (ServerMessage, error) {
+	var m ServerMessage
+	err := m.UnmarshalJSON(data)
+	return m, err
+}
+
+func marshalEnvelope(env envelope, payload any) ([]byte, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	env.Payload = raw
+	return json.Marshal(env)
+}
+
+func unmarshalEnvelope(data []byte) (envelope, error) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return env, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if env.Type == "" {
+		return env, fmt.Errorf("%w: missing type", ErrMalformed)
+	}
+	return env, nil
+}
+
+func decodeClient[T ClientPayload](env envelope) (ClientPayload, error) {
+	var p T
+	err := unmarshalPayload(env, &p)
+	return p, err
+}
+
+func decodeServer[T ServerPayload](env envelope) (ServerPayload, error) {
+	var p T
+	err := unmarshalPayload(env, &p)
+	return p, err
+}
+
+func unmarshalPayload(env envelope, target any) error {
+	if len(env.Payload) == 0 || bytes.Equal(env.Payload, []byte("null")) {
+		return nil
+	}
+	if err := json.Unmarshal(env.Payload, target); err != nil {
+		return fmt.Errorf("%w: %s payload: %v", ErrMalformed, env.Type, err)
+	}
+	return nil
+}
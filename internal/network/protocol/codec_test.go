@@ -0,0 +1,110 @@
+package protocol
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestDecodeServer_ByType(t *testing.T) {
+	msg, err := DecodeServer([]byte(`{"type":"TURN_CHANGED","timestamp":1,"payload":{"currentPlayer":"p1","validActions":["CHECK","RAISE"]}}`))
+	if err != nil {
+		t.Fatalf("DecodeServer failed: %v", err)
+	}
+
+	turn, ok := msg.Payload.(TurnChanged)
+	if !ok {
+		t.Fatalf("expected TurnChanged payload, got %T", msg.Payload)
+	}
+	if turn.CurrentPlayer != "p1" || len(turn.ValidActions) != 2 {
+		t.Errorf("unexpected payload: %+v", turn)
+	}
+}
+
+func TestDecodeServer_Errors(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+		want error
+	}{
+		{"not json", `{`, ErrMalformed},
+		{"missing type", `{"payload":{}}`, ErrMalformed},
+		{"unknown type", `{"type":"SURPRISE"}`, ErrUnknownType},
+		{"wrong field type", `{"type":"GAME_STATE_UPDATE","payload":{"gameId":"g","round":"FLOP","pot":"lots"}}`, ErrMalformed},
+		{"negative pot", `{"type":"GAME_STATE_UPDATE","payload":{"gameId":"g","round":"FLOP","pot":-5}}`, ErrInvalidPayload},
+		{"unknown round", `{"type":"ROUND_PROGRESSED","payload":{"round":"FIFTH"}}`, ErrInvalidPayload},
+		{"unknown action", `{"type":"TURN_CHANGED","payload":{"currentPlayer":"p1","validActions":["DANCE"]}}`, ErrInvalidPayload},
+		{"ack without request", `{"type":"ACK","payload":{"action":"FOLD"}}`, ErrInvalidPayload},
+		{"negative seq", `{"type":"GAME_ENDED","seq":-1,"payload":{"gameId":"g"}}`, ErrInvalidPayload},
+		{"awards mismatch", `{"type":"ROUND_COMPLETED","payload":{"pot":100,"winners":[{"playerId":"p1","amount":90}]}}`, ErrInvalidPayload},
+	}
+
+	for _, tc := range cases {
+		if _, err := DecodeServer([]byte(tc.data)); !errors.Is(err, tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+}
+
+func TestServerMessage_Seq(t *testing.T) {
+	msg := NewServerMessage(GameEnded{GameID: "g"})
+	msg.Seq = 42
+	data, err := EncodeServer(msg)
+	if err != nil {
+		t.Fatalf("EncodeServer failed: %v", err)
+	}
+	decoded, err := DecodeServer(data)
+	if err != nil || decoded.Seq != 42 {
+		t.Fatalf("expected seq 42 back, got %d (%v)", decoded.Seq, err)
+	}
+
+	// Messages outside a game leave the field out
+	data, _ = EncodeServer(NewServerMessage(MatchingCancelled{}))
+	if decoded, _ := DecodeServer(data); decoded.Seq != 0 || bytes.Contains(data, []byte(`"seq"`)) {
+		t.Errorf("unnumbered message encoded as %s", data)
+	}
+}
+
+func TestValidationError_NamesField(t *testing.T) {
+	_, err := DecodeServer([]byte(`{"type":"GAME_STATE_UPDATE","payload":{"gameId":"g","round":"FLOP","players":[{"id":"p1","chips":-1}]}}`))
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if verr.Type != "GAME_STATE_UPDATE" || verr.Field != "players[0].chips" {
+		t.Errorf("unexpected validation error: %+v", verr)
+	}
+}
+
+func TestEncodeClient_Validates(t *testing.T) {
+	if _, err := EncodeClient(NewClientMessage(Raise{})); !errors.Is(err, ErrInvalidPayload) {
+		t.Errorf("expected invalid raise to be rejected, got %v", err)
+	}
+	if _, err := EncodeClient(ClientMessage{Type: ClientFold, Payload: Check{}}); !errors.Is(err, ErrMalformed) {
+		t.Errorf("expected mismatched type to be rejected, got %v", err)
+	}
+	if _, err := NewGameAction(ClientLeaveGame, 0); err == nil {
+		t.Error("expected LEAVE_GAME not to be a game action")
+	}
+}
+
+func TestRoomCodes(t *testing.T) {
+	if code := NormalizeRoomCode(" k7p-x2q "); code != "K7PX2Q" || !ValidRoomCode(code) {
+		t.Errorf("expected K7PX2Q to normalize and validate, got %q", code)
+	}
+	for _, code := range []string{"K7PX2", "K7PX2QQ", "K7PX2?", "k7px2q"} {
+		if ValidRoomCode(code) {
+			t.Errorf("expected %q to be rejected", code)
+		}
+	}
+	if _, err := EncodeClient(NewClientMessage(JoinCodeMatch{Code: "ABC"})); !errors.Is(err, ErrInvalidPayload) {
+		t.Errorf("expected short code to be rejected, got %v", err)
+	}
+	if _, err := EncodeClient(NewClientMessage(CreateRoom{SmallBlind: 20, BigBlind: 20, Seats: 6})); !errors.Is(err, ErrInvalidPayload) {
+		t.Errorf("expected big blind equal to small blind to be rejected, got %v", err)
+	}
+	if _, err := EncodeClient(NewClientMessage(CreateRoom{SmallBlind: 10, BigBlind: 20, Seats: 10})); !errors.Is(err, ErrInvalidPayload) {
+		t.Errorf("expected 10 seats to be rejected, got %v", err)
+	}
+}
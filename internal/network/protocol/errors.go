@@ -0,0 +1,38 @@
+package protocol
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrUnknownType is returned for a message type with no registered payload
+	ErrUnknownType = errors.New("unknown message type")
+	// ErrMalformed is returned when a message is not valid JSON for its type
+	ErrMalformed = errors.New("malformed message")
+	// ErrInvalidPayload is wrapped by every ValidationError
+	ErrInvalidPayload = errors.New("invalid payload")
+)
+
+// ValidationError describes a payload field that broke the protocol rules
+type ValidationError struct {
+	Type   string
+	Field  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s.%s: %s", e.Type, e.Field, e.Reason)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidPayload
+}
+
+func invalidClient(t ClientMessageType, field, reason string) error {
+	return &ValidationError{Type: string(t), Field: field, Reason: reason}
+}
+
+func invalidServer(t ServerMessageType, field, reason string) error {
+	return &ValidationError{Type: string(t), Field: field, Reason: reason}
+}
@@ -0,0 +1,179 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/x/exp/golden"
+)
+
+// fixtureTime keeps golden fixtures stable
+const fixtureTime = 1700000000000
+
+// fixtureHostToken is stamped on host commands
+const fixtureHostToken = "3e9a7c1d-52b8-4f06-8d2e-b7a40c6f9e21"
+
+// fixtureRequestID is stamped on requests that expect a reply and on replies
+const fixtureRequestID = "req-7"
+
+var clientSamples = map[ClientMessageType]ClientPayload{
+	ClientRegister:      Register{UUID: "5f0c6f0e-7a51-4c4b-9a53-1f1bb1f0c001", Nickname: "BraveRabbit", ProtocolVersion: 2, Capabilities: []string{CapRoundProgressed, CapChat}},
+	ClientHeartbeat:     Heartbeat{Seq: 3},
+	ClientJoinRandom:    JoinRandomMatch{},
+	ClientJoinCode:      JoinCodeMatch{Code: "K7PX2Q"},
+	ClientCreateRoom:    CreateRoom{SmallBlind: 10, BigBlind: 20, Seats: 6},
+	ClientSpectate:      SpectateGame{Code: "K7PX2Q"},
+	ClientLeaveSpectate: LeaveSpectate{},
+	ClientListTables:    ListTables{},
+	ClientCancelMatch:   CancelMatching{},
+	ClientCall:          Call{},
+	ClientRaise:         Raise{Amount: 120},
+	ClientFold:          Fold{},
+	ClientCheck:         Check{},
+	ClientAllIn:         AllIn{},
+	ClientLeaveGame:     LeaveGame{},
+	ClientChatMessage:   SendChat{Message: "nice hand"},
+	ClientResumeGame:    ResumeGame{GameID: "game-1", Token: "8d3b1c52-0f6e-4d8a-b1e4-2c7f9a0d6e13"},
+	ClientRequestSync:   RequestSync{GameID: "game-1"},
+
+	ClientHostPause:        HostPause{},
+	ClientHostResume:       HostResume{},
+	ClientHostKick:         HostKick{PlayerID: "p2"},
+	ClientHostSitOut:       HostSitOut{PlayerID: "p2", SitOut: true},
+	ClientHostAdjustStack:  HostAdjustStack{PlayerID: "p2", Amount: 500, Reason: "rebuy"},
+	ClientHostSetBlinds:    HostSetBlinds{SmallBlind: 25, BigBlind: 50},
+	ClientHostSeatDecision: HostSeatDecision{PlayerID: "p3", Approve: true},
+}
+
+var sampleState = GameState{
+	GameID:         "game-1",
+	Round:          "FLOP",
+	Pot:            240,
+	CurrentBet:     40,
+	CommunityCards: []string{"♠A", "♥K", "♦7"},
+	Players: []PlayerInfo{
+		{ID: "p1", Nickname: "BraveRabbit", Chips: 880, Bet: 40, Status: "ACTIVE", Position: 0, HoleCards: []string{"♣Q", "♣J"}},
+		{ID: "p2", Nickname: "QuietFox", Chips: 920, Bet: 0, Status: "ACTIVE", Position: 1},
+	},
+	CurrentPlayer: "p2",
+	ValidActions:  []ClientMessageType{ClientFold, ClientCall, ClientRaise, ClientAllIn},
+	HostID:        "p1",
+}
+
+var serverSamples = map[ServerMessageType]ServerPayload{
+	ServerRegisterSuccess:   RegisterSuccess{UUID: "5f0c6f0e-7a51-4c4b-9a53-1f1bb1f0c001", Nickname: "BraveRabbit", ProtocolVersion: 2, MinVersion: 1, Capabilities: []string{CapChat}, ResumeToken: "8d3b1c52-0f6e-4d8a-b1e4-2c7f9a0d6e13"},
+	ServerRegisterFailure:   RegisterFailure{Reason: "client too old", Code: RegisterUpgradeRequired, MinVersion: 3},
+	ServerMatchingStarted:   MatchingStarted{RequiredPlayers: 2},
+	ServerMatchingProgress:  MatchingProgress{CurrentPlayers: 1, RequiredPlayers: 2},
+	ServerMatchingCompleted: MatchingCompleted{GameID: "game-1"},
+	ServerMatchingCancelled: MatchingCancelled{},
+	ServerRoomCreated:       RoomCreated{Code: "K7PX2Q", SmallBlind: 10, BigBlind: 20, Seats: 6, HostToken: fixtureHostToken},
+	ServerSpectateStarted:   SpectateStarted{GameID: "game-1", Spectators: 2},
+	ServerSpectatorsChanged: SpectatorsChanged{GameID: "game-1", Spectators: 3},
+	ServerTableList: TableList{Tables: []TableInfo{
+		{GameID: "game-1", Round: "FLOP", Players: 2, Seats: 6, Spectators: 3, SmallBlind: 10, BigBlind: 20},
+	}},
+	ServerGameStarted:     GameStarted{GameState: sampleState},
+	ServerGameStateUpdate: GameStateUpdate{GameState: sampleState},
+	ServerPlayerAction:    PlayerActed{GameID: "game-1", PlayerID: "p1", Action: ClientRaise, Amount: 40},
+	ServerTurnChanged:     TurnChanged{GameID: "game-1", CurrentPlayer: "p2", ValidActions: []ClientMessageType{ClientFold, ClientCall}, Deadline: fixtureTime + 30000},
+	ServerRoundProgressed: RoundProgressed{GameID: "game-1", Round: "TURN", CommunityCards: []string{"♠A", "♥K", "♦7", "♣2"}},
+	ServerRoundCompleted:  RoundCompleted{GameID: "game-1", Pot: 240, Winners: []Winner{{PlayerID: "p1", Amount: 240, HandRank: "One Pair"}}},
+	ServerGameEnded:       GameEnded{GameID: "game-1", Reason: "player left"},
+	ServerChatMessage:     ChatReceived{PlayerID: "p2", Nickname: "QuietFox", Message: "gl"},
+	ServerError:           Error{Code: "GAME_NOT_FOUND", Message: "game not found"},
+	ServerInvalidAction:   InvalidAction{Action: ClientCheck, Reason: "cannot check facing a bet"},
+	ServerAck:             Ack{Action: ClientRaise},
+	ServerSeatRequested:   SeatRequested{Code: "K7PX2Q", PlayerID: "p3", Nickname: "LateOwl"},
+	ServerHeartbeatEcho:   HeartbeatEcho{Seq: 3, SentAt: fixtureTime - 40, ReceivedAt: fixtureTime - 15},
+	ServerHostGranted:     HostGranted{Code: "K7PX2Q", HostToken: fixtureHostToken},
+	ServerHostAction:      HostActed{GameID: "game-1", Action: ClientHostAdjustStack, PlayerID: "p2", Nickname: "QuietFox", Amount: 500, Chips: 1420, Reason: "rebuy"},
+}
+
+// goldenPath mirrors where golden.RequireEqual keeps the fixture
+func goldenPath(t *testing.T) string {
+	return filepath.Join("testdata", t.Name()+".golden")
+}
+
+func indent(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		t.Fatalf("Indent failed: %v", err)
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
+
+func TestClientMessages_Golden(t *testing.T) {
+	for _, typ := range ClientTypes() {
+		t.Run(string(typ), func(t *testing.T) {
+			sample, ok := clientSamples[typ]
+			if !ok {
+				t.Fatalf("no golden sample for %s", typ)
+			}
+
+			msg := ClientMessage{Type: typ, Timestamp: fixtureTime, Payload: sample}
+			if IsGameAction(typ) {
+				msg.RequestID = fixtureRequestID
+			}
+			if IsHostCommand(typ) {
+				msg.HostToken = fixtureHostToken
+			}
+			data, err := EncodeClient(msg)
+			if err != nil {
+				t.Fatalf("EncodeClient failed: %v", err)
+			}
+			golden.RequireEqual(t, indent(t, data))
+
+			fixture, err := os.ReadFile(goldenPath(t))
+			if err != nil {
+				t.Fatalf("ReadFile failed: %v", err)
+			}
+			decoded, err := DecodeClient(fixture)
+			if err != nil {
+				t.Fatalf("DecodeClient failed: %v", err)
+			}
+			if !reflect.DeepEqual(decoded, msg) {
+				t.Errorf("fixture decodes to %+v, want %+v", decoded, msg)
+			}
+		})
+	}
+}
+
+func TestServerMessages_Golden(t *testing.T) {
+	for _, typ := range ServerTypes() {
+		t.Run(string(typ), func(t *testing.T) {
+			sample, ok := serverSamples[typ]
+			if !ok {
+				t.Fatalf("no golden sample for %s", typ)
+			}
+
+			msg := ServerMessage{Type: typ, Timestamp: fixtureTime, Payload: sample}
+			if IsReply(typ) {
+				msg.RequestID = fixtureRequestID
+			}
+			data, err := EncodeServer(msg)
+			if err != nil {
+				t.Fatalf("EncodeServer failed: %v", err)
+			}
+			golden.RequireEqual(t, indent(t, data))
+
+			fixture, err := os.ReadFile(goldenPath(t))
+			if err != nil {
+				t.Fatalf("ReadFile failed: %v", err)
+			}
+			decoded, err := DecodeServer(fixture)
+			if err != nil {
+				t.Fatalf("DecodeServer failed: %v", err)
+			}
+			if !reflect.DeepEqual(decoded, msg) {
+				t.Errorf("fixture decodes to %+v, want %+v", decoded, msg)
+			}
+		})
+	}
+}
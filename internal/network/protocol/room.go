@@ -0,0 +1,37 @@
+package protocol
+
+import "strings"
+
+const (
+	// RoomCodeLength is the length of a private room invite code
+	RoomCodeLength = 6
+	// MinSeats and MaxSeats bound the size of a private table
+	MinSeats = 2
+	MaxSeats = 9
+)
+
+// NormalizeRoomCode uppercases a typed code and drops spaces and dashes
+func NormalizeRoomCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == ' ' || r == '-':
+			return -1
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		}
+		return r
+	}, strings.TrimSpace(code))
+}
+
+// ValidRoomCode reports whether code is RoomCodeLength uppercase letters or digits
+func ValidRoomCode(code string) bool {
+	if len(code) != RoomCodeLength {
+		return false
+	}
+	for _, r := range code {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
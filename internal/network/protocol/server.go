@@ -0,0 +1,531 @@
+package protocol
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RegisterSuccess confirms registration
+type RegisterSuccess struct {
+	UUID            string   `json:"uuid"`
+	Nickname        string   `json:"nickname"`
+	ProtocolVersion int      `json:"protocolVersion,omitempty"`    // the server's; missing from legacy servers
+	MinVersion      int      `json:"minProtocolVersion,omitempty"` // oldest client version still accepted
+	Capabilities    []string `json:"capabilities,omitempty"`
+	ResumeToken     string   `json:"resumeToken,omitempty"` // proves the seat is ours when resuming on a later connection
+}
+
+func (RegisterSuccess) ServerType() ServerMessageType { return ServerRegisterSuccess }
+
+func (p RegisterSuccess) Validate() error {
+	if p.UUID == "" {
+		return invalidServer(ServerRegisterSuccess, "uuid", "required")
+	}
+	return nil
+}
+
+// RegisterFailure rejects registration
+type RegisterFailure struct {
+	Reason     string `json:"reason"`
+	Code       string `json:"code,omitempty"`               // RegisterUpgradeRequired for outdated clients
+	MinVersion int    `json:"minProtocolVersion,omitempty"` // set with RegisterUpgradeRequired
+}
+
+func (RegisterFailure) ServerType() ServerMessageType { return ServerRegisterFailure }
+
+func (p RegisterFailure) Validate() error {
+	if p.Reason == "" {
+		return invalidServer(ServerRegisterFailure, "reason", "required")
+	}
+	return nil
+}
+
+// MatchingStarted confirms the client entered the matching queue
+type MatchingStarted struct {
+	RequiredPlayers int `json:"requiredPlayers"`
+}
+
+func (MatchingStarted) ServerType() ServerMessageType { return ServerMatchingStarted }
+
+func (p MatchingStarted) Validate() error {
+	if p.RequiredPlayers < 0 {
+		return invalidServer(ServerMatchingStarted, "requiredPlayers", "must not be negative")
+	}
+	return nil
+}
+
+// MatchingProgress reports how many players are waiting
+type MatchingProgress struct {
+	CurrentPlayers  int `json:"currentPlayers"`
+	RequiredPlayers int `json:"requiredPlayers"`
+}
+
+func (MatchingProgress) ServerType() ServerMessageType { return ServerMatchingProgress }
+
+func (p MatchingProgress) Validate() error {
+	if p.CurrentPlayers < 0 {
+		return invalidServer(ServerMatchingProgress, "currentPlayers", "must not be negative")
+	}
+	if p.RequiredPlayers <= 0 {
+		return invalidServer(ServerMatchingProgress, "requiredPlayers", "must be positive")
+	}
+	return nil
+}
+
+// MatchingCompleted announces the game the client was matched into
+type MatchingCompleted struct {
+	GameID string `json:"gameId"`
+}
+
+func (MatchingCompleted) ServerType() ServerMessageType { return ServerMatchingCompleted }
+
+func (p MatchingCompleted) Validate() error {
+	if p.GameID == "" {
+		return invalidServer(ServerMatchingCompleted, "gameId", "required")
+	}
+	return nil
+}
+
+// MatchingCancelled confirms the client left the matching queue
+type MatchingCancelled struct{}
+
+func (MatchingCancelled) ServerType() ServerMessageType { return ServerMatchingCancelled }
+func (MatchingCancelled) Validate() error               { return nil }
+
+// PlayerInfo is one seat in a game state
+type PlayerInfo struct {
+	ID       string `json:"id"`
+	Nickname string `json:"nickname"`
+	Chips    int    `json:"chips"`
+	Bet      int    `json:"bet"`
+	Status   string `json:"status"`
+	Position int    `json:"position"`
+	// HoleCards holds the receiving player's own cards, and everyone's
+	// at showdown; it is empty otherwise
+	HoleCards []string `json:"holeCards,omitempty"`
+}
+
+// GameState is the full table state sent by the server. HostID names the
+// player allowed to use the host tools at a private table.
+type GameState struct {
+	GameID         string              `json:"gameId"`
+	Round          string              `json:"round"`
+	Pot            int                 `json:"pot"`
+	CurrentBet     int                 `json:"currentBet"`
+	CommunityCards []string            `json:"communityCards"`
+	Players        []PlayerInfo        `json:"players"`
+	CurrentPlayer  string              `json:"currentPlayer"`
+	ValidActions   []ClientMessageType `json:"validActions"`
+	HostID         string              `json:"hostId,omitempty"`
+	Paused         bool                `json:"paused,omitempty"`
+	TurnDeadline   int64               `json:"turnDeadline,omitempty"` // server clock, Unix ms; 0 without a turn clock
+	Winners        []Winner            `json:"winners,omitempty"`      // of the hand just finished, until the next is dealt
+}
+
+func (s GameState) validate(t ServerMessageType) error {
+	if s.GameID == "" {
+		return invalidServer(t, "gameId", "required")
+	}
+	if !bettingRounds[s.Round] {
+		return invalidServer(t, "round", fmt.Sprintf("unknown round %q", s.Round))
+	}
+	if s.Pot < 0 {
+		return invalidServer(t, "pot", "must not be negative")
+	}
+	if s.CurrentBet < 0 {
+		return invalidServer(t, "currentBet", "must not be negative")
+	}
+	if len(s.CommunityCards) > 5 {
+		return invalidServer(t, "communityCards", "more than 5 cards")
+	}
+	for i, p := range s.Players {
+		field := fmt.Sprintf("players[%d]", i)
+		switch {
+		case p.ID == "":
+			return invalidServer(t, field+".id", "required")
+		case p.Chips < 0:
+			return invalidServer(t, field+".chips", "must not be negative")
+		case p.Bet < 0:
+			return invalidServer(t, field+".bet", "must not be negative")
+		case len(p.HoleCards) > 2:
+			return invalidServer(t, field+".holeCards", "more than 2 cards")
+		}
+	}
+	return validateActions(t, s.ValidActions)
+}
+
+func validateActions(t ServerMessageType, actions []ClientMessageType) error {
+	for i, a := range actions {
+		if !gameActions[a] {
+			return invalidServer(t, fmt.Sprintf("validActions[%d]", i), fmt.Sprintf("unknown action %q", a))
+		}
+	}
+	return nil
+}
+
+// GameStarted carries the initial state of a new game
+type GameStarted struct {
+	GameState
+}
+
+func (GameStarted) ServerType() ServerMessageType { return ServerGameStarted }
+func (p GameStarted) Validate() error             { return p.validate(ServerGameStarted) }
+
+// GameStateUpdate carries the full state after a change
+type GameStateUpdate struct {
+	GameState
+}
+
+func (GameStateUpdate) ServerType() ServerMessageType { return ServerGameStateUpdate }
+func (p GameStateUpdate) Validate() error             { return p.validate(ServerGameStateUpdate) }
+
+// PlayerActed announces an action taken by a player
+type PlayerActed struct {
+	GameID   string            `json:"gameId"`
+	PlayerID string            `json:"playerId"`
+	Action   ClientMessageType `json:"action"`
+	Amount   int               `json:"amount"`
+}
+
+func (PlayerActed) ServerType() ServerMessageType { return ServerPlayerAction }
+
+func (p PlayerActed) Validate() error {
+	if p.PlayerID == "" {
+		return invalidServer(ServerPlayerAction, "playerId", "required")
+	}
+	if !gameActions[p.Action] {
+		return invalidServer(ServerPlayerAction, "action", fmt.Sprintf("unknown action %q", p.Action))
+	}
+	if p.Amount < 0 {
+		return invalidServer(ServerPlayerAction, "amount", "must not be negative")
+	}
+	return nil
+}
+
+// TurnChanged announces whose turn it is
+type TurnChanged struct {
+	GameID        string              `json:"gameId"`
+	CurrentPlayer string              `json:"currentPlayer"`
+	ValidActions  []ClientMessageType `json:"validActions"`
+	Deadline      int64               `json:"deadline,omitempty"` // server clock, Unix ms; 0 without a turn clock
+}
+
+func (TurnChanged) ServerType() ServerMessageType { return ServerTurnChanged }
+
+func (p TurnChanged) Validate() error {
+	if p.CurrentPlayer == "" {
+		return invalidServer(ServerTurnChanged, "currentPlayer", "required")
+	}
+	return validateActions(ServerTurnChanged, p.ValidActions)
+}
+
+// RoundProgressed announces a new betting round and its board
+type RoundProgressed struct {
+	GameID         string   `json:"gameId"`
+	Round          string   `json:"round"`
+	CommunityCards []string `json:"communityCards"`
+}
+
+func (RoundProgressed) ServerType() ServerMessageType { return ServerRoundProgressed }
+
+func (p RoundProgressed) Validate() error {
+	if !bettingRounds[p.Round] {
+		return invalidServer(ServerRoundProgressed, "round", fmt.Sprintf("unknown round %q", p.Round))
+	}
+	if len(p.CommunityCards) > 5 {
+		return invalidServer(ServerRoundProgressed, "communityCards", "more than 5 cards")
+	}
+	return nil
+}
+
+// Winner is one pot award in a completed round
+type Winner struct {
+	PlayerID string `json:"playerId"`
+	Amount   int    `json:"amount"`
+	HandRank string `json:"handRank,omitempty"`
+}
+
+// RoundCompleted announces the result of a hand
+type RoundCompleted struct {
+	GameID  string   `json:"gameId"`
+	Pot     int      `json:"pot"`
+	Winners []Winner `json:"winners"`
+}
+
+func (RoundCompleted) ServerType() ServerMessageType { return ServerRoundCompleted }
+
+func (p RoundCompleted) Validate() error {
+	if len(p.Winners) == 0 {
+		return invalidServer(ServerRoundCompleted, "winners", "required")
+	}
+	total := 0
+	for i, w := range p.Winners {
+		if w.PlayerID == "" {
+			return invalidServer(ServerRoundCompleted, fmt.Sprintf("winners[%d].playerId", i), "required")
+		}
+		if w.Amount < 0 {
+			return invalidServer(ServerRoundCompleted, fmt.Sprintf("winners[%d].amount", i), "must not be negative")
+		}
+		total += w.Amount
+	}
+	if total != p.Pot {
+		return invalidServer(ServerRoundCompleted, "winners", fmt.Sprintf("awards %d do not add up to pot %d", total, p.Pot))
+	}
+	return nil
+}
+
+// GameEnded announces the end of a game
+type GameEnded struct {
+	GameID string `json:"gameId"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func (GameEnded) ServerType() ServerMessageType { return ServerGameEnded }
+
+func (p GameEnded) Validate() error {
+	if p.GameID == "" {
+		return invalidServer(ServerGameEnded, "gameId", "required")
+	}
+	return nil
+}
+
+// ChatReceived is a chat message relayed by the server
+type ChatReceived struct {
+	PlayerID string `json:"playerId"`
+	Nickname string `json:"nickname"`
+	Message  string `json:"message"`
+}
+
+func (ChatReceived) ServerType() ServerMessageType { return ServerChatMessage }
+
+func (p ChatReceived) Validate() error {
+	if p.Nickname == "" {
+		return invalidServer(ServerChatMessage, "nickname", "required")
+	}
+	if strings.TrimSpace(p.Message) == "" {
+		return invalidServer(ServerChatMessage, "message", "required")
+	}
+	return nil
+}
+
+// RoomCreated returns the invite code for a private table. HostToken must
+// accompany the host's commands; only the room's creator receives it.
+type RoomCreated struct {
+	Code       string `json:"code"`
+	SmallBlind int    `json:"smallBlind"`
+	BigBlind   int    `json:"bigBlind"`
+	Seats      int    `json:"seats"`
+	HostToken  string `json:"hostToken,omitempty"`
+}
+
+func (RoomCreated) ServerType() ServerMessageType { return ServerRoomCreated }
+
+func (p RoomCreated) Validate() error {
+	if !ValidRoomCode(p.Code) {
+		return invalidServer(ServerRoomCreated, "code", fmt.Sprintf("must be %d letters or digits", RoomCodeLength))
+	}
+	if p.Seats < MinSeats || p.Seats > MaxSeats {
+		return invalidServer(ServerRoomCreated, "seats", fmt.Sprintf("must be between %d and %d", MinSeats, MaxSeats))
+	}
+	return nil
+}
+
+// SpectateStarted confirms the client is watching a table; the state
+// follows as GAME_STATE_UPDATE with other players' hole cards withheld
+type SpectateStarted struct {
+	GameID     string `json:"gameId"`
+	Spectators int    `json:"spectators"`
+}
+
+func (SpectateStarted) ServerType() ServerMessageType { return ServerSpectateStarted }
+
+func (p SpectateStarted) Validate() error {
+	if p.GameID == "" {
+		return invalidServer(ServerSpectateStarted, "gameId", "required")
+	}
+	if p.Spectators < 0 {
+		return invalidServer(ServerSpectateStarted, "spectators", "must not be negative")
+	}
+	return nil
+}
+
+// SpectatorsChanged reports how many clients are watching a table
+type SpectatorsChanged struct {
+	GameID     string `json:"gameId"`
+	Spectators int    `json:"spectators"`
+}
+
+func (SpectatorsChanged) ServerType() ServerMessageType { return ServerSpectatorsChanged }
+
+func (p SpectatorsChanged) Validate() error {
+	if p.Spectators < 0 {
+		return invalidServer(ServerSpectatorsChanged, "spectators", "must not be negative")
+	}
+	return nil
+}
+
+// TableInfo summarizes a table that can be watched
+type TableInfo struct {
+	GameID     string `json:"gameId"`
+	Round      string `json:"round,omitempty"`
+	Players    int    `json:"players"`
+	Seats      int    `json:"seats"`
+	Spectators int    `json:"spectators"`
+	SmallBlind int    `json:"smallBlind"`
+	BigBlind   int    `json:"bigBlind"`
+}
+
+// TableList answers LIST_TABLES
+type TableList struct {
+	Tables []TableInfo `json:"tables"`
+}
+
+func (TableList) ServerType() ServerMessageType { return ServerTableList }
+
+func (p TableList) Validate() error {
+	for i, table := range p.Tables {
+		field := fmt.Sprintf("tables[%d]", i)
+		switch {
+		case table.GameID == "":
+			return invalidServer(ServerTableList, field+".gameId", "required")
+		case table.Players < 0 || table.Spectators < 0:
+			return invalidServer(ServerTableList, field, "counts must not be negative")
+		}
+	}
+	return nil
+}
+
+// Error codes the server uses when a room code cannot be joined
+const (
+	ErrorRoomNotFound = "ROOM_NOT_FOUND"
+	ErrorRoomFull     = "ROOM_FULL"
+	ErrorRoomExpired  = "ROOM_EXPIRED"
+	ErrorSeatDenied   = "SEAT_DENIED"  // the host turned the seat request down
+	ErrorKicked       = "KICKED"       // the host removed us before the game started
+	ErrorNotHost      = "NOT_HOST"     // only the room's host may send host commands
+	ErrorHostCommand  = "HOST_COMMAND" // the host command cannot apply right now
+)
+
+// Error reports a server-side failure
+type Error struct {
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+func (Error) ServerType() ServerMessageType { return ServerError }
+
+func (p Error) Validate() error {
+	if p.Message == "" {
+		return invalidServer(ServerError, "message", "required")
+	}
+	return nil
+}
+
+// InvalidAction rejects a game action
+type InvalidAction struct {
+	Action ClientMessageType `json:"action"`
+	Reason string            `json:"reason"`
+}
+
+func (InvalidAction) ServerType() ServerMessageType { return ServerInvalidAction }
+
+func (p InvalidAction) Validate() error {
+	if p.Reason == "" {
+		return invalidServer(ServerInvalidAction, "reason", "required")
+	}
+	return nil
+}
+
+// HeartbeatEcho answers a HEARTBEAT. With the message timestamp, which is
+// when the server sent the echo, it gives the four times needed to measure
+// the round trip and the clock offset.
+type HeartbeatEcho struct {
+	Seq        int64 `json:"seq"`        // the heartbeat's seq
+	SentAt     int64 `json:"sentAt"`     // the heartbeat's timestamp, client clock
+	ReceivedAt int64 `json:"receivedAt"` // when the heartbeat arrived, server clock
+}
+
+func (HeartbeatEcho) ServerType() ServerMessageType { return ServerHeartbeatEcho }
+
+func (p HeartbeatEcho) Validate() error {
+	if p.Seq <= 0 {
+		return invalidServer(ServerHeartbeatEcho, "seq", "required")
+	}
+	if p.SentAt <= 0 {
+		return invalidServer(ServerHeartbeatEcho, "sentAt", "required")
+	}
+	if p.ReceivedAt <= 0 {
+		return invalidServer(ServerHeartbeatEcho, "receivedAt", "required")
+	}
+	return nil
+}
+
+// Ack confirms the request named by the message's RequestID
+type Ack struct {
+	Action ClientMessageType `json:"action,omitempty"`
+}
+
+func (Ack) ServerType() ServerMessageType { return ServerAck }
+func (Ack) Validate() error               { return nil }
+
+// SeatRequested asks the host to approve a player joining the room
+type SeatRequested struct {
+	Code     string `json:"code"`
+	PlayerID string `json:"playerId"`
+	Nickname string `json:"nickname"`
+}
+
+func (SeatRequested) ServerType() ServerMessageType { return ServerSeatRequested }
+
+func (p SeatRequested) Validate() error {
+	if p.PlayerID == "" {
+		return invalidServer(ServerSeatRequested, "playerId", "required")
+	}
+	return nil
+}
+
+// HostGranted hands the host tools to the receiver, with a fresh token for
+// its host commands, when the previous host leaves the room
+type HostGranted struct {
+	Code      string `json:"code"`
+	HostToken string `json:"hostToken"`
+}
+
+func (HostGranted) ServerType() ServerMessageType { return ServerHostGranted }
+
+func (p HostGranted) Validate() error {
+	if p.HostToken == "" {
+		return invalidServer(ServerHostGranted, "hostToken", "required")
+	}
+	return nil
+}
+
+// HostActed tells everyone at the table what the host did, so stack
+// adjustments and other dealer decisions leave an audit trail. Chips is
+// the player's stack after an adjustment; the blinds are set for
+// HOST_SET_BLINDS.
+type HostActed struct {
+	GameID     string            `json:"gameId,omitempty"`
+	Action     ClientMessageType `json:"action"`
+	PlayerID   string            `json:"playerId,omitempty"`
+	Nickname   string            `json:"nickname,omitempty"`
+	Amount     int               `json:"amount,omitempty"`
+	Chips      int               `json:"chips,omitempty"`
+	SmallBlind int               `json:"smallBlind,omitempty"`
+	BigBlind   int               `json:"bigBlind,omitempty"`
+	SitOut     bool              `json:"sitOut,omitempty"`
+	Approved   bool              `json:"approved,omitempty"`
+	Reason     string            `json:"reason,omitempty"`
+}
+
+func (HostActed) ServerType() ServerMessageType { return ServerHostAction }
+
+func (p HostActed) Validate() error {
+	if !hostCommands[p.Action] {
+		return invalidServer(ServerHostAction, "action", fmt.Sprintf("unknown host action %q", p.Action))
+	}
+	if p.Chips < 0 {
+		return invalidServer(ServerHostAction, "chips", "must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+// Package protocol defines the typed WebSocket messages exchanged with the
+// PokerHole server and the codec that maps them to and from JSON.
+package protocol
+
+// ClientMessageType identifies a client -> server message
+type ClientMessageType string
+
+// ServerMessageType identifies a server -> client message
+type ServerMessageType string
+
+const (
+	// Client -> Server
+	ClientRegister      ClientMessageType = "REGISTER"
+	ClientHeartbeat     ClientMessageType = "HEARTBEAT"
+	ClientJoinRandom    ClientMessageType = "JOIN_RANDOM_MATCH"
+	ClientJoinCode      ClientMessageType = "JOIN_CODE_MATCH"
+	ClientCreateRoom    ClientMessageType = "CREATE_ROOM"
+	ClientSpectate      ClientMessageType = "SPECTATE_GAME"
+	ClientLeaveSpectate ClientMessageType = "LEAVE_SPECTATE"
+	ClientListTables    ClientMessageType = "LIST_TABLES"
+	ClientCancelMatch   ClientMessageType = "CANCEL_MATCHING"
+	ClientCall          ClientMessageType = "CALL"
+	ClientRaise         ClientMessageType = "RAISE"
+	ClientFold          ClientMessageType = "FOLD"
+	ClientCheck         ClientMessageType = "CHECK"
+	ClientAllIn         ClientMessageType = "ALL_IN"
+	ClientLeaveGame     ClientMessageType = "LEAVE_GAME"
+	ClientChatMessage   ClientMessageType = "CHAT_MESSAGE"
+	ClientResumeGame    ClientMessageType = "RESUME_GAME"
+	ClientRequestSync   ClientMessageType = "REQUEST_SYNC"
+
+	// Client -> Server, accepted only from the host of a private room
+	ClientHostPause        ClientMessageType = "HOST_PAUSE"
+	ClientHostResume       ClientMessageType = "HOST_RESUME"
+	ClientHostKick         ClientMessageType = "HOST_KICK"
+	ClientHostSitOut       ClientMessageType = "HOST_SIT_OUT"
+	ClientHostAdjustStack  ClientMessageType = "HOST_ADJUST_STACK"
+	ClientHostSetBlinds    ClientMessageType = "HOST_SET_BLINDS"
+	ClientHostSeatDecision ClientMessageType = "HOST_SEAT_DECISION"
+
+	// Server -> Client
+	ServerRegisterSuccess   ServerMessageType = "REGISTER_SUCCESS"
+	ServerRegisterFailure   ServerMessageType = "REGISTER_FAILURE"
+	ServerMatchingStarted   ServerMessageType = "MATCHING_STARTED"
+	ServerMatchingProgress  ServerMessageType = "MATCHING_PROGRESS"
+	ServerMatchingCompleted ServerMessageType = "MATCHING_COMPLETED"
+	ServerMatchingCancelled ServerMessageType = "MATCHING_CANCELLED"
+	ServerRoomCreated       ServerMessageType = "ROOM_CREATED"
+	ServerSpectateStarted   ServerMessageType = "SPECTATE_STARTED"
+	ServerSpectatorsChanged ServerMessageType = "SPECTATORS_CHANGED"
+	ServerTableList         ServerMessageType = "TABLE_LIST"
+	ServerGameStarted       ServerMessageType = "GAME_STARTED"
+	ServerGameStateUpdate   ServerMessageType = "GAME_STATE_UPDATE"
+	ServerPlayerAction      ServerMessageType = "PLAYER_ACTION"
+	ServerTurnChanged       ServerMessageType = "TURN_CHANGED"
+	ServerRoundProgressed   ServerMessageType = "ROUND_PROGRESSED"
+	ServerRoundCompleted    ServerMessageType = "ROUND_COMPLETED"
+	ServerGameEnded         ServerMessageType = "GAME_ENDED"
+	ServerChatMessage       ServerMessageType = "CHAT_MESSAGE"
+	ServerError             ServerMessageType = "ERROR"
+	ServerInvalidAction     ServerMessageType = "INVALID_ACTION"
+	ServerAck               ServerMessageType = "ACK"
+	ServerSeatRequested     ServerMessageType = "SEAT_REQUESTED"
+	ServerHostAction        ServerMessageType = "HOST_ACTION"
+	ServerHostGranted       ServerMessageType = "HOST_GRANTED"
+	ServerHeartbeatEcho     ServerMessageType = "HEARTBEAT_ECHO"
+)
+
+// ClientPayload is the typed body of a client message
+type ClientPayload interface {
+	ClientType() ClientMessageType
+	Validate() error
+}
+
+// ServerPayload is the typed body of a server message
+type ServerPayload interface {
+	ServerType() ServerMessageType
+	Validate() error
+}
+
+// ClientMessage is a message from client to server. RequestID lets the
+// server's ACK, INVALID_ACTION or ERROR name the request it answers.
+// HostToken accompanies host commands: the secret the server handed the
+// room's host with ROOM_CREATED or HOST_GRANTED.
+type ClientMessage struct {
+	Type      ClientMessageType
+	RequestID string
+	Timestamp int64
+	HostToken string
+	Payload   ClientPayload
+}
+
+// ServerMessage is a message from server to client. RequestID echoes the
+// client request being answered, if any. Seq numbers the updates of one
+// game; snapshots carry the number of the last update they include and
+// messages outside a game carry none.
+type ServerMessage struct {
+	Type      ServerMessageType
+	RequestID string
+	Timestamp int64
+	Seq       int64
+	Payload   ServerPayload
+}
+
+// bettingRounds lists the round names used on the wire
+var bettingRounds = map[string]bool{
+	"PRE_FLOP": true,
+	"FLOP":     true,
+	"TURN":     true,
+	"RIVER":    true,
+	"SHOWDOWN": true,
+}
+
+// gameActions lists the player actions used on the wire
+var gameActions = map[ClientMessageType]bool{
+	ClientFold:  true,
+	ClientCheck: true,
+	ClientCall:  true,
+	ClientRaise: true,
+	ClientAllIn: true,
+}
+
+// hostCommands lists the dealer tools only a room's host may use
+var hostCommands = map[ClientMessageType]bool{
+	ClientHostPause:        true,
+	ClientHostResume:       true,
+	ClientHostKick:         true,
+	ClientHostSitOut:       true,
+	ClientHostAdjustStack:  true,
+	ClientHostSetBlinds:    true,
+	ClientHostSeatDecision: true,
+}
+
+// replyTypes lists the server messages that answer a single request
+var replyTypes = map[ServerMessageType]bool{
+	ServerAck:           true,
+	ServerInvalidAction: true,
+	ServerError:         true,
+}
+
+// IsReply reports whether t answers the request named by its RequestID
+func IsReply(t ServerMessageType) bool {
+	return replyTypes[t]
+}
+
+// IsGameAction reports whether t is a betting action
+func IsGameAction(t ClientMessageType) bool {
+	return gameActions[t]
+}
+
+// IsHostCommand reports whether t is a host-only dealer tool
+func IsHostCommand(t ClientMessageType) bool {
+	return hostCommands[t]
+}
@@ -0,0 +1,45 @@
+package protocol
+
+// Version is the protocol version this client speaks. Bump it whenever a
+// message changes shape. Clients that register without a version speak
+// LegacyVersion.
+const Version = 2
+
+// LegacyVersion is the protocol before versions were negotiated
+const LegacyVersion = 1
+
+// Capabilities name optional features a peer understands, so either side
+// can add messages without a version bump
+const (
+	CapRoundProgressed = "round-progressed" // ROUND_PROGRESSED between streets
+	CapValidActions    = "valid-actions"    // TURN_CHANGED lists the valid actions
+	CapPrivateRooms    = "private-rooms"    // CREATE_ROOM and JOIN_CODE_MATCH
+	CapSpectate        = "spectate"         // SPECTATE_GAME and the table list
+	CapChat            = "chat"             // CHAT_MESSAGE
+	CapResume          = "resume"           // RESUME_GAME and REQUEST_SYNC after a reconnect
+	CapHostTools       = "host-tools"       // HOST_* commands for private rooms
+	CapHeartbeatEcho   = "heartbeat-echo"   // HEARTBEAT is answered with HEARTBEAT_ECHO
+	CapTurnDeadline    = "turn-deadline"    // TURN_CHANGED carries the turn's deadline
+	CapSequence        = "sequence"         // game updates are numbered for gap detection
+)
+
+// Capabilities lists every capability this client advertises
+func Capabilities() []string {
+	return []string{
+		CapRoundProgressed, CapValidActions, CapPrivateRooms, CapSpectate, CapChat, CapResume, CapHostTools,
+		CapHeartbeatEcho, CapTurnDeadline, CapSequence,
+	}
+}
+
+// RegisterUpgradeRequired is the REGISTER_FAILURE code for a client older
+// than the server accepts
+const RegisterUpgradeRequired = "UPGRADE_REQUIRED"
+
+// EffectiveVersion returns the version a peer declared, treating none as
+// the legacy protocol
+func EffectiveVersion(declared int) int {
+	if declared <= 0 {
+		return LegacyVersion
+	}
+	return declared
+}
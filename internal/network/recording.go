@@ -0,0 +1,229 @@
+package network
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Direction says which way a recorded frame travelled
+type Direction string
+
+const (
+	// DirIn is a frame received from the server
+	DirIn Direction = "in"
+	// DirOut is a frame sent to the server
+	DirOut Direction = "out"
+	// DirClosed marks the connection dropping; Text holds the cause
+	DirClosed Direction = "closed"
+)
+
+// Frame is one line of a traffic recording
+type Frame struct {
+	Elapsed time.Duration   `json:"elapsedNs"` // since recording started, from the monotonic clock
+	Dir     Direction       `json:"dir"`
+	Data    json.RawMessage `json:"data,omitempty"` // the frame as sent, when it is JSON
+	Text    string          `json:"text,omitempty"` // frames that are not JSON, kept verbatim
+}
+
+// payload returns the frame's bytes as they were on the wire
+func (f Frame) payload() []byte {
+	if f.Data != nil {
+		return f.Data
+	}
+	return []byte(f.Text)
+}
+
+// Recorder writes every frame a client sends and receives as JSON lines.
+// Recording continues across reconnects. It is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	enc   *json.Encoder
+	start time.Time
+	err   error
+}
+
+// NewRecorder starts a recording on w
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w), start: time.Now()}
+}
+
+// Err returns the first write error; later frames are not recorded
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+func (r *Recorder) record(dir Direction, data []byte) {
+	f := Frame{Elapsed: time.Since(r.start), Dir: dir}
+	if json.Valid(data) {
+		f.Data = json.RawMessage(data)
+	} else {
+		f.Text = string(data)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err == nil {
+		r.err = r.enc.Encode(f)
+	}
+}
+
+// recordingConn tees a connection's frames into a recorder. Closing it on
+// purpose is not recorded as a drop.
+type recordingConn struct {
+	Transport
+	rec     *Recorder
+	closing atomic.Bool
+}
+
+func (c *recordingConn) ReadMessage() (int, []byte, error) {
+	kind, data, err := c.Transport.ReadMessage()
+	switch {
+	case err == nil:
+		c.rec.record(DirIn, data)
+	case !c.closing.Load():
+		c.rec.record(DirClosed, []byte(err.Error()))
+	}
+	return kind, data, err
+}
+
+func (c *recordingConn) WriteMessage(kind int, data []byte) error {
+	c.rec.record(DirOut, data)
+	return c.Transport.WriteMessage(kind, data)
+}
+
+func (c *recordingConn) Close() error {
+	c.closing.Store(true)
+	return c.Transport.Close()
+}
+
+// ReadRecording parses a recording written by a Recorder
+func ReadRecording(r io.Reader) ([]Frame, error) {
+	var frames []Frame
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var f Frame
+		if err := json.Unmarshal(scanner.Bytes(), &f); err != nil {
+			return nil, fmt.Errorf("recording line %d: %w", line, err)
+		}
+		frames = append(frames, f)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read recording: %w", err)
+	}
+	return frames, nil
+}
+
+// errReplayDropped ends a replayed connection where the recorded one dropped
+var errReplayDropped = errors.New("recorded connection dropped")
+
+// Replay plays a recording back to a client as if it came from the server.
+// Received frames arrive on their recorded schedule, recorded drops end
+// the connection so the client reconnects, and whatever the client sends
+// is discarded. After the last frame the connection stays open.
+type Replay struct {
+	mu     sync.Mutex
+	frames []Frame
+	next   int
+	speed  float64
+	start  time.Time
+}
+
+// NewReplay plays frames at the given speed; 2 is twice as fast, and zero
+// or less sends every frame without waiting
+func NewReplay(frames []Frame, speed float64) *Replay {
+	return &Replay{frames: frames, speed: speed}
+}
+
+// Done reports whether every recorded frame has been played
+func (r *Replay) Done() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.next >= len(r.frames)
+}
+
+// Dial opens the next replayed connection; the URL is ignored
+func (r *Replay) Dial(context.Context, string) (Transport, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.start.IsZero() {
+		r.start = time.Now()
+	}
+	return &replayConn{replay: r, closed: make(chan struct{})}, nil
+}
+
+// advance returns the next frame to deliver and when it is due
+func (r *Replay) advance() (Frame, time.Time, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for r.next < len(r.frames) {
+		f := r.frames[r.next]
+		r.next++
+		if f.Dir == DirOut {
+			continue
+		}
+		due := r.start
+		if r.speed > 0 {
+			due = due.Add(time.Duration(float64(f.Elapsed) / r.speed))
+		}
+		return f, due, true
+	}
+	return Frame{}, time.Time{}, false
+}
+
+// replayConn is one connection of a replay
+type replayConn struct {
+	replay    *Replay
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (c *replayConn) ReadMessage() (int, []byte, error) {
+	f, due, ok := c.replay.advance()
+	if !ok {
+		<-c.closed
+		return 0, nil, net.ErrClosed
+	}
+
+	timer := time.NewTimer(time.Until(due))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-c.closed:
+		return 0, nil, net.ErrClosed
+	}
+
+	if f.Dir == DirClosed {
+		return 0, nil, fmt.Errorf("%w: %s", errReplayDropped, f.Text)
+	}
+	return websocket.TextMessage, f.payload(), nil
+}
+
+func (c *replayConn) WriteMessage(int, []byte) error {
+	select {
+	case <-c.closed:
+		return net.ErrClosed
+	default:
+		return nil
+	}
+}
+
+func (c *replayConn) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return nil
+}
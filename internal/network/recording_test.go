@@ -0,0 +1,126 @@
+package network
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+)
+
+// flakyServer registers the client on a server that resumes games, matches
+// a game, sends a garbled frame and drops the first connection, then ends
+// the game on the next one
+func flakyServer(t *testing.T) string {
+	t.Helper()
+	var connections int32
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		if atomic.AddInt32(&connections, 1) == 1 {
+			conn.ReadMessage() // REGISTER
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"REGISTER_SUCCESS","timestamp":1,"payload":{"uuid":"test-uuid","nickname":"test-nickname","capabilities":["resume"]}}`))
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"MATCHING_COMPLETED","timestamp":1,"payload":{"gameId":"game-1"}}`))
+			conn.WriteMessage(websocket.TextMessage, []byte(`not json`))
+			time.Sleep(20 * time.Millisecond)
+			return
+		}
+		for range 3 { // REGISTER, RESUME_GAME, REQUEST_SYNC
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"GAME_ENDED","timestamp":2,"payload":{"gameId":"game-1","reason":"done"}}`))
+		conn.ReadMessage()
+	}))
+	t.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+// watch collects what a client surfaces until it has seen n messages
+func watch(t *testing.T, c *Client, n int) (types []protocol.ServerMessageType, decodeErrors int, reconnected bool) {
+	t.Helper()
+	deadline := time.After(3 * time.Second)
+	for len(types) < n {
+		select {
+		case msg := <-c.Receive():
+			types = append(types, msg.Type)
+		case <-c.Errors():
+			decodeErrors++
+		case change := <-c.States():
+			reconnected = reconnected || change.State == StateReconnecting
+		case <-deadline:
+			t.Fatalf("saw only %v", types)
+		}
+	}
+	return types, decodeErrors, reconnected
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	fast := Backoff{Initial: 10 * time.Millisecond, Max: 20 * time.Millisecond, Multiplier: 2}
+
+	var recording bytes.Buffer
+	live := NewClient(flakyServer(t), "test-uuid", "test-nickname")
+	live.SetBackoff(fast)
+	live.Record(NewRecorder(&recording))
+	if err := live.ConnectWithTimeout(time.Second); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	want, wantErrors, _ := watch(t, live, 3)
+	live.Close()
+
+	frames, err := ReadRecording(&recording)
+	if err != nil {
+		t.Fatalf("ReadRecording failed: %v", err)
+	}
+	var dirs []Direction
+	for i, f := range frames {
+		if i > 0 && f.Elapsed < frames[i-1].Elapsed {
+			t.Errorf("frame %d goes back in time", i)
+		}
+		dirs = append(dirs, f.Dir)
+	}
+	if len(frames) < 9 || frames[0].Dir != DirOut || frames[3].Text != "not json" {
+		t.Fatalf("unexpected recording %v:\n%s", dirs, recording.String())
+	}
+
+	// The replay reproduces the same messages, the garbled frame and the drop
+	replayed := NewClient("ws://127.0.0.1:1/unused", "other-uuid", "other")
+	replayed.SetBackoff(fast)
+	replay := NewReplay(frames, 0)
+	replayed.SetDialer(replay)
+	if err := replayed.ConnectWithTimeout(time.Second); err != nil {
+		t.Fatalf("replay connect failed: %v", err)
+	}
+	defer replayed.Close()
+
+	got, gotErrors, reconnected := watch(t, replayed, 3)
+	if strings.Join(typeNames(got), ",") != strings.Join(typeNames(want), ",") || gotErrors != wantErrors || !reconnected {
+		t.Errorf("replay saw %v (%d errors, reconnect %v), live saw %v (%d errors)", got, gotErrors, reconnected, want, wantErrors)
+	}
+	if replayed.GameID() != "" {
+		t.Errorf("expected the replayed game to end, still in %q", replayed.GameID())
+	}
+	if !replay.Done() || !replayed.IsConnected() {
+		t.Error("expected the replay to finish and stay connected")
+	}
+}
+
+func typeNames(types []protocol.ServerMessageType) []string {
+	names := make([]string, len(types))
+	for i, typ := range types {
+		names[i] = string(typ)
+	}
+	return names
+}
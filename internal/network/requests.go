@@ -0,0 +1,234 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+)
+
+// defaultRequestTimeout bounds how long a game action waits for its reply
+const defaultRequestTimeout = 5 * time.Second
+
+var (
+	// ErrRequestTimeout settles a request the server never answered
+	ErrRequestTimeout = errors.New("request timed out")
+	// ErrConnectionLost settles requests in flight when the connection drops
+	ErrConnectionLost = errors.New("connection lost before reply")
+	// ErrRejected is wrapped by every RequestError
+	ErrRejected = errors.New("request rejected")
+)
+
+// RequestError reports a request the server answered with INVALID_ACTION
+// or ERROR
+type RequestError struct {
+	RequestID string
+	Action    protocol.ClientMessageType
+	Code      string
+	Reason    string
+}
+
+func (e *RequestError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("%s rejected (%s): %s", e.Action, e.Code, e.Reason)
+	}
+	return fmt.Sprintf("%s rejected: %s", e.Action, e.Reason)
+}
+
+func (e *RequestError) Unwrap() error {
+	return ErrRejected
+}
+
+// Result settles a tracked request. Err is nil when the server acknowledged
+// it, a *RequestError when it was rejected, or ErrRequestTimeout /
+// ErrConnectionLost when no reply arrived.
+type Result struct {
+	RequestID string
+	Action    protocol.ClientMessageType
+	Amount    int
+	Err       error
+}
+
+// pendingRequest is a game action waiting for its reply
+type pendingRequest struct {
+	action protocol.ClientMessageType
+	amount int
+	timer  *time.Timer
+}
+
+// Results returns the outcome of every game action sent
+func (c *Client) Results() <-chan Result {
+	return c.results
+}
+
+// SetRequestTimeout changes how long game actions wait for a reply
+func (c *Client) SetRequestTimeout(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requestTimeout = d
+}
+
+// PendingRequests returns the number of game actions awaiting a reply
+func (c *Client) PendingRequests() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.pending)
+}
+
+// track starts the reply timer for a game action
+func (c *Client) track(msg ClientMessage) {
+	req := &pendingRequest{action: msg.Type}
+	if raise, ok := msg.Payload.(protocol.Raise); ok {
+		req.amount = raise.Amount
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	req.timer = time.AfterFunc(c.requestTimeout, func() {
+		c.settle(msg.RequestID, ErrRequestTimeout)
+	})
+	c.pending[msg.RequestID] = req
+}
+
+// untrack forgets a request that never left the client
+func (c *Client) untrack(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if req, ok := c.pending[id]; ok {
+		req.timer.Stop()
+		delete(c.pending, id)
+	}
+}
+
+// isPending reports whether a request still waits for its reply
+func (c *Client) isPending(id string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.pending[id]
+	return ok
+}
+
+// settle reports the outcome of a pending request once
+func (c *Client) settle(id string, err error) bool {
+	c.mu.Lock()
+	req, ok := c.pending[id]
+	if ok {
+		delete(c.pending, id)
+		req.timer.Stop()
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	var rerr *RequestError
+	if errors.As(err, &rerr) && rerr.Action == "" {
+		rerr.Action = req.action
+	}
+
+	c.queued.push(Result{RequestID: id, Action: req.action, Amount: req.amount, Err: err})
+	c.forwarding.Do(func() { go c.forwardResults() })
+	return true
+}
+
+// resultQueue holds settled results in order until the UI reads them, so a
+// slow reader never loses one and settling never blocks the read pump
+type resultQueue struct {
+	mu    sync.Mutex
+	items []Result
+	wake  chan struct{}
+}
+
+func (q *resultQueue) push(res Result) {
+	q.mu.Lock()
+	q.items = append(q.items, res)
+	wake := q.signal()
+	q.mu.Unlock()
+
+	select {
+	case wake <- struct{}{}:
+	default:
+	}
+}
+
+// pop returns the oldest result, or false when the queue is empty
+func (q *resultQueue) pop() (Result, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.items) == 0 {
+		return Result{}, false
+	}
+	res := q.items[0]
+	q.items[0] = Result{}
+	q.items = q.items[1:]
+	return res, true
+}
+
+// signal returns the wake channel; callers hold q.mu
+func (q *resultQueue) signal() chan struct{} {
+	if q.wake == nil {
+		q.wake = make(chan struct{}, 1)
+	}
+	return q.wake
+}
+
+// forwardResults moves queued results onto the results channel until the
+// client is closed
+func (c *Client) forwardResults() {
+	c.queued.mu.Lock()
+	wake := c.queued.signal()
+	c.queued.mu.Unlock()
+
+	for {
+		res, ok := c.queued.pop()
+		if !ok {
+			select {
+			case <-wake:
+				continue
+			case <-c.done:
+				return
+			}
+		}
+		select {
+		case c.results <- res:
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// failPending settles every request in flight with err
+func (c *Client) failPending(err error) {
+	c.mu.RLock()
+	ids := make([]string, 0, len(c.pending))
+	for id := range c.pending {
+		ids = append(ids, id)
+	}
+	c.mu.RUnlock()
+
+	for _, id := range ids {
+		c.settle(id, err)
+	}
+}
+
+// resolve matches a reply to its pending request. It reports whether the
+// message was consumed; ACKs always are.
+func (c *Client) resolve(msg ServerMessage) bool {
+	if !protocol.IsReply(msg.Type) {
+		return false
+	}
+
+	var err error
+	switch p := msg.Payload.(type) {
+	case protocol.InvalidAction:
+		err = &RequestError{RequestID: msg.RequestID, Action: p.Action, Reason: p.Reason}
+	case protocol.Error:
+		err = &RequestError{RequestID: msg.RequestID, Code: p.Code, Reason: p.Message}
+	}
+
+	settled := msg.RequestID != "" && c.settle(msg.RequestID, err)
+	return settled || msg.Type == protocol.ServerAck
+}
@@ -0,0 +1,143 @@
+package network
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+)
+
+// replyServer answers CALL with ACK, CHECK with INVALID_ACTION and ignores
+// everything else
+func replyServer(t *testing.T) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			msg, err := protocol.DecodeClient(data)
+			if err != nil {
+				t.Errorf("DecodeClient failed: %v", err)
+				return
+			}
+			if msg.RequestID == "" {
+				t.Errorf("%s sent without a request ID", msg.Type)
+			}
+
+			var reply protocol.ServerPayload
+			switch msg.Type {
+			case protocol.ClientCall:
+				reply = protocol.Ack{Action: msg.Type}
+			case protocol.ClientCheck:
+				reply = protocol.InvalidAction{Action: msg.Type, Reason: "cannot check facing a bet"}
+			default:
+				continue
+			}
+			out := protocol.NewServerMessage(reply)
+			out.RequestID = msg.RequestID
+			data, _ = protocol.EncodeServer(out)
+			conn.WriteMessage(websocket.TextMessage, data)
+		}
+	}))
+}
+
+func nextResult(t *testing.T, c *Client) Result {
+	t.Helper()
+	select {
+	case res := <-c.Results():
+		return res
+	case <-time.After(2 * time.Second):
+		t.Fatal("no result delivered")
+		return Result{}
+	}
+}
+
+func TestClient_RequestResults(t *testing.T) {
+	server := replyServer(t)
+	defer server.Close()
+
+	client := NewClient("ws"+strings.TrimPrefix(server.URL, "http"), "test-uuid", "test-nickname")
+	client.SetRequestTimeout(100 * time.Millisecond)
+	if err := client.ConnectWithTimeout(time.Second); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer client.Close()
+
+	callID, err := client.Call()
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if res := nextResult(t, client); res.RequestID != callID || res.Err != nil {
+		t.Errorf("expected CALL %s acknowledged, got %+v", callID, res)
+	}
+
+	checkID, _ := client.Check()
+	res := nextResult(t, client)
+	var rerr *RequestError
+	if res.RequestID != checkID || !errors.As(res.Err, &rerr) || !errors.Is(res.Err, ErrRejected) {
+		t.Fatalf("expected CHECK %s rejected, got %+v", checkID, res)
+	}
+	if rerr.Action != protocol.ClientCheck || rerr.Reason != "cannot check facing a bet" {
+		t.Errorf("unexpected rejection: %+v", rerr)
+	}
+
+	raiseID, _ := client.Raise(80)
+	res = nextResult(t, client)
+	if res.RequestID != raiseID || !errors.Is(res.Err, ErrRequestTimeout) || res.Amount != 80 {
+		t.Errorf("expected RAISE %s to time out, got %+v", raiseID, res)
+	}
+
+	if n := client.PendingRequests(); n != 0 {
+		t.Errorf("expected no pending requests, got %d", n)
+	}
+}
+
+func TestClient_ResultsSurviveSlowReader(t *testing.T) {
+	server := replyServer(t)
+	defer server.Close()
+
+	client := NewClient("ws"+strings.TrimPrefix(server.URL, "http"), "test-uuid", "test-nickname")
+	if err := client.ConnectWithTimeout(time.Second); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer client.Close()
+
+	// more calls than the results channel buffers, none read until all settle
+	const calls = 90
+	ids := make([]string, calls)
+	for i := range ids {
+		id, err := client.Call()
+		if err != nil {
+			t.Fatalf("Call %d failed: %v", i, err)
+		}
+		ids[i] = id
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for client.PendingRequests() > 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d calls never settled", client.PendingRequests())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	for i, id := range ids {
+		if res := nextResult(t, client); res.RequestID != id || res.Err != nil {
+			t.Fatalf("result %d: expected CALL %s acknowledged, got %+v", i, id, res)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package network
+
+import (
+	"slices"
+	"time"
+
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+)
+
+const (
+	// defaultSyncRetry is how long REQUEST_SYNC waits for its snapshot
+	// before it is sent again
+	defaultSyncRetry = 2 * time.Second
+	// defaultSyncQuiet is how long a game may go without updates before the
+	// table is asked for anyway: a lost last update leaves no later gap to
+	// notice
+	defaultSyncQuiet = 10 * time.Second
+)
+
+// SetSyncTimers changes how long REQUEST_SYNC waits for its snapshot before
+// it is sent again and how long a game may stay quiet before the client
+// asks for the table
+func (c *Client) SetSyncTimers(retry, quiet time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.syncRetry, c.syncQuiet = retry, quiet
+}
+
+// syncRequested waits for the snapshot a REQUEST_SYNC asked for
+func (c *Client) syncRequested() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.syncing = true
+	c.armSync(c.syncRetry)
+}
+
+// heard rearms the sync timer on every update of the current game; a
+// snapshot answers the REQUEST_SYNC outstanding
+func (c *Client) heard(msg ServerMessage) {
+	_, snapshot := msg.Payload.(protocol.GameStateUpdate)
+	if msg.Seq == 0 && !snapshot {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.gameID == "" {
+		return
+	}
+	if snapshot {
+		c.syncing = false
+	}
+	if c.syncing {
+		c.armSync(c.syncRetry)
+	} else {
+		c.armSync(c.syncQuiet)
+	}
+}
+
+// armSync (re)starts the sync timer; the caller holds c.mu
+func (c *Client) armSync(d time.Duration) {
+	if c.syncTimer == nil {
+		c.syncTimer = time.AfterFunc(d, c.syncDue)
+		return
+	}
+	c.syncTimer.Reset(d)
+}
+
+// syncDue asks for the table of the current game again. Nothing is sent
+// between games, while reconnecting or to servers that cannot resync; the
+// next update or reconnect rearms the timer.
+func (c *Client) syncDue() {
+	c.mu.Lock()
+	gameID := c.gameID
+	due := gameID != "" && c.connected && !c.closed && slices.Contains(c.serverCaps, protocol.CapResume)
+	if !due {
+		c.syncing = false
+	}
+	c.mu.Unlock()
+
+	if due {
+		c.SendPayload(protocol.RequestSync{GameID: gameID})
+	}
+}
@@ -0,0 +1,93 @@
+package network
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+)
+
+// syncServer starts game-1 after registration, then reports when each
+// REQUEST_SYNC arrives. Only the second one is answered.
+func syncServer(t *testing.T) (string, <-chan time.Time) {
+	t.Helper()
+	syncs := make(chan time.Time, 16)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		write := func(seq int64, p protocol.ServerPayload) {
+			msg := protocol.NewServerMessage(p)
+			msg.Seq = seq
+			data, _ := protocol.EncodeServer(msg)
+			conn.WriteMessage(websocket.TextMessage, data)
+		}
+		table := protocol.GameState{GameID: "game-1", Round: "PRE_FLOP"}
+
+		conn.ReadMessage() // REGISTER
+		write(0, protocol.RegisterSuccess{UUID: "test-uuid", ProtocolVersion: protocol.Version, Capabilities: []string{protocol.CapResume}})
+		write(1, protocol.GameStarted{GameState: table})
+		for n := 1; ; {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if msg, err := protocol.DecodeClient(data); err != nil || msg.Type != protocol.ClientRequestSync {
+				continue
+			}
+			syncs <- time.Now()
+			if n++; n == 3 {
+				write(1, protocol.GameStateUpdate{GameState: table})
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http"), syncs
+}
+
+func TestClient_ResyncTimers(t *testing.T) {
+	const retry, quiet = 30 * time.Millisecond, 150 * time.Millisecond
+	url, syncs := syncServer(t)
+	client := NewClient(url, "test-uuid", "test-nickname")
+	client.SetSyncTimers(retry, quiet)
+	start := time.Now()
+	if err := client.ConnectWithTimeout(time.Second); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer client.Close()
+
+	next := func(what string) time.Duration {
+		t.Helper()
+		select {
+		case at := <-syncs:
+			elapsed := at.Sub(start)
+			start = at
+			return elapsed
+		case <-time.After(2 * time.Second):
+			t.Fatalf("no REQUEST_SYNC %s", what)
+			return 0
+		}
+	}
+
+	// A quiet game is asked for even though no gap showed
+	if d := next("after a quiet spell"); d < quiet {
+		t.Errorf("asked after %v, before the game went quiet", d)
+	}
+	// An unanswered request is sent again
+	if d := next("after a lost reply"); d >= quiet {
+		t.Errorf("resent after %v, expected the retry interval", d)
+	}
+	// Once the snapshot arrives the game is quiet again
+	if d := next("after the snapshot"); d < quiet {
+		t.Errorf("asked again after %v although the snapshot arrived", d)
+	}
+}
@@ -0,0 +1,40 @@
+package network
+
+import (
+	"context"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Transport carries frames between the client and the server. Reads come
+// from a single goroutine and writes from another; Close may be called from
+// any goroutine and must unblock both.
+type Transport interface {
+	ReadMessage() (messageType int, data []byte, err error)
+	WriteMessage(messageType int, data []byte) error
+	Close() error
+}
+
+// Dialer opens transports to the server. The context carries the dial
+// timeout.
+type Dialer interface {
+	Dial(ctx context.Context, url string) (Transport, error)
+}
+
+// WebSocketDialer connects with gorilla/websocket; it is the default
+type WebSocketDialer struct{}
+
+// Dial opens a WebSocket connection to url
+func (WebSocketDialer) Dial(ctx context.Context, url string) (Transport, error) {
+	var dialer websocket.Dialer
+	if deadline, ok := ctx.Deadline(); ok {
+		dialer.HandshakeTimeout = time.Until(deadline)
+	}
+
+	conn, _, err := dialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
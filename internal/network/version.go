@@ -0,0 +1,86 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+)
+
+// ErrUpgradeRequired is wrapped by every UpgradeError
+var ErrUpgradeRequired = errors.New("client upgrade required")
+
+// UpgradeError reports a server that no longer accepts this client's
+// protocol version. The client stops reconnecting to that server.
+type UpgradeError struct {
+	Current  int    // protocol.Version
+	Required int    // the server's minimum, 0 when it did not say
+	Reason   string // as given by the server
+}
+
+func (e *UpgradeError) Error() string {
+	if e.Required > 0 {
+		return fmt.Sprintf("server requires protocol v%d, client speaks v%d", e.Required, e.Current)
+	}
+	return fmt.Sprintf("server rejected protocol v%d: %s", e.Current, e.Reason)
+}
+
+func (e *UpgradeError) Unwrap() error {
+	return ErrUpgradeRequired
+}
+
+// negotiate records what the server declared at registration and returns
+// an UpgradeError when it will not talk to this client
+func (c *Client) negotiate(msg ServerMessage) *UpgradeError {
+	switch p := msg.Payload.(type) {
+	case protocol.RegisterSuccess:
+		c.mu.Lock()
+		c.serverVersion = protocol.EffectiveVersion(p.ProtocolVersion)
+		c.serverCaps = p.Capabilities
+		c.mu.Unlock()
+		// Measure the connection right away instead of a heartbeat later
+		if slices.Contains(p.Capabilities, protocol.CapHeartbeatEcho) {
+			select {
+			case c.probe <- struct{}{}:
+			default:
+			}
+		}
+		if p.MinVersion > protocol.Version {
+			return &UpgradeError{Current: protocol.Version, Required: p.MinVersion}
+		}
+	case protocol.RegisterFailure:
+		if p.Code == protocol.RegisterUpgradeRequired {
+			return &UpgradeError{Current: protocol.Version, Required: p.MinVersion, Reason: p.Reason}
+		}
+	}
+	return nil
+}
+
+// outdated gives up on a server that requires a newer client: the
+// connection is closed without reconnecting and later attempts fail until
+// the server changes
+func (c *Client) outdated(err *UpgradeError) {
+	c.mu.Lock()
+	c.upgrade = err
+	c.mu.Unlock()
+
+	c.Disconnect()
+	c.setState(StateChange{State: StateFailed, Err: err})
+}
+
+// ServerVersion returns the protocol version the server declared at
+// registration; legacy servers report protocol.LegacyVersion and 0 means
+// not registered yet
+func (c *Client) ServerVersion() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.serverVersion
+}
+
+// Supports reports whether the server declared a capability at registration
+func (c *Client) Supports(capability string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return slices.Contains(c.serverCaps, capability)
+}
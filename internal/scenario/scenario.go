@@ -0,0 +1,148 @@
+// Package scenario loads practice and regression spots from JSON files
+package scenario
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bunnyholes/pokerhole/client/internal/core/application/service"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/card"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/game/vo"
+)
+
+// Scenario is the on-disk description of a spot
+type Scenario struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Seed        *int64   `json:"seed,omitempty"`
+	SmallBlind  int      `json:"smallBlind"`
+	BigBlind    int      `json:"bigBlind"`
+	Round       string   `json:"round"`
+	Pot         int      `json:"pot"`
+	Board       []string `json:"board,omitempty"`
+	ToAct       int      `json:"toAct"`
+	Seats       []Seat   `json:"seats"`
+}
+
+// Seat is one seat of a Scenario
+type Seat struct {
+	Nickname string   `json:"nickname"`
+	Chips    int      `json:"chips"`
+	Bet      int      `json:"bet,omitempty"`
+	Hole     []string `json:"hole,omitempty"`
+	Folded   bool     `json:"folded,omitempty"`
+}
+
+// Load reads and validates a scenario file
+func Load(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario: %w", err)
+	}
+
+	sc, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return sc, nil
+}
+
+// Parse decodes and validates scenario JSON
+func Parse(data []byte) (*Scenario, error) {
+	var sc Scenario
+	if err := json.Unmarshal(data, &sc); err != nil {
+		return nil, fmt.Errorf("invalid scenario JSON: %w", err)
+	}
+
+	if _, err := sc.Setup(0); err != nil {
+		return nil, err
+	}
+	return &sc, nil
+}
+
+// Setup converts the scenario into an engine setup. The scenario's own seed
+// wins over fallbackSeed when present.
+func (s *Scenario) Setup(fallbackSeed int64) (service.OfflineSetup, error) {
+	round, err := parseRound(s.Round)
+	if err != nil {
+		return service.OfflineSetup{}, err
+	}
+
+	board, err := parseCards(s.Board)
+	if err != nil {
+		return service.OfflineSetup{}, fmt.Errorf("board: %w", err)
+	}
+
+	setup := service.OfflineSetup{
+		Seed:       fallbackSeed,
+		SmallBlind: s.SmallBlind,
+		BigBlind:   s.BigBlind,
+		Round:      round,
+		Pot:        s.Pot,
+		Board:      board,
+		ToAct:      s.ToAct,
+	}
+	if s.Seed != nil {
+		setup.Seed = *s.Seed
+	}
+
+	for i, seat := range s.Seats {
+		hole, err := parseCards(seat.Hole)
+		if err != nil {
+			return service.OfflineSetup{}, fmt.Errorf("seat %d: %w", i, err)
+		}
+		setup.Seats = append(setup.Seats, service.SeatSetup{
+			Nickname: seat.Nickname,
+			Chips:    seat.Chips,
+			Bet:      seat.Bet,
+			Hole:     hole,
+			Folded:   seat.Folded,
+		})
+	}
+
+	if err := setup.Validate(); err != nil {
+		return service.OfflineSetup{}, err
+	}
+	return setup, nil
+}
+
+// NewGame starts an offline game from the scenario
+func (s *Scenario) NewGame(fallbackSeed int64) (*service.OfflineGame, error) {
+	setup, err := s.Setup(fallbackSeed)
+	if err != nil {
+		return nil, err
+	}
+	return service.NewOfflineGameFromSetup(setup)
+}
+
+func parseRound(name string) (vo.BettingRound, error) {
+	if name == "" {
+		return vo.PreFlop, nil
+	}
+
+	normalized := strings.ReplaceAll(strings.ToUpper(strings.TrimSpace(name)), "-", "_")
+	if normalized == "PREFLOP" {
+		normalized = "PRE_FLOP"
+	}
+	for r := vo.PreFlop; r <= vo.River; r++ {
+		if r.String() == normalized {
+			return r, nil
+		}
+	}
+	return vo.PreFlop, errors.New("round must be one of PRE_FLOP, FLOP, TURN, RIVER")
+}
+
+func parseCards(values []string) ([]card.Card, error) {
+	cards := make([]card.Card, 0, len(values))
+	for _, v := range values {
+		c, err := card.ParseCard(v)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, c)
+	}
+	return cards, nil
+}
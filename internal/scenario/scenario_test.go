@@ -0,0 +1,192 @@
+package scenario
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bunnyholes/pokerhole/client/internal/core/application/service"
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/game/vo"
+)
+
+func TestLoad_RiverFlushWins(t *testing.T) {
+	sc, err := Load(filepath.Join("testdata", "river-flush-wins.json"))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	g, err := sc.NewGame(0)
+	if err != nil {
+		t.Fatalf("NewGame failed: %v", err)
+	}
+
+	state := g.GetGameState()
+	if state.Round != "RIVER" {
+		t.Fatalf("Expected RIVER, got %s", state.Round)
+	}
+	if strings.Join(state.CommunityCards, " ") != "♥2 ♥9 ♣K ♦4 ♥7" {
+		t.Fatalf("Unexpected board: %v", state.CommunityCards)
+	}
+	if state.Players[0].Hand != "[♥A ♥J]" || state.Players[1].Hand != "[♠K ♦Q]" {
+		t.Fatalf("Unexpected hole cards: %s / %s", state.Players[0].Hand, state.Players[1].Hand)
+	}
+	if state.Pot != 200 || state.CurrentPlayer != 0 {
+		t.Fatalf("Unexpected pot %d or player to act %d", state.Pot, state.CurrentPlayer)
+	}
+
+	// Check the river down; the flush wins at showdown
+	g.PlayerAction(0, vo.Check, 0)
+	g.PlayerAction(1, vo.Check, 0)
+	if err := g.ProgressRound(); err != nil {
+		t.Fatalf("ProgressRound failed: %v", err)
+	}
+
+	state = g.GetGameState()
+	if state.WinnerIndex != 0 {
+		t.Fatalf("Expected hero to win with a flush, winner %d (%s)", state.WinnerIndex, state.WinnerHandRank)
+	}
+	if players := g.GetPlayers(); players[0].Chips() != 1100 {
+		t.Errorf("Expected hero to collect the 200 pot, chips %d", players[0].Chips())
+	}
+}
+
+func TestRestartDealsSameSpot(t *testing.T) {
+	sc, err := Load(filepath.Join("testdata", "river-flush-wins.json"))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	g, err := sc.NewGame(0)
+	if err != nil {
+		t.Fatalf("NewGame failed: %v", err)
+	}
+
+	g.PlayerAction(0, vo.AllIn, 0)
+	if err := g.Restart(); err != nil {
+		t.Fatalf("Restart failed: %v", err)
+	}
+
+	state := g.GetGameState()
+	if state.Round != "RIVER" || state.Pot != 200 || state.Players[0].Chips != 900 {
+		t.Errorf("Restart did not restore the spot: %+v", state)
+	}
+	if state.Players[0].Hand != "[♥A ♥J]" {
+		t.Errorf("Expected fixed hole cards after restart, got %s", state.Players[0].Hand)
+	}
+}
+
+func TestFixedLaterStreets(t *testing.T) {
+	sc, err := Parse([]byte(`{
+		"name": "river completes the flush",
+		"round": "TURN",
+		"pot": 100,
+		"board": ["2h", "9h", "Kc", "4d", "7h"],
+		"seats": [
+			{"nickname": "Hero", "chips": 500, "hole": ["Ah", "Jh"]},
+			{"nickname": "AI Player", "chips": 500}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	for seed := int64(0); seed < 5; seed++ {
+		g, err := sc.NewGame(seed)
+		if err != nil {
+			t.Fatalf("NewGame failed: %v", err)
+		}
+		// Only the turn board shows until the river is dealt
+		if board := g.GetGameState().CommunityCards; len(board) != 4 {
+			t.Fatalf("Expected 4 visible cards on the turn, got %v", board)
+		}
+		if err := g.ProgressRound(); err != nil {
+			t.Fatalf("ProgressRound failed: %v", err)
+		}
+		if board := g.GetGameState().CommunityCards; strings.Join(board, " ") != "♥2 ♥9 ♣K ♦4 ♥7" {
+			t.Errorf("seed %d: expected the fixed river, got %v", seed, board)
+		}
+	}
+}
+
+func TestLoad_RejectsDuplicateCards(t *testing.T) {
+	_, err := Load(filepath.Join("testdata", "duplicate-card.json"))
+	if err == nil || !strings.Contains(err.Error(), "more than once") {
+		t.Fatalf("Expected duplicate card error, got %v", err)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	cases := map[string]string{
+		"bad json":   `{`,
+		"bad round":  `{"round":"FIFTH","seats":[{"nickname":"Hero","chips":100},{"nickname":"Bot","chips":100}]}`,
+		"bad card":   `{"board":["Zz"],"seats":[{"nickname":"Hero","chips":100},{"nickname":"Bot","chips":100}]}`,
+		"one seat":   `{"seats":[{"nickname":"Hero","chips":100}]}`,
+		"bet > chip": `{"seats":[{"nickname":"Hero","chips":100,"bet":200},{"nickname":"Bot","chips":100}]}`,
+	}
+
+	for name, data := range cases {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestBundledDrillsLoad(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("..", "..", "scenarios", "*.json"))
+	if err != nil {
+		t.Fatalf("Glob failed: %v", err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("No bundled drills found")
+	}
+
+	for _, path := range paths {
+		sc, err := Load(path)
+		if err != nil {
+			t.Errorf("%s: %v", path, err)
+			continue
+		}
+		if _, err := sc.NewGame(1); err != nil {
+			t.Errorf("%s: NewGame failed: %v", path, err)
+		}
+	}
+}
+
+func TestParse_BoardMustMatchRound(t *testing.T) {
+	seats := `"seats":[{"nickname":"Hero","chips":100},{"nickname":"Bot","chips":100}]`
+	cases := map[string]string{
+		"flop missing a card": `{"round":"FLOP","board":["2h","9h"],` + seats + `}`,
+		"turn missing a card": `{"round":"TURN","board":["2h","9h","Kc"],` + seats + `}`,
+		"six cards":           `{"round":"FLOP","board":["2h","9h","Kc","4d","7h","8s"],` + seats + `}`,
+	}
+	for name, data := range cases {
+		if _, err := Parse([]byte(data)); err == nil || !strings.Contains(err.Error(), "board has") {
+			t.Errorf("%s: expected a board/round mismatch, got %v", name, err)
+		}
+	}
+
+	// Cards past the visible board fix the streets still to come
+	for _, data := range []string{
+		`{"round":"FLOP","board":["2h","9h","Kc"],` + seats + `}`,
+		`{"round":"TURN","board":["2h","9h","Kc","4d","7h"],` + seats + `}`,
+		`{"round":"PRE_FLOP","board":["2h","9h","Kc"],` + seats + `}`,
+	} {
+		if _, err := Parse([]byte(data)); err != nil {
+			t.Errorf("%s should be accepted: %v", data, err)
+		}
+	}
+}
+
+func TestScenarioGamesHaveNoReplayToken(t *testing.T) {
+	sc, err := Load(filepath.Join("testdata", "river-flush-wins.json"))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	g, err := sc.NewGame(0)
+	if err != nil {
+		t.Fatalf("NewGame failed: %v", err)
+	}
+	if token, err := g.ReplayToken(); !errors.Is(err, service.ErrScenarioReplay) || token != "" {
+		t.Errorf("Expected no token for a scenario game, got %q (%v)", token, err)
+	}
+}
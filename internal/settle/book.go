@@ -0,0 +1,189 @@
+// Package settle keeps the buy-in book of a home game and works out who
+// pays whom at the end of the night
+package settle
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Account is one player's money in and out of the session, in chips
+type Account struct {
+	PlayerID string
+	Name     string
+	BuyIns   int // first buy-in plus rebuys
+	In       int // chips bought
+	Out      int // chips taken off the table before the end
+	Stack    int // chips in front of the player now
+}
+
+// Net is what the player won (positive) or lost, counting the current
+// stack as cashed out
+func (a Account) Net() int {
+	return a.Out + a.Stack - a.In
+}
+
+// CashOut is everything the player leaves with
+func (a Account) CashOut() int {
+	return a.Out + a.Stack
+}
+
+// Book follows player stacks across the hands of a session. It is safe
+// for concurrent use.
+type Book struct {
+	mu       sync.Mutex
+	started  time.Time
+	accounts map[string]*Account
+	order    []string // player IDs in seating order
+}
+
+// NewBook starts an empty session
+func NewBook() *Book {
+	return &Book{started: time.Now(), accounts: make(map[string]*Account)}
+}
+
+// Seat records a player's stack; the first time a player is seen their
+// stack is their buy-in. Later calls only follow the stack.
+func (b *Book) Seat(playerID, name string, stack int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	a, ok := b.accounts[playerID]
+	if !ok {
+		a = &Account{PlayerID: playerID, Name: name, BuyIns: 1, In: stack}
+		b.accounts[playerID] = a
+		b.order = append(b.order, playerID)
+	}
+	if name != "" {
+		a.Name = name
+	}
+	a.Stack = stack
+}
+
+// Adjust records chips added to (rebuy) or taken off (partial cash-out)
+// a player's stack outside of play
+func (b *Book) Adjust(playerID string, amount int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	a, ok := b.accounts[playerID]
+	if !ok {
+		return
+	}
+	if amount > 0 {
+		a.BuyIns++
+		a.In += amount
+	} else {
+		a.Out -= amount
+	}
+	a.Stack = max(a.Stack+amount, 0)
+}
+
+// Empty reports whether no player has bought in yet
+func (b *Book) Empty() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.order) == 0
+}
+
+// Summary settles the session with every stack cashed out as it stands
+func (b *Book) Summary() Summary {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s := Summary{Started: b.started, Accounts: make([]Account, 0, len(b.order))}
+	for _, id := range b.order {
+		a := *b.accounts[id]
+		s.Accounts = append(s.Accounts, a)
+		s.In += a.In
+		s.Out += a.CashOut()
+	}
+	s.Transfers = Transfers(s.Accounts)
+	return s
+}
+
+// Summary is the settled session
+type Summary struct {
+	Started   time.Time
+	Accounts  []Account
+	Transfers []Transfer
+	In        int // chips bought by everyone
+	Out       int // chips cashed out by everyone
+}
+
+// Balanced reports whether every chip bought was cashed out; bots and
+// players who left with their stack unrecorded break the balance
+func (s Summary) Balanced() bool {
+	return s.In == s.Out
+}
+
+// Transfer is one payment that settles the session
+type Transfer struct {
+	From   string // name of the paying player
+	To     string
+	Amount int
+}
+
+// Transfers returns a short list of payments that settles every account.
+// Losers and winners whose amounts match exactly are paired first; the
+// rest pay the largest remaining winner, so n players never need more
+// than n-1 payments. When the book is unbalanced only the smaller side is
+// settled.
+func Transfers(accounts []Account) []Transfer {
+	var debtors, creditors []*party
+	for _, a := range accounts {
+		switch net := a.Net(); {
+		case net < 0:
+			debtors = append(debtors, &party{a.Name, -net})
+		case net > 0:
+			creditors = append(creditors, &party{a.Name, net})
+		}
+	}
+	byAmount := func(x, y *party) int {
+		return cmp.Or(cmp.Compare(y.amount, x.amount), cmp.Compare(x.name, y.name))
+	}
+	slices.SortStableFunc(debtors, byAmount)
+	slices.SortStableFunc(creditors, byAmount)
+
+	var out []Transfer
+	pay := func(d, c *party, amount int) {
+		out = append(out, Transfer{From: d.name, To: c.name, Amount: amount})
+		d.amount -= amount
+		c.amount -= amount
+	}
+
+	for _, d := range debtors {
+		for _, c := range creditors {
+			if d.amount > 0 && d.amount == c.amount {
+				pay(d, c, d.amount)
+			}
+		}
+	}
+
+	for {
+		d, c := largest(debtors), largest(creditors)
+		if d == nil || c == nil {
+			return out
+		}
+		pay(d, c, min(d.amount, c.amount))
+	}
+}
+
+// party is a player still owed or owing during Transfers
+type party struct {
+	name   string
+	amount int
+}
+
+// largest returns the party with the most left to settle, or nil
+func largest(parties []*party) *party {
+	var best *party
+	for _, p := range parties {
+		if p.amount > 0 && (best == nil || p.amount > best.amount) {
+			best = p
+		}
+	}
+	return best
+}
@@ -0,0 +1,51 @@
+package settle
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Text renders the summary for pasting into a group chat
+func (s Summary) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "PokerHole 정산 · %s\n\n", s.Started.Format("2006-01-02 15:04"))
+
+	for _, a := range s.Accounts {
+		fmt.Fprintf(&b, "%s: 바이인 %d회 %d, 회수 %d, 손익 %+d\n", a.Name, a.BuyIns, a.In, a.CashOut(), a.Net())
+	}
+	if !s.Balanced() {
+		fmt.Fprintf(&b, "\n주의: 바이인 %d, 회수 %d으로 %d칩이 맞지 않습니다.\n", s.In, s.Out, s.Out-s.In)
+	}
+
+	b.WriteString("\n송금\n")
+	if len(s.Transfers) == 0 {
+		b.WriteString("정산할 금액이 없습니다.\n")
+	}
+	for _, t := range s.Transfers {
+		fmt.Fprintf(&b, "%s → %s %d\n", t.From, t.To, t.Amount)
+	}
+	return b.String()
+}
+
+// WriteCSV writes one row per player followed by one row per transfer
+func (s Summary) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	rows := [][]string{{"kind", "player", "buy_ins", "in", "out", "net", "to", "amount"}}
+	for _, a := range s.Accounts {
+		rows = append(rows, []string{"player", a.Name, itoa(a.BuyIns), itoa(a.In), itoa(a.CashOut()), itoa(a.Net()), "", ""})
+	}
+	for _, t := range s.Transfers {
+		rows = append(rows, []string{"transfer", t.From, "", "", "", "", t.To, itoa(t.Amount)})
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return fmt.Errorf("write settlement csv: %w", err)
+	}
+	return nil
+}
+
+func itoa(n int) string {
+	return strconv.Itoa(n)
+}
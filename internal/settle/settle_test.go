@@ -0,0 +1,124 @@
+package settle
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+func TestBook_RebuysAndCashOuts(t *testing.T) {
+	b := NewBook()
+	b.Seat("a", "Alice", 1000)
+	b.Seat("b", "Bob", 1000)
+	b.Seat("c", "Carol", 1000)
+
+	// Bob busts and rebuys, Alice takes some chips home early
+	b.Seat("b", "", 0)
+	b.Seat("a", "", 1900)
+	b.Adjust("b", 1000)
+	b.Adjust("a", -400)
+	b.Seat("b", "", 600)
+	b.Seat("c", "", 1500)
+	b.Seat("a", "Alice2", 1500)
+
+	s := b.Summary()
+	if len(s.Accounts) != 3 || s.Accounts[0].Name != "Alice2" {
+		t.Fatalf("unexpected accounts: %+v", s.Accounts)
+	}
+	alice, bob, carol := s.Accounts[0], s.Accounts[1], s.Accounts[2]
+	if alice.Out != 400 || alice.CashOut() != 1900 || alice.Net() != 900 {
+		t.Errorf("alice = %+v", alice)
+	}
+	if bob.BuyIns != 2 || bob.In != 2000 || bob.Net() != -1400 {
+		t.Errorf("bob = %+v", bob)
+	}
+	if carol.Net() != 500 || !s.Balanced() {
+		t.Errorf("carol = %+v, in %d out %d", carol, s.In, s.Out)
+	}
+
+	want := []Transfer{{"Bob", "Alice2", 900}, {"Bob", "Carol", 500}}
+	if len(s.Transfers) != len(want) {
+		t.Fatalf("transfers = %+v", s.Transfers)
+	}
+	for i := range want {
+		if s.Transfers[i] != want[i] {
+			t.Errorf("transfer %d = %+v, want %+v", i, s.Transfers[i], want[i])
+		}
+	}
+}
+
+func TestTransfers(t *testing.T) {
+	account := func(name string, net int) Account {
+		return Account{Name: name, In: 1000, Stack: 1000 + net}
+	}
+	cases := []struct {
+		name     string
+		accounts []Account
+		want     int
+	}{
+		{"nobody owes", []Account{account("a", 0), account("b", 0)}, 0},
+		{"exact pairs first", []Account{account("a", -300), account("b", -500), account("c", 500), account("d", 300)}, 2},
+		{"one loser", []Account{account("a", -900), account("b", 300), account("c", 300), account("d", 300)}, 3},
+		{"chain", []Account{account("a", -700), account("b", -200), account("c", 600), account("d", 300)}, 3},
+		{"unbalanced", []Account{account("a", -100), account("b", 300)}, 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			transfers := Transfers(tc.accounts)
+			if len(transfers) != tc.want {
+				t.Fatalf("got %d transfers %+v, want %d", len(transfers), transfers, tc.want)
+			}
+
+			owed := map[string]int{}
+			for _, a := range tc.accounts {
+				owed[a.Name] = a.Net()
+			}
+			for _, tr := range transfers {
+				if tr.Amount <= 0 {
+					t.Errorf("non-positive transfer %+v", tr)
+				}
+				owed[tr.From] += tr.Amount
+				owed[tr.To] -= tr.Amount
+			}
+			for name, left := range owed {
+				if tc.name != "unbalanced" && left != 0 {
+					t.Errorf("%s still has %d to settle", name, left)
+				}
+			}
+		})
+	}
+}
+
+func TestSummaryExport(t *testing.T) {
+	b := NewBook()
+	b.Seat("a", "Alice", 1000)
+	b.Seat("b", "Bob, Jr.", 1000)
+	b.Seat("a", "", 1250)
+	b.Seat("b", "", 750)
+	s := b.Summary()
+
+	text := s.Text()
+	for _, want := range []string{"Alice: 바이인 1회 1000, 회수 1250, 손익 +250", "Bob, Jr. → Alice 250"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("text missing %q:\n%s", want, text)
+		}
+	}
+
+	var out strings.Builder
+	if err := s.WriteCSV(&out); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(out.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("csv does not parse back: %v", err)
+	}
+	if len(rows) != 4 || rows[2][1] != "Bob, Jr." || rows[3][0] != "transfer" || rows[3][7] != "250" {
+		t.Errorf("unexpected rows: %v", rows)
+	}
+
+	// Chips that vanish from the table unbalance the book, and it says so
+	b.Seat("a", "", 0)
+	if text := b.Summary().Text(); !strings.Contains(text, "주의") {
+		t.Errorf("expected an imbalance warning:\n%s", text)
+	}
+}
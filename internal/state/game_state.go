@@ -1,13 +1,15 @@
 package state
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/bunnyholes/pokerhole/client/internal/network"
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
 )
 
-// GameState represents the current game state
-type GameState struct {
+// Snapshot is a point-in-time copy of the game state
+type Snapshot struct {
 	GameID         string
 	Round          string
 	Pot            int
@@ -16,124 +18,217 @@ type GameState struct {
 	Players        []PlayerState
 	CurrentPlayer  string
 	ValidActions   []string
-	mu             sync.RWMutex
+	Winners        []WinnerState // result of the last completed hand
+	Ended          bool
+	EndReason      string
+	Spectators     int    // clients watching without a seat
+	HostID         string // player allowed to use the host tools
+	Paused         bool   // the host stopped the game
+	TurnDeadline   int64  // server clock, Unix ms, when the turn runs out; 0 without a clock
+}
+
+// WinnerState is one pot award of a completed hand
+type WinnerState struct {
+	PlayerID string
+	Amount   int
+	HandRank string
+}
+
+// GameState represents the current game state
+type GameState struct {
+	mu      sync.RWMutex
+	data    Snapshot
+	version int                         // bumped by every server update
+	pending map[string]optimisticAction // actions shown before the server confirmed them
+
+	seq     int64                           // last numbered update applied
+	held    map[int64]network.ServerMessage // updates that arrived ahead of a missing one
+	syncing bool                            // a gap is open and a snapshot was asked for
 }
 
 // PlayerState represents a player's state
 type PlayerState struct {
-	ID       string
-	Nickname string
-	Chips    int
-	Bet      int
-	Status   string
-	Position int
+	ID         string
+	Nickname   string
+	Chips      int
+	Bet        int
+	Status     string
+	Position   int
+	HoleCards  []string
+	LastAction string // e.g. "RAISE 40", cleared each betting round
 }
 
 // NewGameState creates a new game state
 func NewGameState() *GameState {
 	return &GameState{
-		Players:        make([]PlayerState, 0),
-		CommunityCards: make([]string, 0),
-		ValidActions:   make([]string, 0),
+		data: Snapshot{
+			Players:        make([]PlayerState, 0),
+			CommunityCards: make([]string, 0),
+			ValidActions:   make([]string, 0),
+		},
 	}
 }
 
-// Update updates the game state from a server message
-func (s *GameState) Update(msg network.ServerMessage) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	payload := msg.Payload
-	if payload == nil {
-		return
+// applyMessage changes the state by one server message
+func (s *GameState) applyMessage(msg network.ServerMessage) {
+	s.version++
+	switch p := msg.Payload.(type) {
+	case protocol.GameStarted:
+		s.data = Snapshot{Spectators: s.data.Spectators}
+		s.apply(p.GameState)
+	case protocol.GameStateUpdate:
+		s.apply(p.GameState)
+	case protocol.TurnChanged:
+		s.data.CurrentPlayer = p.CurrentPlayer
+		s.data.ValidActions = actionNames(p.ValidActions)
+		s.data.TurnDeadline = p.Deadline
+	case protocol.RoundProgressed:
+		s.data.Round = p.Round
+		s.data.CommunityCards = append([]string(nil), p.CommunityCards...)
+		for i := range s.data.Players {
+			s.data.Players[i].LastAction = ""
+		}
+	case protocol.PlayerActed:
+		if i := s.playerIndex(p.PlayerID); i >= 0 {
+			s.data.Players[i].LastAction = actionLabel(p.Action, p.Amount)
+		}
+		if p.PlayerID == s.data.CurrentPlayer {
+			s.data.TurnDeadline = 0
+		}
+	case protocol.RoundCompleted:
+		s.data.Winners = winnerStates(p.Winners)
+		s.data.CurrentPlayer = ""
+		s.data.ValidActions = nil
+		s.data.TurnDeadline = 0
+	case protocol.GameEnded:
+		s.data.Ended = true
+		s.data.EndReason = p.Reason
+		s.data.CurrentPlayer = ""
+		s.data.ValidActions = nil
+		s.data.TurnDeadline = 0
+	case protocol.MatchingCompleted:
+		s.data.GameID = p.GameID
+	case protocol.SpectateStarted:
+		// Watching a new table starts from an empty state
+		s.data = Snapshot{GameID: p.GameID, Spectators: p.Spectators}
+	case protocol.HostActed:
+		switch p.Action {
+		case protocol.ClientHostPause:
+			s.data.Paused = true
+			s.data.ValidActions = nil
+			s.data.TurnDeadline = 0
+		case protocol.ClientHostResume:
+			s.data.Paused = false
+		case protocol.ClientHostAdjustStack:
+			if i := s.playerIndex(p.PlayerID); i >= 0 {
+				s.data.Players[i].Chips = p.Chips
+			}
+		}
+	case protocol.SpectatorsChanged:
+		if p.GameID == "" || p.GameID == s.data.GameID {
+			s.data.Spectators = p.Spectators
+		}
 	}
+}
 
-	// Update fields from payload
-	if gameID, ok := payload["gameId"].(string); ok {
-		s.GameID = gameID
-	}
-	if round, ok := payload["round"].(string); ok {
-		s.Round = round
-	}
-	if pot, ok := payload["pot"].(float64); ok {
-		s.Pot = int(pot)
-	}
-	if currentBet, ok := payload["currentBet"].(float64); ok {
-		s.CurrentBet = int(currentBet)
-	}
-	if currentPlayer, ok := payload["currentPlayer"].(string); ok {
-		s.CurrentPlayer = currentPlayer
+// apply replaces the state with a full server snapshot
+func (s *GameState) apply(gs protocol.GameState) {
+	s.data.GameID = gs.GameID
+	s.data.Round = gs.Round
+	s.data.Pot = gs.Pot
+	s.data.CurrentBet = gs.CurrentBet
+	s.data.CurrentPlayer = gs.CurrentPlayer
+	s.data.CommunityCards = append(make([]string, 0, len(gs.CommunityCards)), gs.CommunityCards...)
+	s.data.ValidActions = actionNames(gs.ValidActions)
+	s.data.HostID = gs.HostID
+	s.data.Paused = gs.Paused
+	s.data.TurnDeadline = gs.TurnDeadline
+
+	// A snapshot taken after a hand carries its result, which a lost
+	// ROUND_COMPLETED would otherwise take with it; a new hand clears it
+	if len(gs.Winners) > 0 {
+		s.data.Winners = winnerStates(gs.Winners)
+	} else if gs.Round == "PRE_FLOP" && len(gs.CommunityCards) == 0 {
+		s.data.Winners = nil
 	}
 
-	// Update community cards
-	if cards, ok := payload["communityCards"].([]interface{}); ok {
-		s.CommunityCards = make([]string, 0, len(cards))
-		for _, card := range cards {
-			if cardStr, ok := card.(string); ok {
-				s.CommunityCards = append(s.CommunityCards, cardStr)
+	previous := s.data.Players
+	s.data.Players = make([]PlayerState, 0, len(gs.Players))
+	for _, p := range gs.Players {
+		player := PlayerState{
+			ID:        p.ID,
+			Nickname:  p.Nickname,
+			Chips:     p.Chips,
+			Bet:       p.Bet,
+			Status:    p.Status,
+			Position:  p.Position,
+			HoleCards: append([]string(nil), p.HoleCards...),
+		}
+		for _, old := range previous {
+			if old.ID == p.ID {
+				player.LastAction = old.LastAction
 			}
 		}
+		s.data.Players = append(s.data.Players, player)
 	}
+}
 
-	// Update players
-	if players, ok := payload["players"].([]interface{}); ok {
-		s.Players = make([]PlayerState, 0, len(players))
-		for _, player := range players {
-			if playerMap, ok := player.(map[string]interface{}); ok {
-				ps := PlayerState{}
-				if id, ok := playerMap["id"].(string); ok {
-					ps.ID = id
-				}
-				if nickname, ok := playerMap["nickname"].(string); ok {
-					ps.Nickname = nickname
-				}
-				if chips, ok := playerMap["chips"].(float64); ok {
-					ps.Chips = int(chips)
-				}
-				if bet, ok := playerMap["bet"].(float64); ok {
-					ps.Bet = int(bet)
-				}
-				if status, ok := playerMap["status"].(string); ok {
-					ps.Status = status
-				}
-				if position, ok := playerMap["position"].(float64); ok {
-					ps.Position = int(position)
-				}
-				s.Players = append(s.Players, ps)
-			}
+func (s *GameState) playerIndex(id string) int {
+	for i, p := range s.data.Players {
+		if p.ID == id {
+			return i
 		}
 	}
+	return -1
+}
 
-	// Update valid actions
-	if actions, ok := payload["validActions"].([]interface{}); ok {
-		s.ValidActions = make([]string, 0, len(actions))
-		for _, action := range actions {
-			if actionStr, ok := action.(string); ok {
-				s.ValidActions = append(s.ValidActions, actionStr)
-			}
-		}
+// winnerStates converts a hand result
+func winnerStates(winners []protocol.Winner) []WinnerState {
+	states := make([]WinnerState, 0, len(winners))
+	for _, w := range winners {
+		states = append(states, WinnerState{PlayerID: w.PlayerID, Amount: w.Amount, HandRank: w.HandRank})
 	}
+	return states
+}
+
+func actionLabel(action protocol.ClientMessageType, amount int) string {
+	if amount > 0 && action != protocol.ClientFold && action != protocol.ClientCheck {
+		return fmt.Sprintf("%s %d", action, amount)
+	}
+	return string(action)
+}
+
+func actionNames(actions []protocol.ClientMessageType) []string {
+	names := make([]string, 0, len(actions))
+	for _, a := range actions {
+		names = append(names, string(a))
+	}
+	return names
 }
 
 // GetSnapshot returns a thread-safe copy of the game state
-func (s *GameState) GetSnapshot() GameState {
+func (s *GameState) GetSnapshot() Snapshot {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	snapshot := GameState{
-		GameID:         s.GameID,
-		Round:          s.Round,
-		Pot:            s.Pot,
-		CurrentBet:     s.CurrentBet,
-		CurrentPlayer:  s.CurrentPlayer,
-		CommunityCards: make([]string, len(s.CommunityCards)),
-		Players:        make([]PlayerState, len(s.Players)),
-		ValidActions:   make([]string, len(s.ValidActions)),
-	}
+	return s.data.clone()
+}
+
+// clone returns a deep copy of the snapshot
+func (d Snapshot) clone() Snapshot {
+	c := d
+	c.CommunityCards = make([]string, len(d.CommunityCards))
+	c.Players = make([]PlayerState, len(d.Players))
+	c.ValidActions = make([]string, len(d.ValidActions))
 
-	copy(snapshot.CommunityCards, s.CommunityCards)
-	copy(snapshot.Players, s.Players)
-	copy(snapshot.ValidActions, s.ValidActions)
+	c.Winners = append([]WinnerState(nil), d.Winners...)
+
+	copy(c.CommunityCards, d.CommunityCards)
+	copy(c.Players, d.Players)
+	copy(c.ValidActions, d.ValidActions)
+	for i := range c.Players {
+		c.Players[i].HoleCards = append([]string(nil), d.Players[i].HoleCards...)
+	}
 
-	return snapshot
+	return c
 }
@@ -0,0 +1,203 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+)
+
+func TestGameState_UpdateFromTypedMessages(t *testing.T) {
+	s := NewGameState()
+
+	s.Update(protocol.NewServerMessage(protocol.GameStateUpdate{GameState: protocol.GameState{
+		GameID:       "game-1",
+		Round:        "PRE_FLOP",
+		Pot:          30,
+		CurrentBet:   20,
+		Players:      []protocol.PlayerInfo{{ID: "p1", Nickname: "Hero", Chips: 990, Bet: 10}},
+		ValidActions: []protocol.ClientMessageType{protocol.ClientCall},
+	}}))
+	s.Update(protocol.NewServerMessage(protocol.RoundProgressed{GameID: "game-1", Round: "FLOP", CommunityCards: []string{"♠A", "♥K", "♦7"}}))
+	s.Update(protocol.NewServerMessage(protocol.TurnChanged{GameID: "game-1", CurrentPlayer: "p1", ValidActions: []protocol.ClientMessageType{protocol.ClientCheck}}))
+
+	snap := s.GetSnapshot()
+	if snap.GameID != "game-1" || snap.Pot != 30 || snap.Round != "FLOP" || len(snap.CommunityCards) != 3 {
+		t.Fatalf("unexpected snapshot: %+v", snap)
+	}
+	if snap.CurrentPlayer != "p1" || len(snap.ValidActions) != 1 || snap.ValidActions[0] != "CHECK" {
+		t.Errorf("turn not applied: %+v", snap)
+	}
+	if len(snap.Players) != 1 || snap.Players[0].Chips != 990 {
+		t.Errorf("players not applied: %+v", snap.Players)
+	}
+
+	// Snapshots are independent copies
+	snap.Players[0].Chips = 0
+	if s.GetSnapshot().Players[0].Chips != 990 {
+		t.Error("snapshot shares memory with the state")
+	}
+}
+
+func TestGameState_OptimisticRollback(t *testing.T) {
+	s := NewGameState()
+	s.Update(protocol.NewServerMessage(protocol.GameStateUpdate{GameState: protocol.GameState{
+		GameID:        "game-1",
+		Round:         "FLOP",
+		Pot:           40,
+		CurrentBet:    20,
+		Players:       []protocol.PlayerInfo{{ID: "p1", Nickname: "Hero", Chips: 500}},
+		CurrentPlayer: "p1",
+		ValidActions:  []protocol.ClientMessageType{protocol.ClientFold, protocol.ClientCall, protocol.ClientRaise},
+	}}))
+
+	s.Optimistic("req-1", "p1", protocol.ClientRaise, 60)
+	snap := s.GetSnapshot()
+	if snap.Pot != 100 || snap.Players[0].Chips != 440 || snap.CurrentPlayer != "" || len(snap.ValidActions) != 0 {
+		t.Fatalf("raise not shown: %+v", snap)
+	}
+
+	if !s.Rollback("req-1") {
+		t.Fatal("expected rejected raise to roll back")
+	}
+	snap = s.GetSnapshot()
+	if snap.Pot != 40 || snap.Players[0].Chips != 500 || snap.CurrentPlayer != "p1" || len(snap.ValidActions) != 3 {
+		t.Errorf("state not restored: %+v", snap)
+	}
+
+	// A server update after the action wins over a late rejection
+	s.Optimistic("req-2", "p1", protocol.ClientCall, 0)
+	s.Update(protocol.NewServerMessage(protocol.TurnChanged{GameID: "game-1", CurrentPlayer: "p2"}))
+	if s.Rollback("req-2") || s.GetSnapshot().CurrentPlayer != "p2" {
+		t.Error("rollback should not undo newer server state")
+	}
+}
+
+func TestGameState_Spectators(t *testing.T) {
+	s := NewGameState()
+	s.Update(protocol.NewServerMessage(protocol.GameStateUpdate{GameState: protocol.GameState{GameID: "game-1", Round: "FLOP", Pot: 80}}))
+
+	s.Update(protocol.NewServerMessage(protocol.SpectateStarted{GameID: "game-2", Spectators: 1}))
+	if snap := s.GetSnapshot(); snap.GameID != "game-2" || snap.Pot != 0 || snap.Spectators != 1 {
+		t.Fatalf("expected a fresh state for the watched table, got %+v", snap)
+	}
+
+	s.Update(protocol.NewServerMessage(protocol.SpectatorsChanged{GameID: "game-1", Spectators: 9}))
+	s.Update(protocol.NewServerMessage(protocol.SpectatorsChanged{GameID: "game-2", Spectators: 4}))
+	s.Update(protocol.NewServerMessage(protocol.GameStarted{GameState: protocol.GameState{GameID: "game-2", Round: "PRE_FLOP"}}))
+	if snap := s.GetSnapshot(); snap.Spectators != 4 {
+		t.Errorf("expected 4 spectators from game-2 to survive a new game, got %d", snap.Spectators)
+	}
+}
+
+func TestGameState_HostActions(t *testing.T) {
+	s := NewGameState()
+	s.Update(protocol.NewServerMessage(protocol.GameStateUpdate{GameState: protocol.GameState{
+		GameID:        "game-1",
+		Round:         "FLOP",
+		Players:       []protocol.PlayerInfo{{ID: "p1", Nickname: "Hero", Chips: 500}},
+		CurrentPlayer: "p1",
+		ValidActions:  []protocol.ClientMessageType{protocol.ClientCheck},
+		HostID:        "p1",
+	}}))
+
+	s.Update(protocol.NewServerMessage(protocol.HostActed{GameID: "game-1", Action: protocol.ClientHostPause}))
+	if snap := s.GetSnapshot(); !snap.Paused || len(snap.ValidActions) != 0 || snap.HostID != "p1" {
+		t.Fatalf("pause not applied: %+v", snap)
+	}
+
+	s.Update(protocol.NewServerMessage(protocol.HostActed{GameID: "game-1", Action: protocol.ClientHostAdjustStack, PlayerID: "p1", Amount: 300, Chips: 800, Reason: "rebuy"}))
+	s.Update(protocol.NewServerMessage(protocol.HostActed{GameID: "game-1", Action: protocol.ClientHostResume}))
+	if snap := s.GetSnapshot(); snap.Paused || snap.Players[0].Chips != 800 {
+		t.Errorf("resume or adjustment not applied: %+v", snap)
+	}
+}
+
+func TestGameState_TurnDeadline(t *testing.T) {
+	s := NewGameState()
+	s.Update(protocol.NewServerMessage(protocol.GameStateUpdate{GameState: protocol.GameState{
+		GameID:       "game-1",
+		Round:        "PRE_FLOP",
+		Players:      []protocol.PlayerInfo{{ID: "p1", Nickname: "Hero"}, {ID: "p2", Nickname: "Villain"}},
+		TurnDeadline: 5000,
+	}}))
+	if s.GetSnapshot().TurnDeadline != 5000 {
+		t.Fatal("snapshot deadline not applied")
+	}
+
+	s.Update(protocol.NewServerMessage(protocol.TurnChanged{GameID: "game-1", CurrentPlayer: "p2", Deadline: 9000}))
+	s.Update(protocol.NewServerMessage(protocol.PlayerActed{GameID: "game-1", PlayerID: "p1", Action: protocol.ClientCall}))
+	if got := s.GetSnapshot().TurnDeadline; got != 9000 {
+		t.Errorf("another player's action moved the clock to %d", got)
+	}
+	s.Update(protocol.NewServerMessage(protocol.PlayerActed{GameID: "game-1", PlayerID: "p2", Action: protocol.ClientFold}))
+	if got := s.GetSnapshot().TurnDeadline; got != 0 {
+		t.Errorf("the clock should stop once the player acts, got %d", got)
+	}
+}
+
+func TestGameState_Sequence(t *testing.T) {
+	s := NewGameState()
+	numbered := func(seq int64, p protocol.ServerPayload) protocol.ServerMessage {
+		msg := protocol.NewServerMessage(p)
+		msg.Seq = seq
+		return msg
+	}
+	acted := func(seq int64, id string) protocol.ServerMessage {
+		return numbered(seq, protocol.PlayerActed{GameID: "game-1", PlayerID: id, Action: protocol.ClientCheck})
+	}
+	turn := func(seq int64, id string) protocol.ServerMessage {
+		return numbered(seq, protocol.TurnChanged{GameID: "game-1", CurrentPlayer: id})
+	}
+
+	if got := s.Update(numbered(1, protocol.GameStarted{GameState: protocol.GameState{
+		GameID:  "game-1",
+		Round:   "PRE_FLOP",
+		Players: []protocol.PlayerInfo{{ID: "p1", Nickname: "Hero"}, {ID: "p2", Nickname: "Villain"}},
+	}})); got != Applied {
+		t.Fatalf("GAME_STARTED = %v", got)
+	}
+	if got := s.Update(turn(2, "p1")); got != Applied || s.Seq() != 2 {
+		t.Fatalf("next update = %v at seq %d", got, s.Seq())
+	}
+	if got := s.Update(turn(2, "p1")); got != Duplicate {
+		t.Errorf("a repeated update = %v", got)
+	}
+
+	// 3 is lost: 4 opens a gap, 5 waits behind it
+	if got := s.Update(acted(4, "p2")); got != Gap || !s.Syncing() {
+		t.Fatalf("update past a gap = %v", got)
+	}
+	if got := s.Update(turn(5, "p1")); got != Held {
+		t.Errorf("second early update = %v", got)
+	}
+	if got := s.Update(turn(5, "p1")); got != Duplicate {
+		t.Errorf("a repeated early update = %v", got)
+	}
+	if snap := s.GetSnapshot(); snap.CurrentPlayer != "p1" || snap.Players[1].LastAction != "" {
+		t.Fatalf("held updates leaked into the table: %+v", snap)
+	}
+
+	// A late 3 lets the held updates through in order
+	if got := s.Update(turn(3, "p2")); got != Applied || s.Seq() != 5 || s.Syncing() {
+		t.Fatalf("missing update = %v, seq %d", got, s.Seq())
+	}
+	if snap := s.GetSnapshot(); snap.CurrentPlayer != "p1" || snap.Players[1].LastAction != "CHECK" {
+		t.Errorf("held updates not applied in order: %+v", snap)
+	}
+
+	// When 6 never arrives the snapshot as of 7 stands in for it
+	s.Update(acted(8, "p1"))
+	s.Update(numbered(7, protocol.GameStateUpdate{GameState: protocol.GameState{
+		GameID:        "game-1",
+		Round:         "FLOP",
+		CurrentPlayer: "p1",
+		Players:       []protocol.PlayerInfo{{ID: "p1", Nickname: "Hero"}, {ID: "p2", Nickname: "Villain"}},
+	}}))
+	if snap := s.GetSnapshot(); s.Seq() != 8 || s.Syncing() || snap.Round != "FLOP" || snap.Players[0].LastAction != "CHECK" {
+		t.Errorf("snapshot did not rebase the sequence: seq %d, %+v", s.Seq(), snap)
+	}
+	stale := numbered(6, protocol.GameStateUpdate{GameState: protocol.GameState{GameID: "game-1", Round: "PRE_FLOP"}})
+	if got := s.Update(stale); got != Duplicate || s.GetSnapshot().Round != "FLOP" {
+		t.Errorf("an older snapshot = %v", got)
+	}
+}
@@ -0,0 +1,78 @@
+package state
+
+import "github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+
+// optimisticAction remembers the state before a locally applied action
+type optimisticAction struct {
+	before  Snapshot
+	version int
+}
+
+// Optimistic shows the player's action before the server confirms it. The
+// request ID is later passed to Confirm or Rollback.
+func (s *GameState) Optimistic(requestID, playerID string, action protocol.ClientMessageType, amount int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pending == nil {
+		s.pending = make(map[string]optimisticAction)
+	}
+	s.pending[requestID] = optimisticAction{before: s.data.clone(), version: s.version}
+
+	for i := range s.data.Players {
+		p := &s.data.Players[i]
+		if p.ID != playerID {
+			continue
+		}
+
+		var commit int
+		switch action {
+		case protocol.ClientFold:
+			p.Status = "FOLDED"
+		case protocol.ClientCall:
+			commit = s.data.CurrentBet - p.Bet
+		case protocol.ClientRaise:
+			commit = amount - p.Bet
+		case protocol.ClientAllIn:
+			commit = p.Chips
+			p.Status = "ALL_IN"
+		}
+		commit = max(0, min(commit, p.Chips))
+
+		p.Chips -= commit
+		p.Bet += commit
+		s.data.Pot += commit
+		s.data.CurrentBet = max(s.data.CurrentBet, p.Bet)
+	}
+
+	// The turn has passed; wait for the server to name the next player
+	s.data.CurrentPlayer = ""
+	s.data.ValidActions = s.data.ValidActions[:0]
+}
+
+// Confirm keeps an optimistic action the server acknowledged
+func (s *GameState) Confirm(requestID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.pending, requestID)
+}
+
+// Rollback restores the state from before a rejected action. It reports
+// false when a server update has replaced the state since, in which case
+// the server's view already stands.
+func (s *GameState) Rollback(requestID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	action, ok := s.pending[requestID]
+	if !ok {
+		return false
+	}
+	delete(s.pending, requestID)
+
+	if action.version != s.version {
+		return false
+	}
+	s.data = action.before
+	return true
+}
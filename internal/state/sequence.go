@@ -0,0 +1,135 @@
+package state
+
+import (
+	"github.com/bunnyholes/pokerhole/client/internal/network"
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+)
+
+// maxHeld is how many early updates are held before the snapshot is asked
+// for again, in case the request or its answer was lost
+const maxHeld = 16
+
+// Outcome is what Update did with a server message
+type Outcome int
+
+const (
+	// Applied messages changed the state, along with any held updates
+	// they were the missing link for
+	Applied Outcome = iota
+	// Duplicate messages were already applied and are ignored
+	Duplicate
+	// Held messages arrived ahead of a missing update and wait for it
+	Held
+	// Gap is Held for a message that found updates missing; the caller
+	// should ask the server for a full snapshot
+	Gap
+)
+
+// Update applies a server message in sequence order. Numbered updates are
+// applied exactly once and never past a missing one; a snapshot replaces
+// the state as of its number and releases the updates held after it.
+// Messages without a number are applied as they arrive.
+func (s *GameState) Update(msg network.ServerMessage) Outcome {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch p := msg.Payload.(type) {
+	case protocol.GameStarted:
+		if msg.Seq > 0 && msg.Seq <= s.seq && p.GameID == s.data.GameID {
+			return Duplicate
+		}
+		// A new game starts its own numbering
+		s.resequence(msg.Seq)
+		s.applyMessage(msg)
+		return Applied
+	case protocol.SpectateStarted:
+		s.resequence(0)
+		s.applyMessage(msg)
+		return Applied
+	}
+
+	switch {
+	case msg.Seq == 0:
+		s.applyMessage(msg)
+		return Applied
+	case isSnapshot(msg):
+		if msg.Seq < s.seq {
+			return Duplicate
+		}
+	case s.seq == 0 || msg.Seq == s.seq+1:
+		// The first numbered update sets where the count starts
+	case msg.Seq <= s.seq:
+		return Duplicate
+	default:
+		return s.hold(msg)
+	}
+
+	s.applyMessage(msg)
+	s.seq = msg.Seq
+	s.release()
+	return Applied
+}
+
+// Seq returns the number of the last update applied
+func (s *GameState) Seq() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.seq
+}
+
+// Syncing reports whether updates are missing and the state is waiting
+// for them or for a snapshot
+func (s *GameState) Syncing() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.syncing
+}
+
+func isSnapshot(msg network.ServerMessage) bool {
+	_, ok := msg.Payload.(protocol.GameStateUpdate)
+	return ok
+}
+
+// resequence forgets the numbering of the previous game
+func (s *GameState) resequence(seq int64) {
+	s.seq = seq
+	clear(s.held)
+	s.syncing = false
+}
+
+// hold keeps an early update until the ones before it arrive
+func (s *GameState) hold(msg network.ServerMessage) Outcome {
+	if _, ok := s.held[msg.Seq]; ok {
+		return Duplicate
+	}
+	if s.held == nil {
+		s.held = make(map[int64]network.ServerMessage)
+	}
+	s.held[msg.Seq] = msg
+
+	if !s.syncing || len(s.held)%maxHeld == 0 {
+		s.syncing = true
+		return Gap
+	}
+	return Held
+}
+
+// release applies the held updates that now follow on, and drops those a
+// snapshot already covered
+func (s *GameState) release() {
+	for {
+		msg, ok := s.held[s.seq+1]
+		if !ok {
+			break
+		}
+		delete(s.held, msg.Seq)
+		s.applyMessage(msg)
+		s.seq = msg.Seq
+	}
+	for seq := range s.held {
+		if seq <= s.seq {
+			delete(s.held, seq)
+		}
+	}
+	s.syncing = len(s.held) > 0
+}
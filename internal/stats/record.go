@@ -0,0 +1,48 @@
+// Package stats records completed hands and summarizes them for the dashboard
+package stats
+
+import "time"
+
+// Result represents the outcome of a hand from the player's point of view
+type Result string
+
+const (
+	ResultWin   Result = "WIN"
+	ResultLoss  Result = "LOSS"
+	ResultSplit Result = "SPLIT"
+	ResultPush  Result = "PUSH"
+)
+
+// HandRecord is a single completed hand as persisted in the history file
+type HandRecord struct {
+	SessionID  string    `json:"sessionId"`
+	HandNumber int       `json:"handNumber"`
+	PlayedAt   time.Time `json:"playedAt"`
+	Mode       string    `json:"mode"` // session mode: practice, scored or tournament
+	Position   string    `json:"position"`
+	Pot        int       `json:"pot"`
+	StartChips int       `json:"startChips"`
+	EndChips   int       `json:"endChips"`
+	HandRank   string    `json:"handRank,omitempty"`
+	Result     Result    `json:"result"`
+}
+
+// Net returns the chips won (positive) or lost (negative) in the hand
+func (r HandRecord) Net() int {
+	return r.EndChips - r.StartChips
+}
+
+// ResultFor derives the hand result from the net chip change and whether the
+// player was among the winners
+func ResultFor(net int, winner bool, winners int) Result {
+	switch {
+	case winner && winners > 1:
+		return ResultSplit
+	case winner:
+		return ResultWin
+	case net < 0:
+		return ResultLoss
+	default:
+		return ResultPush
+	}
+}
@@ -0,0 +1,115 @@
+package stats
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func record(session string, hand int, position string, start, end, pot int, result Result) HandRecord {
+	return HandRecord{
+		SessionID:  session,
+		HandNumber: hand,
+		PlayedAt:   time.Unix(int64(hand), 0),
+		Mode:       "offline",
+		Position:   position,
+		Pot:        pot,
+		StartChips: start,
+		EndChips:   end,
+		Result:     result,
+	}
+}
+
+func TestStore_AppendAndLoad(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "nested", "hands.jsonl"))
+
+	records, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load on missing file failed: %v", err)
+	}
+	if len(records) != 0 {
+		t.Fatalf("Expected no records, got %d", len(records))
+	}
+
+	first := record("s1", 1, "SB", 1000, 1040, 80, ResultWin)
+	second := record("s1", 2, "BB", 1040, 900, 280, ResultLoss)
+	if err := store.Append(first); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if err := store.Append(second); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+
+	records, err = store.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	if records[1].Net() != -140 {
+		t.Errorf("Expected net -140, got %d", records[1].Net())
+	}
+}
+
+func TestResultFor(t *testing.T) {
+	cases := []struct {
+		net     int
+		winner  bool
+		winners int
+		want    Result
+	}{
+		{40, true, 1, ResultWin},
+		{0, true, 2, ResultSplit},
+		{-20, false, 1, ResultLoss},
+		{0, false, 1, ResultPush},
+	}
+
+	for _, tc := range cases {
+		if got := ResultFor(tc.net, tc.winner, tc.winners); got != tc.want {
+			t.Errorf("ResultFor(%d, %v, %d) = %s, want %s", tc.net, tc.winner, tc.winners, got, tc.want)
+		}
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	records := []HandRecord{
+		record("old", 1, "SB", 1000, 1300, 600, ResultWin),
+		record("old", 2, "BB", 1300, 1100, 1600, ResultLoss),
+		record("cur", 1, "SB", 1000, 1020, 40, ResultWin),
+		record("cur", 2, "BB", 1020, 1020, 40, ResultSplit),
+		record("cur", 3, "SB", 1020, 500, 1040, ResultLoss),
+		record("cur", 4, "BB", 500, 700, 1200, ResultWin),
+	}
+
+	d := Summarize(records, "cur", 2)
+
+	if d.Lifetime.Hands != 6 || d.Lifetime.Wins != 3 || d.Lifetime.Losses != 2 || d.Lifetime.Splits != 1 {
+		t.Errorf("Unexpected lifetime totals: %+v", d.Lifetime)
+	}
+	if d.Lifetime.Net != -200 {
+		t.Errorf("Expected lifetime net -200, got %d", d.Lifetime.Net)
+	}
+	if d.Session.Hands != 4 || d.Session.Net != -300 {
+		t.Errorf("Unexpected session totals: %+v", d.Session)
+	}
+
+	wantBankroll := []int{300, 100, 120, 120, -400, -200}
+	for i, v := range wantBankroll {
+		if d.Bankroll[i] != v {
+			t.Errorf("Bankroll[%d] = %d, want %d", i, d.Bankroll[i], v)
+		}
+	}
+
+	// Ranked by the size of the pot, not by the chips won or lost in it
+	if len(d.BiggestWon) != 2 || d.BiggestWon[0].Pot != 1200 || d.BiggestWon[1].Pot != 600 {
+		t.Errorf("Unexpected biggest won: %+v", d.BiggestWon)
+	}
+	if len(d.BiggestLost) != 2 || d.BiggestLost[0].Pot != 1600 || d.BiggestLost[1].Pot != 1040 {
+		t.Errorf("Unexpected biggest lost: %+v", d.BiggestLost)
+	}
+
+	if len(d.ByPosition) != 2 || d.ByPosition[0].Position != "BB" || d.ByPosition[1].Hands != 3 {
+		t.Errorf("Unexpected position breakdown: %+v", d.ByPosition)
+	}
+}
@@ -0,0 +1,88 @@
+package stats
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store persists completed hands as JSON lines in a local file
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewStore creates a Store backed by the given file path
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// DefaultPath returns the history file location (~/.pokerhole/hands.jsonl)
+func DefaultPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".pokerhole", "hands.jsonl"), nil
+}
+
+// Path returns the backing file path
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Append writes a completed hand to the end of the history file
+func (s *Store) Append(record HandRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// Load reads every recorded hand in the order it was played.
+// A missing file is not an error; malformed lines are skipped.
+func (s *Store) Load() ([]HandRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history: %w", err)
+	}
+	defer f.Close()
+
+	var records []HandRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record HandRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+
+	return records, scanner.Err()
+}
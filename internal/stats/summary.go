@@ -0,0 +1,103 @@
+package stats
+
+import "sort"
+
+// Totals aggregates results over a set of hands
+type Totals struct {
+	Hands  int
+	Wins   int
+	Losses int
+	Splits int
+	Net    int
+}
+
+// WinRate returns the share of hands won (splits count as half)
+func (t Totals) WinRate() float64 {
+	if t.Hands == 0 {
+		return 0
+	}
+	return (float64(t.Wins) + float64(t.Splits)/2) / float64(t.Hands)
+}
+
+func (t *Totals) add(r HandRecord) {
+	t.Hands++
+	t.Net += r.Net()
+	switch r.Result {
+	case ResultWin:
+		t.Wins++
+	case ResultLoss:
+		t.Losses++
+	case ResultSplit:
+		t.Splits++
+	}
+}
+
+// PositionStats aggregates results for one table position
+type PositionStats struct {
+	Position string
+	Totals
+}
+
+// Dashboard is the summary rendered by the statistics screen
+type Dashboard struct {
+	Lifetime    Totals
+	Session     Totals
+	Bankroll    []int        // cumulative net chips after each hand
+	BiggestWon  []HandRecord // largest pots the player came out ahead in
+	BiggestLost []HandRecord // largest pots the player came out behind in
+	ByPosition  []PositionStats
+}
+
+// Summarize builds the dashboard from recorded hands.
+// sessionID selects the hands counted as the current session.
+func Summarize(records []HandRecord, sessionID string, top int) Dashboard {
+	var d Dashboard
+
+	positions := map[string]*PositionStats{}
+	var order []string
+	running := 0
+
+	for _, r := range records {
+		d.Lifetime.add(r)
+		if sessionID != "" && r.SessionID == sessionID {
+			d.Session.add(r)
+		}
+
+		running += r.Net()
+		d.Bankroll = append(d.Bankroll, running)
+
+		ps, ok := positions[r.Position]
+		if !ok {
+			ps = &PositionStats{Position: r.Position}
+			positions[r.Position] = ps
+			order = append(order, r.Position)
+		}
+		ps.add(r)
+
+		if r.Net() > 0 {
+			d.BiggestWon = append(d.BiggestWon, r)
+		} else if r.Net() < 0 {
+			d.BiggestLost = append(d.BiggestLost, r)
+		}
+	}
+
+	sort.SliceStable(d.BiggestWon, func(i, j int) bool {
+		return d.BiggestWon[i].Pot > d.BiggestWon[j].Pot
+	})
+	sort.SliceStable(d.BiggestLost, func(i, j int) bool {
+		return d.BiggestLost[i].Pot > d.BiggestLost[j].Pot
+	})
+	if len(d.BiggestWon) > top {
+		d.BiggestWon = d.BiggestWon[:top]
+	}
+	if len(d.BiggestLost) > top {
+		d.BiggestLost = d.BiggestLost[:top]
+	}
+
+	sort.Strings(order)
+	for _, pos := range order {
+		d.ByPosition = append(d.ByPosition, *positions[pos])
+	}
+
+	return d
+}
@@ -0,0 +1,272 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/bunnyholes/pokerhole/client/internal/network"
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+	"github.com/bunnyholes/pokerhole/client/internal/state"
+)
+
+const (
+	chatHistoryLimit = 200 // scrollback kept per session
+	chatMessageLimit = 200
+	chatMinLines     = 2
+	chatPaneChrome   = 3 // border plus the composer row
+)
+
+var (
+	chatPanelStyle = onlinePanelStyle.Copy()
+	chatMutedStyle = lipgloss.NewStyle().Foreground(ColorTextMuted)
+)
+
+// chatLine is one received table chat message.
+type chatLine struct {
+	at       time.Time
+	playerID string
+	nickname string
+	text     string
+}
+
+// chatState holds the table chat pane.
+type chatState struct {
+	open      bool
+	composing bool
+	input     textinput.Model
+	view      viewport.Model
+	lines     []chatLine
+	muted     map[string]bool // player IDs whose messages are hidden
+	unread    int
+}
+
+func newChatState() chatState {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = "메시지 입력 · /mute 닉네임"
+	input.CharLimit = chatMessageLimit
+	input.Width = 64
+
+	return chatState{input: input, view: viewport.New(0, chatMinLines)}
+}
+
+// toggleChat opens or closes the chat pane.
+func (m Model) toggleChat() (tea.Model, tea.Cmd) {
+	if !m.chat.open && m.serverLacks(protocol.CapChat) {
+		m = m.withStatus(statusWarning, "이 서버는 채팅을 지원하지 않습니다.", 3*time.Second)
+		return m, m.statusCommand(3 * time.Second)
+	}
+	m.chat.open = !m.chat.open
+	m.chat.composing = false
+	m.chat.input.Blur()
+	if m.chat.open {
+		m.chat.unread = 0
+		m = m.refreshChat()
+		m.chat.view.GotoBottom()
+	}
+	return m, nil
+}
+
+// composeChat focuses the chat input; game keys are ignored until it closes.
+func (m Model) composeChat() (tea.Model, tea.Cmd) {
+	if !m.chat.open {
+		return m, nil
+	}
+	m.chat.composing = true
+	return m, m.chat.input.Focus()
+}
+
+func (m Model) handleChatKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.chat.composing = false
+		m.chat.input.Blur()
+		return m, nil
+	case tea.KeyEnter:
+		return m.submitChat()
+	case tea.KeyPgUp, tea.KeyPgDown:
+		return m.scrollChat(msg)
+	}
+
+	var cmd tea.Cmd
+	m.chat.input, cmd = m.chat.input.Update(msg)
+	return m, cmd
+}
+
+func (m Model) scrollChat(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.Type == tea.KeyPgUp {
+		m.chat.view.HalfPageUp()
+	} else {
+		m.chat.view.HalfPageDown()
+	}
+	return m, nil
+}
+
+// submitChat sends the composed message or runs a /mute command.
+func (m Model) submitChat() (tea.Model, tea.Cmd) {
+	text := strings.TrimSpace(sanitizeText(m.chat.input.Value()))
+	m.chat.input.Reset()
+	m.chat.composing = false
+	m.chat.input.Blur()
+
+	if text == "" {
+		return m, nil
+	}
+	if cmd, name, ok := strings.Cut(text, " "); ok && (cmd == "/mute" || cmd == "/unmute") {
+		return m.setMuted(strings.TrimSpace(name), cmd == "/mute")
+	}
+
+	if m.client == nil {
+		return m, nil
+	}
+	if err := m.client.SendPayload(protocol.SendChat{Message: text}); err != nil {
+		m = m.withStatus(statusError, fmt.Sprintf("채팅 전송 실패: %v", err), 4*time.Second)
+		return m, m.statusCommand(4 * time.Second)
+	}
+	return m, nil
+}
+
+// setMuted hides or shows a player's chat by nickname.
+func (m Model) setMuted(name string, muted bool) (tea.Model, tea.Cmd) {
+	id, ok := m.chatPlayerID(name)
+	switch {
+	case !ok:
+		m = m.withStatus(statusWarning, fmt.Sprintf("%s 플레이어를 찾을 수 없습니다.", name), 3*time.Second)
+		return m, m.statusCommand(3 * time.Second)
+	case m.client != nil && id == m.client.UUID():
+		m = m.withStatus(statusWarning, "자기 자신은 음소거할 수 없습니다.", 3*time.Second)
+		return m, m.statusCommand(3 * time.Second)
+	}
+
+	// Copy so earlier Model values keep their own mute list
+	set := maps.Clone(m.chat.muted)
+	if set == nil {
+		set = map[string]bool{}
+	}
+	if muted {
+		set[id] = true
+		m = m.withStatus(statusInfo, fmt.Sprintf("%s 님을 음소거했습니다.", name), 3*time.Second)
+	} else {
+		delete(set, id)
+		m = m.withStatus(statusInfo, fmt.Sprintf("%s 님의 음소거를 해제했습니다.", name), 3*time.Second)
+	}
+	m.chat.muted = set
+	return m.refreshChat(), m.statusCommand(3 * time.Second)
+}
+
+// chatPlayerID finds a player by nickname at the table or in the chat log.
+func (m Model) chatPlayerID(name string) (string, bool) {
+	for _, p := range m.table.GetSnapshot().Players {
+		if strings.EqualFold(sanitizeText(p.Nickname), name) {
+			return p.ID, true
+		}
+	}
+	for i := len(m.chat.lines) - 1; i >= 0; i-- {
+		if strings.EqualFold(m.chat.lines[i].nickname, name) {
+			return m.chat.lines[i].playerID, true
+		}
+	}
+	return "", false
+}
+
+// receiveChat stores an incoming message for the pane.
+func (m Model) receiveChat(msg network.ServerMessage) Model {
+	p, ok := msg.Payload.(protocol.ChatReceived)
+	if !ok {
+		return m
+	}
+
+	at := time.Now()
+	if msg.Timestamp > 0 {
+		at = time.UnixMilli(msg.Timestamp)
+	}
+	m.chat.lines = append(m.chat.lines, chatLine{
+		at:       at,
+		playerID: p.PlayerID,
+		nickname: sanitizeText(p.Nickname),
+		text:     sanitizeText(p.Message),
+	})
+	if n := len(m.chat.lines); n > chatHistoryLimit {
+		m.chat.lines = m.chat.lines[n-chatHistoryLimit:]
+	}
+	if !m.chat.open && !m.chat.muted[p.PlayerID] {
+		m.chat.unread++
+	}
+	return m.refreshChat()
+}
+
+// refreshChat sizes the scrollback and follows new messages when the
+// reader is already at the bottom.
+func (m Model) refreshChat() Model {
+	follow := m.chat.view.AtBottom()
+	m.chat.view.Width = m.contentWidth() - chatPanelStyle.GetHorizontalFrameSize()
+	m.chat.view.Height = m.chatViewportHeight(m.table.GetSnapshot())
+
+	nameStyle := lipgloss.NewStyle().Foreground(ColorAccentGold).Bold(true)
+	timeStyle := lipgloss.NewStyle().Foreground(ColorTextMuted)
+	wrap := lipgloss.NewStyle().Width(m.chat.view.Width)
+
+	var rows []string
+	for _, line := range m.chat.lines {
+		if m.chat.muted[line.playerID] {
+			continue
+		}
+		rows = append(rows, wrap.Render(fmt.Sprintf("%s %s %s",
+			timeStyle.Render(line.at.Format("15:04")),
+			nameStyle.Render(line.nickname+":"),
+			line.text)))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, chatMutedStyle.Render("아직 채팅이 없습니다."))
+	}
+
+	m.chat.view.SetContent(strings.Join(rows, "\n"))
+	if follow {
+		m.chat.view.GotoBottom()
+	}
+	return m
+}
+
+// chatViewportHeight gives the scrollback whatever rows the table leaves.
+func (m Model) chatViewportHeight(snap state.Snapshot) int {
+	used := 2 + lipgloss.Height(m.renderOnlineTail(snap)) + chatPaneChrome
+	return max(chatMinLines, m.contentHeight()-1-used)
+}
+
+func (m Model) renderChatPane(snap state.Snapshot) string {
+	footer := helpKeyStyle.Render("[Enter]") + " 입력  " +
+		helpKeyStyle.Render("[PgUp/PgDn]") + " 스크롤  " +
+		helpKeyStyle.Render("[T]") + " 닫기"
+	if n := len(m.chat.muted); n > 0 {
+		footer += chatMutedStyle.Render(fmt.Sprintf("  음소거 %d명", n))
+	}
+	if m.chat.composing {
+		footer = m.chat.input.View()
+	}
+
+	// The seats may have changed since the last refresh
+	view := m.chat.view
+	view.Height = m.chatViewportHeight(snap)
+	if m.chat.view.AtBottom() {
+		view.GotoBottom()
+	}
+
+	return chatPanelStyle.Width(m.contentWidth() - chatPanelStyle.GetHorizontalBorderSize()).
+		Render(view.View() + "\n" + footer)
+}
+
+// chatHint labels the chat toggle with the unread count.
+func (m Model) chatHint() string {
+	hint := helpKeyStyle.Render("[T]") + " 채팅"
+	if m.chat.unread > 0 {
+		hint += lipgloss.NewStyle().Foreground(ColorAccentGold).Bold(true).Render(fmt.Sprintf(" %d", m.chat.unread))
+	}
+	return hint
+}
@@ -0,0 +1,286 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/bunnyholes/pokerhole/client/internal/network"
+)
+
+// connectTimeout bounds a connection attempt started from the UI
+const connectTimeout = 3 * time.Second
+
+// connectionState mirrors the client's connection state machine.
+type connectionState struct {
+	state     network.ConnState
+	attempt   int
+	nextRetry time.Duration
+	err       error
+	input     textinput.Model
+	quality   network.LinkQuality
+}
+
+// connStateMsg delivers a connection state change from the client.
+type connStateMsg struct {
+	Change network.StateChange
+}
+
+// listenForConnState waits for the next connection state change.
+func listenForConnState(client *network.Client) tea.Cmd {
+	if client == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		change, ok := <-client.States()
+		if !ok {
+			return nil
+		}
+		return connStateMsg{Change: change}
+	}
+}
+
+// linkQualityMsg delivers a heartbeat measurement from the client.
+type linkQualityMsg struct {
+	Quality network.LinkQuality
+}
+
+// listenForQuality waits for the next connection quality update.
+func listenForQuality(client *network.Client) tea.Cmd {
+	if client == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		q, ok := <-client.Qualities()
+		if !ok {
+			return nil
+		}
+		return linkQualityMsg{Quality: q}
+	}
+}
+
+// connectCmd dials the server in the background; progress arrives as
+// connStateMsg through listenForConnState.
+func connectCmd(client *network.Client) tea.Cmd {
+	if client == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		if err := client.ConnectWithTimeout(connectTimeout); err != nil && !errors.Is(err, network.ErrConnectInProgress) {
+			return connStateMsg{Change: network.StateChange{State: network.StateFailed, Err: err}}
+		}
+		return nil
+	}
+}
+
+// isOnline reports whether the client is connected to the server.
+func (m Model) isOnline() bool {
+	return m.conn.state == network.StateConnected
+}
+
+// serverLacks reports whether the server left capability out of what it
+// declared at registration; nothing is ruled out before then.
+func (m Model) serverLacks(capability string) bool {
+	return m.client != nil && m.client.ServerVersion() > 0 && !m.client.Supports(capability)
+}
+
+func (m Model) handleConnState(msg connStateMsg) (tea.Model, tea.Cmd) {
+	change := msg.Change
+	previous := m.conn.state
+
+	m.conn.state = change.State
+	m.conn.attempt = change.Attempt
+	m.conn.nextRetry = change.NextRetry
+	m.conn.err = change.Err
+	if change.State != network.StateConnected {
+		m.conn.quality = network.LinkQuality{}
+	}
+	m.home.items = m.buildHomeMenu()
+
+	var cmd tea.Cmd
+	var upgrade *network.UpgradeError
+	switch {
+	case change.State == network.StateFailed && errors.As(change.Err, &upgrade):
+		m = m.openUpgrade(upgrade)
+		m = m.withStatus(statusError, "클라이언트 업데이트가 필요합니다.", 5*time.Second)
+		cmd = m.statusCommand(5 * time.Second)
+	case change.State == network.StateConnected && m.lan.pending != nil:
+		m, cmd = m.openHostedRoom()
+	case change.State == network.StateConnected && m.lan.joining != "":
+		m, cmd = m.joinDiscoveredRoom()
+	case change.State == network.StateConnected && previous != network.StateConnected:
+		m = m.withStatus(statusSuccess, "서버에 연결되었습니다.", 3*time.Second)
+		cmd = m.statusCommand(3 * time.Second)
+	case change.State == network.StateFailed && m.hosting():
+		m, cmd = m.stopHosting("LAN 테이블에 연결하지 못해 호스팅을 종료했습니다.")
+	case change.State == network.StateFailed && m.lan.joining != "":
+		m.lan.joining = ""
+		m = m.abandonMatching()
+		m = m.withStatus(statusError, "LAN 테이블에 연결하지 못했습니다.", 5*time.Second)
+		cmd = m.statusCommand(5 * time.Second)
+	case change.State == network.StateReconnecting && change.Attempt == 1:
+		m = m.withStatus(statusWarning, "서버 연결이 끊겼습니다. 재연결 중...", 4*time.Second)
+		m = m.abandonMatching()
+		m = m.abandonSpectating()
+		cmd = m.statusCommand(4 * time.Second)
+	case change.State == network.StateFailed && previous != network.StateFailed:
+		m = m.abandonMatching()
+		m = m.abandonSpectating()
+		m = m.withStatus(statusError, "서버 연결 실패 - [서버 연결]에서 다시 시도하세요.", 5*time.Second)
+		cmd = m.statusCommand(5 * time.Second)
+	}
+
+	return m, tea.Batch(listenForConnState(m.client), cmd)
+}
+
+// handleLinkQuality keeps the latest measurement and warns once when the
+// connection turns bad, and once more when it recovers.
+func (m Model) handleLinkQuality(msg linkQualityMsg) (tea.Model, tea.Cmd) {
+	previous := m.conn.quality
+	q := msg.Quality
+	m.conn.quality = q
+
+	var cmd tea.Cmd
+	switch {
+	case !m.isOnline():
+	case q.Degraded() && !previous.Degraded():
+		text := fmt.Sprintf("연결 상태가 불안정합니다 · 지연 %dms", q.RTT.Milliseconds())
+		if q.Missed >= 2 {
+			text = "서버 응답이 늦어지고 있습니다. 연결을 확인하세요."
+		}
+		m = m.withStatus(statusWarning, text, 4*time.Second)
+		cmd = m.statusCommand(4 * time.Second)
+	case previous.Degraded() && !q.Degraded():
+		m = m.withStatus(statusInfo, "연결 상태가 회복되었습니다.", 3*time.Second)
+		cmd = m.statusCommand(3 * time.Second)
+	}
+	return m, tea.Batch(listenForQuality(m.client), cmd)
+}
+
+// renderLatency shows the measured round trip, or nothing before the
+// first echo arrives.
+func (m Model) renderLatency() string {
+	q := m.conn.quality
+	if !m.isOnline() || (!q.Measured() && q.Missed < 2) {
+		return ""
+	}
+
+	color := ColorAccentGreen
+	text := fmt.Sprintf("%dms", q.RTT.Milliseconds())
+	switch {
+	case q.Missed >= 2:
+		color, text = ColorError, "응답 없음"
+	case q.Degraded():
+		color = ColorWarning
+	case q.RTT >= 150*time.Millisecond:
+		color = ColorAccentGold
+	}
+	return lipgloss.NewStyle().Foreground(color).Render("● " + text)
+}
+
+// openConnectModal asks for the server URL before connecting.
+func (m Model) openConnectModal() (tea.Model, tea.Cmd) {
+	if m.client == nil {
+		m = m.withStatus(statusError, "네트워크 클라이언트가 없습니다.", 4*time.Second)
+		return m, m.statusCommand(4 * time.Second)
+	}
+
+	switch m.conn.state {
+	case network.StateConnected:
+		m = m.withStatus(statusInfo, "이미 서버에 연결되어 있습니다.", 3*time.Second)
+		return m, m.statusCommand(3 * time.Second)
+	case network.StateConnecting, network.StateReconnecting:
+		m = m.withStatus(statusInfo, "연결을 시도하는 중입니다.", 3*time.Second)
+		return m, m.statusCommand(3 * time.Second)
+	}
+
+	input := textinput.New()
+	input.Prompt = "URL  "
+	input.CharLimit = 200
+	input.Width = 52
+	input.SetValue(m.client.ServerURL())
+	input.CursorEnd()
+	m.conn.input = input
+	m.modal = modalConnect
+	return m, m.conn.input.Focus()
+}
+
+func (m Model) handleConnectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.modal = modalNone
+		return m, nil
+	case tea.KeyEnter:
+		url := strings.TrimSpace(m.conn.input.Value())
+		if !strings.HasPrefix(url, "ws://") && !strings.HasPrefix(url, "wss://") {
+			m = m.withStatus(statusWarning, "ws:// 또는 wss:// 로 시작하는 주소를 입력하세요.", 4*time.Second)
+			return m, m.statusCommand(4 * time.Second)
+		}
+		if err := m.client.SetServerURL(url); err != nil {
+			m = m.withStatus(statusWarning, fmt.Sprintf("주소를 바꿀 수 없습니다: %v", err), 4*time.Second)
+			return m, m.statusCommand(4 * time.Second)
+		}
+
+		m.modal = modalNone
+		m.conn.state = network.StateConnecting
+		m.home.items = m.buildHomeMenu()
+		m = m.withStatus(statusInfo, "서버에 연결하는 중: "+url, 3*time.Second)
+		return m, tea.Batch(connectCmd(m.client), m.statusCommand(3*time.Second))
+	}
+
+	var cmd tea.Cmd
+	m.conn.input, cmd = m.conn.input.Update(msg)
+	return m, cmd
+}
+
+func (m Model) renderConnectModal() string {
+	width := minInt(68, m.contentWidth()-4)
+	header := headerTitleStyle.Width(width).Render("서버 연결")
+
+	lines := []string{
+		"접속할 서버 주소를 입력하세요.",
+		"",
+		m.conn.input.View(),
+	}
+	if m.conn.err != nil {
+		lines = append(lines, "", lipgloss.NewStyle().Foreground(ColorError).Render("마지막 오류: "+truncate(m.conn.err.Error(), width-12)))
+	}
+	lines = append(lines, "", menuDescStyle.Render("[Enter] 연결  •  [ESC] 취소"))
+
+	return panelEmphasisStyle.Width(width).Render(header + "\n\n" + strings.Join(lines, "\n"))
+}
+
+// connectionBadge returns the badge label, color and note for the state.
+func (m Model) connectionBadge() (string, lipgloss.TerminalColor, string) {
+	switch m.conn.state {
+	case network.StateConnecting:
+		return "CONNECTING", ColorInfo, "연결 중..."
+	case network.StateConnected:
+		return "ONLINE", ColorAccentGreen, "연결됨"
+	case network.StateReconnecting:
+		return "RECONNECTING", ColorWarning, fmt.Sprintf("%d회 · %.1fs", m.conn.attempt, m.conn.nextRetry.Seconds())
+	case network.StateFailed:
+		return "FAILED", ColorError, "연결 실패"
+	default:
+		return "OFFLINE", ColorWarning, "오프라인 연습 모드"
+	}
+}
+
+func truncate(s string, width int) string {
+	if width <= 0 || lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
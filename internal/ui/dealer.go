@@ -0,0 +1,316 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/bunnyholes/pokerhole/client/internal/network"
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+	"github.com/bunnyholes/pokerhole/client/internal/state"
+)
+
+const (
+	dealerLogLimit = 50 // host actions kept for the audit list
+	dealerLogShown = 4
+)
+
+// dealerEdit is the value the host panel input is collecting.
+type dealerEdit int
+
+const (
+	dealerEditNone dealerEdit = iota
+	dealerEditStack
+	dealerEditBlinds
+)
+
+// dealerState holds the host tools of a private table.
+type dealerState struct {
+	requests []protocol.SeatRequested // joins waiting for our decision
+	log      []protocol.HostActed     // host actions announced at the table
+	sitOut   map[string]bool          // players benched from the next hand
+	selected int
+	edit     dealerEdit
+	input    textinput.Model
+}
+
+// isHost reports whether the server gave us the host tools.
+func (m Model) isHost(snap state.Snapshot) bool {
+	return m.client != nil && snap.HostID != "" && snap.HostID == m.client.UUID()
+}
+
+// openDealerPanel shows the host tools over the table.
+func (m Model) openDealerPanel() (tea.Model, tea.Cmd) {
+	snap := m.table.GetSnapshot()
+	if !m.isHost(snap) {
+		m = m.withStatus(statusWarning, "방장만 사용할 수 있습니다.", 2*time.Second)
+		return m, m.statusCommand(2 * time.Second)
+	}
+	m.dealer.selected = min(m.dealer.selected, max(len(snap.Players)-1, 0))
+	m.dealer.edit = dealerEditNone
+	m.modal = modalDealer
+	return m, nil
+}
+
+func (m Model) handleDealerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.dealer.edit != dealerEditNone {
+		return m.handleDealerInput(msg)
+	}
+
+	snap := m.table.GetSnapshot()
+	var target state.PlayerState
+	hasTarget := m.dealer.selected < len(snap.Players)
+	if hasTarget {
+		target = snap.Players[m.dealer.selected]
+	}
+
+	switch msg.String() {
+	case "esc", "p", "P":
+		m.modal = modalNone
+		return m, nil
+	case "up":
+		if m.dealer.selected > 0 {
+			m.dealer.selected--
+		}
+		return m, nil
+	case "down":
+		if m.dealer.selected < len(snap.Players)-1 {
+			m.dealer.selected++
+		}
+		return m, nil
+	case " ":
+		if snap.Paused {
+			return m.sendHostCommand(protocol.HostResume{})
+		}
+		return m.sendHostCommand(protocol.HostPause{})
+	case "b", "B":
+		return m.editDealerValue(dealerEditBlinds, "블라인드 ", "25/50")
+	}
+
+	if !hasTarget {
+		return m, nil
+	}
+	switch msg.String() {
+	case "k", "K":
+		return m.sendHostCommand(protocol.HostKick{PlayerID: target.ID})
+	case "s", "S":
+		return m.sendHostCommand(protocol.HostSitOut{PlayerID: target.ID, SitOut: !m.dealer.sitOut[target.ID]})
+	case "c", "C":
+		return m.editDealerValue(dealerEditStack, "칩 조정 ", "+500 리바이")
+	}
+	return m, nil
+}
+
+// editDealerValue starts typing a stack adjustment or new blinds.
+func (m Model) editDealerValue(edit dealerEdit, prompt, placeholder string) (tea.Model, tea.Cmd) {
+	input := textinput.New()
+	input.Prompt = prompt
+	input.Placeholder = placeholder
+	input.CharLimit = 40
+	input.Width = 30
+	m.dealer.edit = edit
+	m.dealer.input = input
+	return m, m.dealer.input.Focus()
+}
+
+func (m Model) handleDealerInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.dealer.edit = dealerEditNone
+		return m, nil
+	case tea.KeyEnter:
+		var cmd protocol.ClientPayload
+		var err error
+		value := strings.TrimSpace(m.dealer.input.Value())
+		if m.dealer.edit == dealerEditBlinds {
+			cmd, err = parseBlinds(value)
+		} else if snap := m.table.GetSnapshot(); m.dealer.selected < len(snap.Players) {
+			cmd, err = parseStackAdjustment(snap.Players[m.dealer.selected].ID, value)
+		} else {
+			m.dealer.edit = dealerEditNone
+			return m, nil
+		}
+		if err != nil {
+			m = m.withStatus(statusWarning, err.Error(), 4*time.Second)
+			return m, m.statusCommand(4 * time.Second)
+		}
+		m.dealer.edit = dealerEditNone
+		return m.sendHostCommand(cmd)
+	}
+
+	var cmd tea.Cmd
+	m.dealer.input, cmd = m.dealer.input.Update(msg)
+	return m, cmd
+}
+
+// parseStackAdjustment reads "+500 리바이": a signed amount and a reason.
+func parseStackAdjustment(playerID, value string) (protocol.HostAdjustStack, error) {
+	amountText, reason, _ := strings.Cut(value, " ")
+	amount, err := strconv.Atoi(amountText)
+	req := protocol.HostAdjustStack{PlayerID: playerID, Amount: amount, Reason: strings.TrimSpace(reason)}
+	if err != nil || req.Validate() != nil {
+		return req, fmt.Errorf("칩 조정은 \"+500 리바이\"처럼 금액과 사유를 입력하세요.")
+	}
+	return req, nil
+}
+
+// parseBlinds reads "25/50" as the blinds for the next hand.
+func parseBlinds(value string) (protocol.HostSetBlinds, error) {
+	small, big, _ := strings.Cut(value, "/")
+	sb, err1 := strconv.Atoi(strings.TrimSpace(small))
+	bb, err2 := strconv.Atoi(strings.TrimSpace(big))
+	req := protocol.HostSetBlinds{SmallBlind: sb, BigBlind: bb}
+	if err1 != nil || err2 != nil || req.Validate() != nil {
+		return req, fmt.Errorf("블라인드는 \"25/50\"처럼 스몰/빅 순서로 입력하세요.")
+	}
+	return req, nil
+}
+
+// sendHostCommand sends a host tool; the server answers everyone with
+// HOST_ACTION or us with an ERROR.
+func (m Model) sendHostCommand(p protocol.ClientPayload) (tea.Model, tea.Cmd) {
+	if m.client == nil || !m.isOnline() {
+		m = m.withStatus(statusWarning, "서버에 연결되어 있지 않습니다.", 3*time.Second)
+		return m, m.statusCommand(3 * time.Second)
+	}
+	if err := m.client.SendPayload(p); err != nil {
+		m = m.withStatus(statusError, fmt.Sprintf("방장 명령 전송 실패: %v", err), 4*time.Second)
+		return m, m.statusCommand(4 * time.Second)
+	}
+	return m, nil
+}
+
+// decideSeat answers the oldest seat request in the room lobby.
+func (m Model) decideSeat(approve bool) (tea.Model, tea.Cmd) {
+	req := m.dealer.requests[0]
+	m.dealer.requests = m.dealer.requests[1:]
+	return m.sendHostCommand(protocol.HostSeatDecision{PlayerID: req.PlayerID, Approve: approve})
+}
+
+// handleDealerEvent applies seat requests, announced host actions and a
+// handed-over host role.
+func (m Model) handleDealerEvent(msg network.ServerMessage) (Model, tea.Cmd) {
+	switch p := msg.Payload.(type) {
+	case protocol.HostGranted:
+		m = m.withStatus(statusInfo, "방장 권한을 넘겨받았습니다.", 4*time.Second)
+	case protocol.SeatRequested:
+		m.dealer.requests = append(m.dealer.requests, p)
+		m = m.withStatus(statusInfo, fmt.Sprintf("%s 님이 입장을 요청했습니다.", sanitizeText(p.Nickname)), 4*time.Second)
+	case protocol.HostActed:
+		var applied bool
+		var cmd tea.Cmd
+		if m, cmd, applied = m.applyUpdate(msg); !applied {
+			return m, cmd
+		}
+		if p.Action == protocol.ClientHostSitOut {
+			if m.dealer.sitOut == nil {
+				m.dealer.sitOut = make(map[string]bool)
+			}
+			m.dealer.sitOut[p.PlayerID] = p.SitOut
+		}
+		if p.Action == protocol.ClientHostAdjustStack && m.book != nil {
+			m.book.Adjust(p.PlayerID, p.Amount)
+			m.trackStacks(m.table.GetSnapshot())
+		}
+		m.dealer.log = append(m.dealer.log, p)
+		if len(m.dealer.log) > dealerLogLimit {
+			m.dealer.log = m.dealer.log[len(m.dealer.log)-dealerLogLimit:]
+		}
+		m = m.withStatus(statusInfo, "방장: "+hostActionText(p), 4*time.Second)
+	default:
+		return m, nil
+	}
+	return m, m.statusCommand(4 * time.Second)
+}
+
+// hostActionText describes a host action for the status bar and log.
+func hostActionText(p protocol.HostActed) string {
+	name := sanitizeText(p.Nickname)
+	switch p.Action {
+	case protocol.ClientHostPause:
+		return "게임 일시정지"
+	case protocol.ClientHostResume:
+		return "게임 재개"
+	case protocol.ClientHostKick:
+		return name + " 내보냄"
+	case protocol.ClientHostSitOut:
+		if p.SitOut {
+			return name + " 다음 핸드부터 쉬기"
+		}
+		return name + " 다음 핸드부터 복귀"
+	case protocol.ClientHostAdjustStack:
+		return fmt.Sprintf("%s 칩 %+d → %d (%s)", name, p.Amount, p.Chips, sanitizeText(p.Reason))
+	case protocol.ClientHostSetBlinds:
+		return fmt.Sprintf("다음 핸드 블라인드 %d/%d", p.SmallBlind, p.BigBlind)
+	case protocol.ClientHostSeatDecision:
+		if p.Approved {
+			return name + " 입장 수락"
+		}
+		return name + " 입장 거절"
+	}
+	return string(p.Action)
+}
+
+func (m Model) renderDealerPanel() string {
+	width := minInt(64, m.contentWidth()-4)
+	snap := m.table.GetSnapshot()
+	header := headerTitleStyle.Width(width).Render("방장 도구")
+
+	progress := lipgloss.NewStyle().Foreground(ColorAccentGreen).Render("진행 중")
+	if snap.Paused {
+		progress = lipgloss.NewStyle().Foreground(ColorWarning).Render("일시정지")
+	}
+	lines := []string{"게임 " + progress}
+
+	for i, p := range snap.Players {
+		line := fmt.Sprintf("%-16s 칩 %d", truncate(sanitizeText(p.Nickname), 16), p.Chips)
+		if m.dealer.sitOut[p.ID] {
+			line += "  쉬는 중"
+		}
+		if i == m.dealer.selected {
+			lines = append(lines, menuItemSelectedStyle.Render("▶ "+line))
+		} else {
+			lines = append(lines, menuItemStyle.Render("  "+line))
+		}
+	}
+
+	if m.dealer.edit != dealerEditNone {
+		lines = append(lines, "", m.dealer.input.View())
+	}
+
+	if len(m.dealer.log) > 0 {
+		lines = append(lines, "", chatMutedStyle.Render("최근 기록"))
+		for _, entry := range m.dealer.log[max(0, len(m.dealer.log)-dealerLogShown):] {
+			lines = append(lines, chatMutedStyle.Render("· "+truncate(hostActionText(entry), width-6)))
+		}
+	}
+
+	keys := "[↑/↓] 선택 • [Space] 정지/재개 • [B] 블라인드\n[K] 내보내기 • [S] 쉬기 • [C] 칩 조정 • [ESC] 닫기"
+	if m.dealer.edit != dealerEditNone {
+		keys = "[Enter] 확인 • [ESC] 취소"
+	}
+	lines = append(lines, "", menuDescStyle.Render(keys))
+
+	return panelEmphasisStyle.Width(width).Render(header + "\n\n" + strings.Join(lines, "\n"))
+}
+
+// renderSeatRequest shows the oldest join request in the room lobby.
+func (m Model) renderSeatRequest() []string {
+	if len(m.dealer.requests) == 0 {
+		return nil
+	}
+	req := m.dealer.requests[0]
+	line := fmt.Sprintf("입장 요청   %s", sanitizeText(req.Nickname))
+	if n := len(m.dealer.requests); n > 1 {
+		line += fmt.Sprintf(" 외 %d명", n-1)
+	}
+	return []string{
+		lipgloss.NewStyle().Foreground(ColorAccentGold).Render(line),
+		helpKeyStyle.Render("[Y]") + " 수락  " + helpKeyStyle.Render("[N]") + " 거절",
+	}
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"strings"
@@ -21,20 +22,65 @@ func (m Model) startOfflineSession() (tea.Model, tea.Cmd) {
 		name = "Player"
 	}
 
-	game := service.NewOfflineGame(name)
-	if err := game.Start(); err != nil {
+	game, err := m.newOfflineGame(name)
+	if err != nil {
 		m = m.withStatus(statusError, fmt.Sprintf("게임 시작 실패: %v", err), 5*time.Second)
 		return m, m.statusCommand(5 * time.Second)
 	}
+	// A replay token reproduces one game; later games are dealt fresh
+	m.offline.replayToken = ""
 
 	m.game.offlineGame = game
 	m.game.snapshot = game.GetGameState()
 	m.screen = screenGame
 	m.modal = modalNone
 	m = m.withStatus(statusInfo, "오프라인 게임을 시작합니다.", 3*time.Second)
+	if game.IsScenario() {
+		m = m.withStatus(statusInfo, fmt.Sprintf("시나리오: %s", m.offline.scenario.Name), 3*time.Second)
+	}
+
+	cmds := []tea.Cmd{m.statusCommand(3 * time.Second), animationTickCmd()}
+	if m.game.snapshot.CurrentPlayer == 1 {
+		cmds = append(cmds, tea.Tick(550*time.Millisecond, func(time.Time) tea.Msg { return aiTurnMsg{} }))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// newOfflineGame creates a started offline game, honoring a replay token or
+// fixed seed supplied on the command line.
+func (m Model) newOfflineGame(name string) (*service.OfflineGame, error) {
+	if m.offline.replayToken != "" {
+		game, err := service.ReplayOfflineGame(name, m.offline.replayToken)
+		if err == nil {
+			game.SetMode(m.offline.mode)
+		}
+		return game, err
+	}
+
+	if m.offline.scenario != nil {
+		seed := time.Now().UnixNano()
+		if m.offline.seedSet {
+			seed = m.offline.seed
+		}
+		game, err := m.offline.scenario.NewGame(seed)
+		if err == nil {
+			game.SetMode(m.offline.mode)
+		}
+		return game, err
+	}
+
+	var game *service.OfflineGame
+	if m.offline.seedSet {
+		game = service.NewOfflineGameWithSeed(name, m.offline.seed)
+	} else {
+		game = service.NewOfflineGame(name)
+	}
 
-	cmd := tea.Batch(m.statusCommand(3*time.Second), animationTickCmd())
-	return m, cmd
+	game.SetMode(m.offline.mode)
+	if err := game.Start(); err != nil {
+		return nil, err
+	}
+	return game, nil
 }
 
 func (m Model) handleGameKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
@@ -58,11 +104,42 @@ func (m Model) handleGameKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "r":
 		amount := m.suggestRaiseAmount()
 		return m.performPlayerAction(vo.Raise, amount)
+	case "x":
+		m.modal = modalReplay
+		return m, nil
+	case "u":
+		return m.takeBack(m.game.offlineGame.UndoLastAction, "마지막 액션을 되돌렸습니다.")
+	case "b":
+		return m.takeBack(m.game.offlineGame.RewindStreet, "스트리트 처음으로 돌아갑니다.")
 	}
 
 	return m, nil
 }
 
+// takeBack runs an undo operation and resumes play from the restored state.
+func (m Model) takeBack(undo func() error, done string) (tea.Model, tea.Cmd) {
+	if err := undo(); err != nil {
+		message := fmt.Sprintf("되돌리기 실패: %v", err)
+		switch {
+		case errors.Is(err, service.ErrUndoDisabled):
+			message = "연습 모드에서만 되돌릴 수 있습니다."
+		case errors.Is(err, service.ErrNothingToUndo):
+			message = "되돌릴 액션이 없습니다."
+		}
+		m = m.withStatus(statusWarning, message, 3*time.Second)
+		return m, m.statusCommand(3 * time.Second)
+	}
+
+	m.game.snapshot = m.currentSnapshot()
+	m = m.withStatus(statusInfo, done, 3*time.Second)
+
+	cmds := []tea.Cmd{m.statusCommand(3 * time.Second)}
+	if m.game.snapshot.CurrentPlayer == 1 {
+		cmds = append(cmds, tea.Tick(550*time.Millisecond, func(time.Time) tea.Msg { return aiTurnMsg{} }))
+	}
+	return m, tea.Batch(cmds...)
+}
+
 func (m Model) performPlayerAction(action vo.PlayerAction, amount int) (tea.Model, tea.Cmd) {
 	players := m.game.offlineGame.GetPlayers()
 	if len(players) == 0 {
@@ -152,23 +229,8 @@ func (m Model) performAITurn() (Model, tea.Cmd) {
 		return m, nil
 	}
 
-	ai := players[1]
-	var action vo.PlayerAction
-	amount := snapshot.CurrentBet
-	callAmount := snapshot.CurrentBet - ai.Bet()
-
-	switch {
-	case ai.Status() == player.AllIn:
-		action = vo.Check
-	case callAmount <= 0:
-		action = vo.Check
-	case callAmount < ai.Chips():
-		action = vo.Call
-	default:
-		action = vo.AllIn
-	}
-
-	if err := m.game.offlineGame.PlayerAction(1, action, amount); err != nil {
+	action, _, err := m.game.offlineGame.PlayBot(1)
+	if err != nil {
 		m = m.withStatus(statusError, fmt.Sprintf("AI 액션 실패: %v", err), 4*time.Second)
 		return m, m.statusCommand(4 * time.Second)
 	}
@@ -195,40 +257,18 @@ func (m Model) evaluateRoundProgress() (Model, tea.Cmd) {
 		return m, nil
 	}
 
-	snapshot := m.game.snapshot
-	players := m.game.offlineGame.GetPlayers()
-
-	// Count active players and check if all have matching bets
-	activePlayers := 0
-	allBetsMatch := true
-	maxBet := snapshot.CurrentBet
-
-	for _, p := range players {
-		status := p.Status()
-		// Count non-folded players
-		if status != player.Folded {
-			activePlayers++
-			// Check if active/waiting players have matching bets (AllIn players are exempt)
-			if status == player.Active || status == player.Waiting {
-				if p.Bet() != maxBet {
-					allBetsMatch = false
-				}
-			}
-		}
-	}
-
-	// Progress round if:
-	// 1. Only one player left (others folded), OR
-	// 2. All non-folded players have matching bets AND currentPlayer is back to 0
-	//    (meaning betting round is complete - both players acted and turn cycled back)
-	// Note: evaluateRoundProgress is only called AFTER a PlayerAction,
-	// so currentPlayer==0 means we've completed a full cycle
-	shouldProgress := activePlayers <= 1 || (allBetsMatch && snapshot.CurrentPlayer == 0)
-	if !shouldProgress {
+	if !m.game.offlineGame.StreetComplete() {
 		return m, nil
 	}
 
 	if err := m.game.offlineGame.ProgressRound(); err != nil {
+		if audited, ok := m.flagAuditFailure(err); ok {
+			m = audited
+			m.game.snapshot = m.currentSnapshot()
+			m.modal = modalShowdown
+			m = m.recordCompletedHand()
+			return m, m.statusCommand(30 * time.Second)
+		}
 		m.status = statusState{message: fmt.Sprintf("라운드 진행 실패: %v", err), level: statusError, seq: m.status.seq + 1}
 		return m, m.statusCommand(4 * time.Second)
 	}
@@ -240,6 +280,7 @@ func (m Model) evaluateRoundProgress() (Model, tea.Cmd) {
 	// Check if we reached showdown
 	if m.game.snapshot.Round == "SHOWDOWN" {
 		m.modal = modalShowdown
+		m = m.recordCompletedHand()
 		return m, m.statusCommand(3 * time.Second)
 	}
 
@@ -266,9 +307,15 @@ func (m Model) viewOfflineGame() string {
 
 	snapshot := m.game.snapshot
 
+	title := "PokerHole - Offline Practice"
+	if m.offline.scenario != nil && m.game.offlineGame.IsScenario() {
+		title = "Drill - " + m.offline.scenario.Name
+	}
+
 	header := lipgloss.JoinHorizontal(lipgloss.Top,
-		headerTitleStyle.Render("PokerHole - Offline Practice"),
+		headerTitleStyle.Render(title),
 		lipgloss.NewStyle().Foreground(ColorTextSecondary).PaddingLeft(2).Render(fmt.Sprintf("라운드: %s", snapshot.Round)),
+		lipgloss.NewStyle().Foreground(ColorTextMuted).PaddingLeft(2).Render(fmt.Sprintf("시드 %d", m.game.offlineGame.Seed())),
 	)
 
 	community := m.renderCommunityArea(snapshot)
@@ -336,7 +383,21 @@ func renderPlayerRow(cards []card.Card, p service.PlayerSnapshot, hide bool, act
 func (m Model) renderPotArea(snapshot service.GameStateSnapshot) string {
 	pot := statusBarStyle(statusInfo).Render(fmt.Sprintf("Pot %d", snapshot.Pot))
 	bet := statusBarStyle(statusInfo).Render(fmt.Sprintf("현재 베팅 %d", snapshot.CurrentBet))
-	return panelStyle.Render(lipgloss.JoinHorizontal(lipgloss.Left, pot, "  ", bet))
+	parts := []string{pot, "  ", bet}
+
+	if g := m.game.offlineGame; g != nil && g.Mode() == service.ModePractice {
+		var undo []string
+		if g.CanUndo() {
+			undo = append(undo, helpKeyStyle.Render("[U]")+" 되돌리기")
+		}
+		if g.CanRewindStreet() {
+			undo = append(undo, helpKeyStyle.Render("[B]")+" 스트리트 처음")
+		}
+		if len(undo) > 0 {
+			parts = append(parts, "  ", strings.Join(undo, " "))
+		}
+	}
+	return panelStyle.Render(lipgloss.JoinHorizontal(lipgloss.Left, parts...))
 }
 
 func (m Model) renderActionBar() string {
@@ -349,6 +410,7 @@ func (m Model) renderActionBar() string {
 		{"레이즈", "[R]"},
 		{"체크", "[K]"},
 		{"올인", "[A]"},
+		{"리플레이", "[X]"},
 		{"메뉴", "[ESC]"},
 	}
 
@@ -7,6 +7,9 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/bunnyholes/pokerhole/client/internal/network"
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
 )
 
 var (
@@ -30,10 +33,6 @@ var (
 				Foreground(ColorTextPrimary).
 				Bold(true)
 
-	homeMenuDescriptionStyle = lipgloss.NewStyle().
-					Foreground(ColorTextSecondary).
-					MarginLeft(2)
-
 	homeDetailPanelStyle = panelStyle.Copy()
 
 	homeDetailHeadingStyle = lipgloss.NewStyle().
@@ -44,11 +43,22 @@ var (
 	homeDetailBodyStyle = lipgloss.NewStyle().
 				Foreground(ColorTextSecondary)
 
+	homeLANLabelStyle = lipgloss.NewStyle().
+				Foreground(ColorTextMuted).
+				MarginTop(1).
+				PaddingLeft(1)
+
 	homeHintStyle = lipgloss.NewStyle().
 			Foreground(ColorTextSecondary).
 			MarginTop(1)
 )
 
+// homeCapabilities names the server capability an online entry needs
+var homeCapabilities = map[homeAction]string{
+	homeActionPrivateRoom: protocol.CapPrivateRooms,
+	homeActionSpectate:    protocol.CapSpectate,
+}
+
 func (m Model) buildHomeMenu() []menuItem {
 	items := []menuItem{
 		{
@@ -61,6 +71,31 @@ func (m Model) buildHomeMenu() []menuItem {
 			description: "실제 서버에 접속하여 다른 플레이어와 겨룹니다.",
 			action:      homeActionOnlineMatch,
 		},
+		{
+			title:       "비공개 방",
+			description: "초대 코드로 방을 만들거나 입장합니다.",
+			action:      homeActionPrivateRoom,
+		},
+		{
+			title:       "테이블 관전",
+			description: "자리에 앉지 않고 진행 중인 테이블을 지켜봅니다.",
+			action:      homeActionSpectate,
+		},
+		{
+			title:       "LAN 테이블 열기",
+			description: "이 컴퓨터에서 테이블을 열고 같은 네트워크의 친구를 초대합니다.",
+			action:      homeActionHostLAN,
+		},
+		{
+			title:       "전적 통계",
+			description: "지금까지 플레이한 핸드의 결과와 뱅크롤 추이를 확인합니다.",
+			action:      homeActionStats,
+		},
+		{
+			title:       "서버 연결",
+			description: "서버 주소를 정하고 접속하거나 다시 시도합니다.",
+			action:      homeActionConnect,
+		},
 		{
 			title:       "게임 종료",
 			description: "포커홀 클라이언트를 종료합니다.",
@@ -68,12 +103,42 @@ func (m Model) buildHomeMenu() []menuItem {
 		},
 	}
 
-	if !m.online {
-		items[1].disabled = true
-		if m.client == nil {
-			items[1].disabledMsg = "서버 연결을 찾을 수 없습니다."
-		} else {
-			items[1].disabledMsg = "서버가 온라인 모드를 아직 지원하지 않습니다."
+	items = append(items, m.lanTableItems()...)
+
+	if m.hosting() {
+		for i := range items {
+			if items[i].action == homeActionHostLAN {
+				items[i].title = "LAN 호스팅 종료"
+				items[i].description = "테이블을 닫고 원래 서버로 돌아갑니다. 주소: " + m.lanAddress()
+			}
+		}
+	}
+
+	if !m.isOnline() {
+		var reason string
+		switch {
+		case m.client == nil:
+			reason = "서버 연결을 찾을 수 없습니다."
+		case m.conn.state == network.StateConnecting:
+			reason = "서버에 연결하는 중입니다."
+		case m.conn.state == network.StateReconnecting:
+			reason = "서버에 다시 연결하는 중입니다."
+		default:
+			reason = "서버에 연결되어 있지 않습니다. [서버 연결]에서 접속하세요."
+		}
+		for i := range items {
+			switch items[i].action {
+			case homeActionOnlineMatch, homeActionPrivateRoom, homeActionSpectate:
+				items[i].disabled = true
+				items[i].disabledMsg = reason
+			}
+		}
+	} else {
+		for i := range items {
+			if capability, ok := homeCapabilities[items[i].action]; ok && m.serverLacks(capability) {
+				items[i].disabled = true
+				items[i].disabledMsg = fmt.Sprintf("이 서버는 %s 기능을 지원하지 않습니다.", items[i].title)
+			}
 		}
 	}
 
@@ -111,14 +176,9 @@ func (m Model) handleHomeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return updated, cmd
 	}
 
-	if msg.Type == tea.KeyRunes {
-		switch strings.ToLower(string(msg.Runes)) {
-		case "1":
-			return m.activateMenuItem(0)
-		case "2":
-			return m.activateMenuItem(1)
-		case "3":
-			return m.activateMenuItem(2)
+	if msg.Type == tea.KeyRunes && len(msg.Runes) == 1 {
+		if r := msg.Runes[0]; r >= '1' && r <= '9' {
+			return m.activateMenuItem(int(r - '1'))
 		}
 	}
 
@@ -143,10 +203,24 @@ func (m Model) executeMenuAction(item menuItem) (tea.Model, tea.Cmd) {
 	case homeActionOffline:
 		return m.startOfflineSession()
 	case homeActionOnlineMatch:
-		m = m.withStatus(statusInfo, "온라인 매치는 준비 중입니다.", 4*time.Second)
-		return m, m.statusCommand(4 * time.Second)
+		return m.startMatching()
+	case homeActionPrivateRoom:
+		return m.openRoomModal()
+	case homeActionSpectate:
+		return m.openTables()
+	case homeActionHostLAN:
+		if m.hosting() {
+			return m.stopHosting("LAN 호스팅을 종료했습니다.")
+		}
+		return m.openHostModal()
+	case homeActionStats:
+		return m.openStats()
+	case homeActionConnect:
+		return m.openConnectModal()
 	case homeActionQuit:
 		return m, tea.Quit
+	case homeActionJoinLAN:
+		return m.joinLANTable(item.table)
 	default:
 		return m, nil
 	}
@@ -212,16 +286,8 @@ func (m Model) renderConnectionStatus() string {
 		Bold(true).
 		Padding(0, 2)
 
-	label := "OFFLINE"
-	note := "오프라인 연습 모드"
-
-	if m.online {
-		badge = badge.Background(ColorAccentGreen)
-		label = "ONLINE"
-		note = "서버 연결 완료"
-	} else {
-		badge = badge.Background(ColorWarning)
-	}
+	label, color, note := m.connectionBadge()
+	badge = badge.Background(color)
 
 	noteStyle := lipgloss.NewStyle().
 		Foreground(ColorTextSecondary).
@@ -267,11 +333,12 @@ func (m Model) renderMenuColumn(width int) string {
 	var rows []string
 
 	for i, item := range m.home.items {
-		base := lipgloss.JoinVertical(
-			lipgloss.Left,
-			homeMenuTitleStyle.Copy().Width(innerWidth).Render(fmt.Sprintf("%d. %s", i+1, item.title)),
-			homeMenuDescriptionStyle.Copy().Width(innerWidth).Render(item.description),
-		)
+		if item.action == homeActionJoinLAN && (i == 0 || m.home.items[i-1].action != homeActionJoinLAN) {
+			rows = append(rows, homeLANLabelStyle.Copy().Width(innerWidth).Render("근처 LAN 테이블"))
+		}
+
+		// Descriptions live in the detail panel so every entry fits the frame
+		base := homeMenuTitleStyle.Copy().Width(innerWidth).Render(fmt.Sprintf("%d. %s", i+1, item.title))
 
 		style := homeMenuEntryStyle.Copy()
 		if item.disabled {
@@ -293,7 +360,7 @@ func (m Model) renderMenuColumn(width int) string {
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		homeSectionLabelStyle.Copy().Width(width).Render("게임 모드"),
-		panelStyle.Copy().Width(width).Render(content),
+		panelStyle.Copy().Width(width-panelStyle.GetHorizontalBorderSize()).Render(content),
 	)
 }
 
@@ -308,7 +375,7 @@ func (m Model) renderMenuDetail(width int) string {
 		innerWidth = width
 	}
 
-	panel := homeDetailPanelStyle.Copy().Width(width)
+	panel := homeDetailPanelStyle.Copy().Width(width - homeDetailPanelStyle.GetHorizontalBorderSize())
 
 	if len(m.home.items) == 0 {
 		empty := homeDetailBodyStyle.Copy().Width(innerWidth).Render("선택 가능한 메뉴가 없습니다.")
@@ -322,6 +389,22 @@ func (m Model) renderMenuDetail(width int) string {
 
 	sections := []string{heading, description}
 
+	if selected.action == homeActionConnect && m.client != nil {
+		url := homeDetailBodyStyle.Copy().
+			Foreground(ColorInfo).
+			Width(innerWidth).
+			Render(truncate(m.client.ServerURL(), innerWidth))
+		sections = append(sections, url)
+	}
+
+	if selected.action == homeActionJoinLAN {
+		url := homeDetailBodyStyle.Copy().
+			Foreground(ColorInfo).
+			Width(innerWidth).
+			Render(truncate(selected.table.URL, innerWidth) + "\n방 코드 " + selected.table.Code)
+		sections = append(sections, url)
+	}
+
 	if selected.disabled && selected.disabledMsg != "" {
 		warning := homeDetailBodyStyle.Copy().
 			Foreground(ColorWarning).
@@ -15,8 +15,8 @@ func (m Model) advanceIntroAnimation() (tea.Model, tea.Cmd) {
 	if cmd != nil {
 		if doneMsg := cmd(); doneMsg != nil {
 			if _, ok := doneMsg.(intro.DoneMsg); ok {
-				m.screen = screenHome
-				return m, animationTickCmd()
+				m, cmd := m.leaveIntro()
+				return m, tea.Batch(cmd, animationTickCmd())
 			}
 		}
 	}
@@ -0,0 +1,295 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/bunnyholes/pokerhole/client/internal/discovery"
+	"github.com/bunnyholes/pokerhole/client/internal/localserver"
+	"github.com/bunnyholes/pokerhole/client/internal/network"
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+)
+
+// defaultLANAddr is where hosted tables listen unless WithLANAddr says otherwise
+const defaultLANAddr = ":7777"
+
+// lanRefreshInterval is how often the home screen rereads discovered tables
+const lanRefreshInterval = time.Second
+
+// maxLANTables caps the discovered tables listed on the home screen
+const maxLANTables = 3
+
+// lanState tracks a table this client hosts for the local network and the
+// tables other hosts announce. While hosting, our own client plays on the
+// hosted server like any guest.
+type lanState struct {
+	addr        string
+	host        *localserver.Host
+	previousURL string               // server to return to when hosting stops
+	pending     *protocol.CreateRoom // room to open once we are connected
+
+	listener  *discovery.Listener
+	listenErr error // why there is no listener, until the home screen shows it
+	announce  discovery.AnnounceOptions
+	announcer *discovery.Announcer
+	tables    []discovery.Table // announced by other hosts, newest poll
+	joining   string            // room code to join once connected to a LAN table
+}
+
+// lanRefreshMsg asks the model to reread the discovered tables.
+type lanRefreshMsg struct{}
+
+func lanRefreshCmd() tea.Cmd {
+	return tea.Tick(lanRefreshInterval, func(time.Time) tea.Msg { return lanRefreshMsg{} })
+}
+
+// hosting reports whether this client runs a LAN table.
+func (m Model) hosting() bool {
+	return m.lan.host != nil
+}
+
+// openHostModal asks for the blinds and seats of the table to host.
+func (m Model) openHostModal() (tea.Model, tea.Cmd) {
+	if m.client == nil {
+		m = m.withStatus(statusError, "네트워크 클라이언트가 없습니다.", 4*time.Second)
+		return m, m.statusCommand(4 * time.Second)
+	}
+
+	updated, _ := m.openRoomModal()
+	m = updated.(Model)
+	m.room.mode = roomHost
+	return m.focusRoomField(roomFieldSmallBlind)
+}
+
+// hostTable starts the LAN server, moves our client onto it and opens a
+// private room there once connected.
+func (m Model) hostTable() (tea.Model, tea.Cmd) {
+	req, field, err := m.roomSettings()
+	if err != nil {
+		return m.rejectRoomSettings(field, err)
+	}
+
+	addr := m.lan.addr
+	if addr == "" {
+		addr = defaultLANAddr
+	}
+	host, err := localserver.Listen(addr, localserver.Options{Logf: func(string, ...any) {}})
+	if err != nil {
+		m = m.withStatus(statusError, fmt.Sprintf("테이블을 열 수 없습니다: %v", err), 5*time.Second)
+		return m, m.statusCommand(5 * time.Second)
+	}
+
+	previous := m.client.ServerURL()
+	m.client.Disconnect()
+	if err := m.client.SetServerURL(host.LocalURL()); err != nil {
+		host.Close()
+		m = m.withStatus(statusWarning, fmt.Sprintf("연결을 바꿀 수 없습니다: %v", err), 4*time.Second)
+		return m, m.statusCommand(4 * time.Second)
+	}
+
+	m.lan.host = host
+	m.lan.announcer, _ = discovery.NewAnnouncer(roomAnnouncements(host, m.playerName), m.lan.announce)
+	m.lan.previousURL = previous
+	m.lan.pending = &req
+	m.room.code = ""
+	m = m.enterRoomLobby(req.Seats)
+	m.conn.state = network.StateConnecting
+	m.home.items = m.buildHomeMenu()
+	m = m.withStatus(statusInfo, "LAN 테이블을 여는 중입니다.", 3*time.Second)
+	return m, tea.Batch(connectCmd(m.client), m.spinner.Tick, m.statusCommand(3*time.Second))
+}
+
+// openHostedRoom creates the room on our own server after connecting.
+func (m Model) openHostedRoom() (Model, tea.Cmd) {
+	req := m.lan.pending
+	m.lan.pending = nil
+	if err := m.client.SendPayload(*req); err != nil {
+		return m.stopHosting(fmt.Sprintf("방 만들기 실패: %v", err))
+	}
+	return m, nil
+}
+
+// stopHosting closes the LAN server and reconnects to the previous server.
+func (m Model) stopHosting(notice string) (Model, tea.Cmd) {
+	if !m.hosting() {
+		return m, nil
+	}
+
+	m.client.Disconnect()
+	if m.lan.announcer != nil {
+		m.lan.announcer.Close()
+	}
+	m.lan.host.Close()
+	previous := m.lan.previousURL
+	m.lan = lanState{addr: m.lan.addr, listener: m.lan.listener, announce: m.lan.announce, tables: m.lan.tables}
+	m.room.code = ""
+	if m.screen == screenMatching || m.screen == screenOnline {
+		m.screen = screenHome
+		m.matching = matchingState{}
+	}
+
+	var cmd tea.Cmd
+	if previous != "" && m.client.SetServerURL(previous) == nil {
+		m.conn.state = network.StateConnecting
+		cmd = connectCmd(m.client)
+	}
+	m.home.items = m.buildHomeMenu()
+	m = m.withStatus(statusInfo, notice, 4*time.Second)
+	return m, tea.Batch(cmd, m.statusCommand(4*time.Second))
+}
+
+// lanAddress is the first address guests can use to reach our table.
+func (m Model) lanAddress() string {
+	if !m.hosting() {
+		return ""
+	}
+	if urls := m.lan.host.LANURLs(); len(urls) > 0 {
+		return urls[0]
+	}
+	return m.lan.host.LocalURL()
+}
+
+// roomAnnouncements describes the rooms on our hosted server for discovery.
+func roomAnnouncements(host *localserver.Host, playerName string) func() []discovery.Announcement {
+	name := playerName + "의 테이블"
+	return func() []discovery.Announcement {
+		var tables []discovery.Announcement
+		for _, room := range host.Rooms() {
+			free := room.Seats - room.Players
+			if room.Started {
+				free = 0
+			}
+			tables = append(tables, discovery.Announcement{
+				Name:       name,
+				Variant:    discovery.VariantHoldem,
+				Code:       room.Code,
+				SmallBlind: room.SmallBlind,
+				BigBlind:   room.BigBlind,
+				Seats:      room.Seats,
+				FreeSeats:  free,
+				Port:       host.Port(),
+				Path:       localserver.Path,
+			})
+		}
+		return tables
+	}
+}
+
+// announceNow pushes a seat change to listeners without waiting.
+func (m Model) announceNow() {
+	if m.lan.announcer != nil {
+		m.lan.announcer.Announce()
+	}
+}
+
+// leaveIntro opens the home screen, warning once when LAN tables cannot
+// be discovered.
+func (m Model) leaveIntro() (Model, tea.Cmd) {
+	m.screen = screenHome
+	if m.lan.listenErr == nil {
+		return m, nil
+	}
+	m = m.withStatus(statusWarning, fmt.Sprintf("LAN 테이블을 찾을 수 없습니다: %v", m.lan.listenErr), 6*time.Second)
+	m.lan.listenErr = nil
+	return m, m.statusCommand(6 * time.Second)
+}
+
+// refreshLANTables rereads the discovered tables, keeping the menu
+// selection on the same entry where possible.
+func (m Model) refreshLANTables() (tea.Model, tea.Cmd) {
+	if m.lan.listener == nil {
+		return m, nil
+	}
+
+	var own string
+	if m.lan.announcer != nil {
+		own = m.lan.announcer.ID()
+	}
+	var tables []discovery.Table
+	for _, t := range m.lan.listener.Tables() {
+		if t.Host != own && len(tables) < maxLANTables {
+			tables = append(tables, t)
+		}
+	}
+
+	var selected string
+	if idx := m.home.selected; idx < len(m.home.items) && m.home.items[idx].action == homeActionJoinLAN {
+		selected = m.home.items[idx].table.Key()
+	}
+	m.lan.tables = tables
+	m.home.items = m.buildHomeMenu()
+	if selected != "" {
+		m.home.selected = int(homeActionQuit)
+		for i, item := range m.home.items {
+			if item.action == homeActionJoinLAN && item.table.Key() == selected {
+				m.home.selected = i
+			}
+		}
+	}
+	m.home.selected = min(m.home.selected, len(m.home.items)-1)
+	return m, lanRefreshCmd()
+}
+
+// lanTableItems lists discovered tables as home menu entries.
+func (m Model) lanTableItems() []menuItem {
+	items := make([]menuItem, 0, len(m.lan.tables))
+	for _, t := range m.lan.tables {
+		item := menuItem{
+			title: "LAN · " + truncate(sanitizeText(t.Name), 20),
+			description: fmt.Sprintf("%s · 블라인드 %d/%d · 빈자리 %d/%d",
+				sanitizeText(t.Variant), t.SmallBlind, t.BigBlind, t.FreeSeats, t.Seats),
+			action: homeActionJoinLAN,
+			table:  t,
+		}
+		switch {
+		case m.hosting():
+			item.disabled = true
+			item.disabledMsg = "LAN 호스팅 중에는 다른 테이블에 앉을 수 없습니다."
+		case t.FreeSeats <= 0:
+			item.disabled = true
+			item.disabledMsg = "빈자리가 없거나 이미 게임이 시작되었습니다."
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// joinLANTable connects to a discovered host and joins its room once the
+// connection is up.
+func (m Model) joinLANTable(t discovery.Table) (tea.Model, tea.Cmd) {
+	if m.client == nil {
+		m = m.withStatus(statusError, "네트워크 클라이언트가 없습니다.", 4*time.Second)
+		return m, m.statusCommand(4 * time.Second)
+	}
+
+	m.client.Disconnect()
+	if err := m.client.SetServerURL(t.URL); err != nil {
+		m = m.withStatus(statusWarning, fmt.Sprintf("연결을 바꿀 수 없습니다: %v", err), 4*time.Second)
+		return m, m.statusCommand(4 * time.Second)
+	}
+
+	m.lan.joining = t.Code
+	m.room.code = t.Code
+	m = m.enterRoomLobby(t.Seats)
+	m.conn.state = network.StateConnecting
+	m.home.items = m.buildHomeMenu()
+	m = m.withStatus(statusInfo, fmt.Sprintf("%s에 연결하는 중입니다.", sanitizeText(t.Name)), 3*time.Second)
+	return m, tea.Batch(connectCmd(m.client), m.spinner.Tick, m.statusCommand(3*time.Second))
+}
+
+// joinDiscoveredRoom sends the pending room code after connecting.
+func (m Model) joinDiscoveredRoom() (Model, tea.Cmd) {
+	code := m.lan.joining
+	m.lan.joining = ""
+	if err := m.client.JoinCodeMatch(code); err != nil {
+		m.screen = screenHome
+		m.matching = matchingState{}
+		m.room.code = ""
+		m = m.withStatus(statusError, fmt.Sprintf("방 입장 요청 실패: %v", err), 4*time.Second)
+		return m, m.statusCommand(4 * time.Second)
+	}
+	m = m.withStatus(statusInfo, fmt.Sprintf("방 %s에 입장을 요청했습니다.", code), 3*time.Second)
+	return m, m.statusCommand(3 * time.Second)
+}
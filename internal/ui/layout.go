@@ -38,6 +38,9 @@ func (m Model) renderStatusBar() string {
 		base := statusBarStyle(statusNeutral)
 		hintIcon := spinnerStyle().Render("●")
 		hint := fmt.Sprintf("%s 도움말 [?]  |  정보 [H]  |  종료 [Ctrl+C]", hintIcon)
+		if latency := m.renderLatency(); latency != "" {
+			hint += "  |  " + latency
+		}
 		return base.Width(width).Render(" " + hint)
 	}
 
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/ledger"
+)
+
+// DefaultLedgerDir returns ~/.pokerhole/ledgers
+func DefaultLedgerDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".pokerhole", "ledgers"), nil
+}
+
+// flagAuditFailure turns a conservation violation into a loud, sticky error.
+func (m Model) flagAuditFailure(err error) (Model, bool) {
+	if !errors.Is(err, ledger.ErrConservation) {
+		return m, false
+	}
+	m.game.auditErr = err
+	m = m.withStatus(statusError, "칩 보존 위반! [L]로 원장을 내보내 확인하세요.", 30*time.Second)
+	return m, true
+}
+
+// exportLedger writes the current hand's ledger as JSON to the ledger directory.
+func (m Model) exportLedger() (tea.Model, tea.Cmd) {
+	if m.game.offlineGame == nil {
+		return m, nil
+	}
+	if m.ledgerDir == "" {
+		m = m.withStatus(statusWarning, "원장 저장 위치가 설정되지 않았습니다.", 4*time.Second)
+		return m, m.statusCommand(4 * time.Second)
+	}
+
+	handID := m.game.offlineGame.HandID()
+	data, err := m.game.offlineGame.Ledger().ExportHand(handID)
+	if err == nil {
+		err = os.MkdirAll(m.ledgerDir, 0o755)
+	}
+	path := filepath.Join(m.ledgerDir, handID+".json")
+	if err == nil {
+		err = os.WriteFile(path, data, 0o644)
+	}
+	if err != nil {
+		m = m.withStatus(statusError, fmt.Sprintf("원장 내보내기 실패: %v", err), 4*time.Second)
+		return m, m.statusCommand(4 * time.Second)
+	}
+
+	m = m.withStatus(statusSuccess, "원장 저장: "+path, 5*time.Second)
+	return m, m.statusCommand(5 * time.Second)
+}
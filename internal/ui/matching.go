@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/bunnyholes/pokerhole/client/internal/network"
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+)
+
+// matchingState tracks a random-match queue entry.
+type matchingState struct {
+	started    time.Time
+	elapsed    time.Duration
+	current    int // players in the queue, as reported by the server
+	required   int
+	cancelling bool
+	private    bool // waiting in a private room rather than the random queue
+}
+
+// startMatching joins the random-match queue.
+func (m Model) startMatching() (tea.Model, tea.Cmd) {
+	if err := m.client.JoinRandomMatch(); err != nil {
+		m = m.withStatus(statusError, fmt.Sprintf("매칭 요청 실패: %v", err), 4*time.Second)
+		return m, m.statusCommand(4 * time.Second)
+	}
+
+	m.matching = matchingState{started: time.Now()}
+	m.dealer = dealerState{}
+	m.room.code = ""
+	m.screen = screenMatching
+	m = m.withStatus(statusInfo, "매칭 대기열에 참가했습니다.", 3*time.Second)
+	return m, tea.Batch(m.spinner.Tick, m.statusCommand(3*time.Second))
+}
+
+// handleMatchingEvent follows the queue until the server seats us.
+func (m Model) handleMatchingEvent(msg network.ServerMessage) (Model, tea.Cmd) {
+	m.table.Update(msg)
+	m.announceNow()
+
+	switch p := msg.Payload.(type) {
+	case protocol.MatchingStarted:
+		m.matching.required = p.RequiredPlayers
+		return m, nil
+	case protocol.MatchingProgress:
+		m.matching.current = p.CurrentPlayers
+		m.matching.required = p.RequiredPlayers
+		return m, nil
+	case protocol.RoomCreated:
+		m.room.code = p.Code
+		m.matching.required = p.Seats
+		m = m.withStatus(statusSuccess, fmt.Sprintf("방 코드 %s · 친구에게 알려 주세요.", p.Code), 6*time.Second)
+		return m, m.statusCommand(6 * time.Second)
+	case protocol.MatchingCompleted:
+		m.screen = screenOnline
+		m.online = onlineState{}
+		m = m.withStatus(statusSuccess, "매칭 완료! 테이블로 이동합니다.", 3*time.Second)
+	case protocol.MatchingCancelled:
+		m.screen = screenHome
+		m.room.code = ""
+		m = m.withStatus(statusInfo, "매칭을 취소했습니다.", 3*time.Second)
+	case protocol.Error:
+		m.screen = screenHome
+		m.room.code = ""
+		m = m.withStatus(statusError, roomErrorText(p), 3*time.Second)
+	default:
+		return m, nil
+	}
+
+	m.matching = matchingState{}
+	return m, m.statusCommand(3 * time.Second)
+}
+
+func (m Model) handleMatchingKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if len(m.dealer.requests) > 0 {
+		switch msg.String() {
+		case "y", "Y":
+			return m.decideSeat(true)
+		case "n", "N":
+			return m.decideSeat(false)
+		}
+	}
+	if msg.String() != "esc" || m.matching.cancelling {
+		return m, nil
+	}
+
+	if err := m.client.SendPayload(protocol.CancelMatching{}); err != nil {
+		// Without a connection there is no queue left to leave
+		m.lan.joining = ""
+		m.screen = screenHome
+		m.matching = matchingState{}
+		m.room.code = ""
+		m = m.withStatus(statusWarning, "연결이 끊겨 매칭을 종료했습니다.", 4*time.Second)
+		return m, m.statusCommand(4 * time.Second)
+	}
+
+	m.matching.cancelling = true
+	return m, nil
+}
+
+// abandonMatching leaves the matching screen when the connection drops;
+// the server forgets the queue entry with the connection.
+func (m Model) abandonMatching() Model {
+	if m.screen != screenMatching {
+		return m
+	}
+	m.screen = screenHome
+	m.matching = matchingState{}
+	m.room.code = ""
+	return m.withStatus(statusWarning, "서버 연결이 끊겨 매칭을 종료했습니다.", 4*time.Second)
+}
+
+func (m Model) viewMatching() string {
+	width := m.contentWidth()
+
+	titleText := "RANDOM MATCH · 랜덤 매칭"
+	heading := m.spinner.View() + " 상대를 찾는 중..."
+	if m.matching.private {
+		titleText = "PRIVATE ROOM · 비공개 방"
+		heading = m.spinner.View() + " 친구를 기다리는 중..."
+		if m.room.code == "" {
+			heading = m.spinner.View() + " 방을 만드는 중..."
+		}
+	}
+	if m.matching.cancelling {
+		heading = m.spinner.View() + " 매칭 취소 중..."
+	}
+
+	title := headerTitleStyle.Copy().
+		Width(width).
+		Align(lipgloss.Center).
+		Render(titleText)
+
+	elapsed := m.matching.elapsed.Truncate(time.Second)
+	lines := []string{
+		homeDetailHeadingStyle.Render(heading),
+	}
+	if m.room.code != "" {
+		lines = append(lines, "방 코드     "+lipgloss.NewStyle().Foreground(ColorAccentGold).Bold(true).Render(m.room.code))
+	}
+	if m.hosting() {
+		lines = append(lines,
+			"LAN 주소    "+lipgloss.NewStyle().Foreground(ColorInfo).Render(truncate(m.lanAddress(), 40)),
+			menuDescStyle.Render("주소는 [서버 연결], 코드는 [비공개 방]에 입력"),
+		)
+	}
+	lines = append(lines, m.renderSeatRequest()...)
+	lines = append(lines, fmt.Sprintf("대기 시간   %02d:%02d", int(elapsed.Minutes()), int(elapsed.Seconds())%60))
+
+	if m.matching.required > 0 {
+		lines = append(lines, fmt.Sprintf("대기열      %d / %d 명  %s",
+			m.matching.current, m.matching.required, queueBar(m.matching.current, m.matching.required, 20)))
+	} else {
+		lines = append(lines, menuDescStyle.Render("대기열 정보를 기다리는 중..."))
+	}
+
+	lines = append(lines, "", helpKeyStyle.Render("[ESC]")+" 매칭 취소")
+
+	panel := panelStyle.Copy().Width(minInt(56, width)).Render(strings.Join(lines, "\n"))
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		"",
+		lipgloss.PlaceHorizontal(width, lipgloss.Center, panel),
+	)
+	return m.applyShell(body)
+}
+
+// queueBar draws queue fill as a fixed-width bar.
+func queueBar(current, required, width int) string {
+	filled := 0
+	if required > 0 {
+		filled = min(width, current*width/required)
+	}
+	return lipgloss.NewStyle().Foreground(ColorAccentGreen).Render(strings.Repeat("█", filled)) +
+		lipgloss.NewStyle().Foreground(ColorTextMuted).Render(strings.Repeat("░", width-filled))
+}
@@ -20,6 +20,16 @@ type serverMessageMsg struct {
 	Message network.ServerMessage
 }
 
+// protocolErrorMsg reports a server message that failed to decode or validate
+type protocolErrorMsg struct {
+	Err error
+}
+
+// actionResultMsg settles a game action sent to the server
+type actionResultMsg struct {
+	Result network.Result
+}
+
 func animationTickCmd() tea.Cmd {
 	return tea.Tick(33*time.Millisecond, func(time.Time) tea.Msg {
 		return animationTickMsg{}
@@ -32,10 +42,29 @@ func listenForMessages(client *network.Client) tea.Cmd {
 	}
 
 	return func() tea.Msg {
-		msg, ok := <-client.Receive()
+		select {
+		case msg, ok := <-client.Receive():
+			if !ok {
+				return nil
+			}
+			return serverMessageMsg{Message: msg}
+		case err := <-client.Errors():
+			return protocolErrorMsg{Err: err}
+		}
+	}
+}
+
+// listenForResults waits for the next settled game action
+func listenForResults(client *network.Client) tea.Cmd {
+	if client == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		res, ok := <-client.Results()
 		if !ok {
 			return nil
 		}
-		return serverMessageMsg{Message: msg}
+		return actionResultMsg{Result: res}
 	}
 }
@@ -11,7 +11,7 @@ import (
 
 func (m Model) handleModalKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch m.modal {
-	case modalHelp, modalAbout:
+	case modalHelp, modalAbout, modalReplay:
 		switch msg.String() {
 		case "esc", "q":
 			m.modal = modalNone
@@ -21,6 +21,8 @@ func (m Model) handleModalKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "n", "N":
 			return m.restartAfterShowdown()
+		case "l", "L":
+			return m.exportLedger()
 		case "esc", "q":
 			m.modal = modalNone
 			m.screen = screenHome
@@ -44,6 +46,7 @@ func (m Model) restartAfterShowdown() (tea.Model, tea.Cmd) {
 	}
 
 	m.game.snapshot = m.currentSnapshot()
+	m.game.auditErr = nil
 	m.modal = modalNone
 	m.screen = screenGame
 	m = m.withStatus(statusSuccess, "새 게임이 시작되었습니다.", 3*time.Second)
@@ -67,6 +70,14 @@ func (m Model) renderModal() string {
 		return m.renderAboutModal()
 	case modalShowdown:
 		return m.renderShowdownModal()
+	case modalReplay:
+		return m.renderReplayModal()
+	case modalConnect:
+		return m.renderConnectModal()
+	case modalRoom:
+		return m.renderRoomModal()
+	case modalDealer:
+		return m.renderDealerPanel()
 	default:
 		return ""
 	}
@@ -80,6 +91,11 @@ func (m Model) renderHelpModal() string {
 		"  [F] 폴드  |  [C] 콜",
 		"  [R] 레이즈 | [K] 체크",
 		"  [A] 올인",
+		"  [X] 리플레이 토큰",
+		"  [U] 되돌리기 | [B] 스트리트 처음 (연습 모드)",
+		"  [+/-] 레이즈 금액 조절 (온라인)",
+		"  [T] 채팅 열기/닫기 | [Enter] 입력 | /mute 닉네임",
+		"  [P] 방장 도구 (비공개 방 방장)",
 		"",
 		"일반 조작:",
 		"  [ESC] 메뉴로 돌아가기",
@@ -107,6 +123,35 @@ func (m Model) renderAboutModal() string {
 	return panelEmphasisStyle.Width(width).Render(header + "\n\n" + body + "\n\n" + footer)
 }
 
+func (m Model) renderReplayModal() string {
+	if m.game.offlineGame == nil {
+		return ""
+	}
+
+	width := minInt(74, m.contentWidth()-4)
+	frame, _ := panelEmphasisStyle.GetFrameSize()
+	header := headerTitleStyle.Width(width).Render("리플레이 토큰")
+
+	lines := []string{fmt.Sprintf("시드: %d", m.game.offlineGame.Seed()), ""}
+	if token, err := m.game.offlineGame.ReplayToken(); err == nil {
+		lines = append(lines,
+			lipgloss.NewStyle().Width(width-frame).Render(token),
+			"",
+			menuDescStyle.Render("버그 제보 시 위 토큰을 첨부하세요. --replay 로 재현합니다."),
+		)
+	} else {
+		lines = append(lines,
+			"시나리오 게임은 리플레이 토큰을 만들 수 없습니다.",
+			"",
+			menuDescStyle.Render("같은 --scenario 파일과 위 시드(--seed)로 다시 시작하면 재현됩니다."),
+		)
+	}
+
+	body := strings.Join(lines, "\n")
+	footer := menuDescStyle.Render("[ESC] 또는 [Q] 닫기")
+	return panelEmphasisStyle.Width(width).Render(header + "\n\n" + body + "\n\n" + footer)
+}
+
 func (m Model) renderShowdownModal() string {
 	if m.game.offlineGame == nil {
 		return ""
@@ -124,15 +169,21 @@ func (m Model) renderShowdownModal() string {
 		lines = append(lines, statusBarStyle(statusSuccess).Width(width).Render(" "+message))
 		if winner.HandRank != "" {
 			lines = append(lines, menuDescStyle.Width(width).Render("핸드: "+winner.HandRank))
+		} else {
+			lines = append(lines, menuDescStyle.Width(width).Render("나머지 플레이어가 모두 폴드했습니다"))
 		}
 	} else {
 		lines = append(lines, statusBarStyle(statusInfo).Width(width).Render(" 비겼습니다"))
 	}
 
+	if m.game.auditErr != nil {
+		lines = append(lines, statusBarStyle(statusError).Width(width).Render(" ⚠ 칩 보존 위반: 원장과 실제 칩이 다릅니다"))
+	}
+
 	lines = append(lines, "")
 	lines = append(lines, m.renderShowdownPlayers(width))
 	lines = append(lines, "")
-	lines = append(lines, menuDescStyle.Width(width).Render("[N] 새 게임  •  [ESC] 메뉴로"))
+	lines = append(lines, menuDescStyle.Width(width).Render("[N] 새 게임  •  [L] 원장 내보내기  •  [ESC] 메뉴로"))
 
 	content := strings.Join(lines, "\n")
 	return panelEmphasisStyle.Width(width).Render(content)
@@ -6,9 +6,17 @@ import (
 
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
 
+	"github.com/bunnyholes/pokerhole/client/internal/adapter/out/websocket"
 	"github.com/bunnyholes/pokerhole/client/internal/core/application/service"
+	"github.com/bunnyholes/pokerhole/client/internal/discovery"
 	"github.com/bunnyholes/pokerhole/client/internal/network"
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+	"github.com/bunnyholes/pokerhole/client/internal/scenario"
+	"github.com/bunnyholes/pokerhole/client/internal/settle"
+	"github.com/bunnyholes/pokerhole/client/internal/state"
+	"github.com/bunnyholes/pokerhole/client/internal/stats"
 	intro "github.com/bunnyholes/pokerhole/client/internal/ui/scenes/intro"
 )
 
@@ -16,9 +24,15 @@ import (
 type screenID string
 
 const (
-	screenIntro screenID = "intro"
-	screenHome  screenID = "home"
-	screenGame  screenID = "game"
+	screenIntro    screenID = "intro"
+	screenHome     screenID = "home"
+	screenGame     screenID = "game"
+	screenOnline   screenID = "online"
+	screenMatching screenID = "matching"
+	screenStats    screenID = "stats"
+	screenTables   screenID = "tables"
+	screenSettle   screenID = "settle"
+	screenUpgrade  screenID = "upgrade"
 )
 
 // modalID represents modal overlays rendered above the primary screen.
@@ -29,6 +43,10 @@ const (
 	modalHelp     modalID = "help"
 	modalAbout    modalID = "about"
 	modalShowdown modalID = "showdown"
+	modalReplay   modalID = "replay"
+	modalConnect  modalID = "connect"
+	modalRoom     modalID = "room"
+	modalDealer   modalID = "dealer"
 )
 
 // statusLevel controls the accent color of the status bar.
@@ -48,7 +66,13 @@ type homeAction int
 const (
 	homeActionOffline homeAction = iota
 	homeActionOnlineMatch
+	homeActionPrivateRoom
+	homeActionSpectate
+	homeActionHostLAN
+	homeActionStats
+	homeActionConnect
 	homeActionQuit
+	homeActionJoinLAN // a discovered LAN table, listed after the fixed entries
 )
 
 type menuItem struct {
@@ -57,6 +81,7 @@ type menuItem struct {
 	action      homeAction
 	disabled    bool
 	disabledMsg string
+	table       discovery.Table // set for homeActionJoinLAN
 }
 
 type homeState struct {
@@ -67,6 +92,21 @@ type homeState struct {
 type gameState struct {
 	offlineGame *service.OfflineGame
 	snapshot    service.GameStateSnapshot
+	auditErr    error
+}
+
+// offlineOptions carries command-line settings for new offline sessions.
+type offlineOptions struct {
+	seed        int64
+	seedSet     bool
+	replayToken string // replayed by the next offline game only
+	scenario    *scenario.Scenario
+	mode        service.SessionMode
+}
+
+type statsState struct {
+	dashboard stats.Dashboard
+	loadErr   string
 }
 
 type statusState struct {
@@ -79,7 +119,9 @@ type statusState struct {
 type Model struct {
 	client     *network.Client
 	playerName string
-	online     bool
+	conn       connectionState
+	table      *state.GameState           // online game as the server reports it
+	remote     *service.OnlineGameService // sends our actions for table
 
 	spinner spinner.Model
 
@@ -92,12 +134,28 @@ type Model struct {
 	introModel intro.Model // Updated: Now using intro.Model instead of intro.State
 	home       homeState
 	game       gameState
+	stats      statsState
+	offline    offlineOptions
+	online     onlineState
+	matching   matchingState
+	room       roomState
+	chat       chatState
+	spectate   spectateState
+	lan        lanState
+	dealer     dealerState
+	settle     settleState
+	upgrade    upgradeState
+
+	history   *stats.Store
+	sessionID string
+	ledgerDir string
+	book      *settle.Book
 
 	status statusState
 }
 
 // NewModel constructs the CLI application model.
-func NewModel(client *network.Client, online bool, playerName string) Model {
+func NewModel(client *network.Client, playerName string) Model {
 	sp := spinner.New()
 	sp.Spinner = spinner.Dot
 	sp.Style = spinnerStyle()
@@ -105,18 +163,81 @@ func NewModel(client *network.Client, online bool, playerName string) Model {
 	m := Model{
 		client:     client,
 		playerName: playerName,
-		online:     online,
 		spinner:    sp,
 		screen:     screenIntro,
 		modal:      modalNone,
 		introModel: intro.NewModel(80), // Updated: Initialize with intro.NewModel
+		sessionID:  uuid.New().String(),
+		table:      state.NewGameState(),
+		chat:       newChatState(),
 	}
 
+	if client != nil {
+		m.remote = service.NewOnlineGameService(websocket.NewClient(client, m.table))
+	}
 	m.home.items = m.buildHomeMenu()
 
 	return m
 }
 
+// WithHistory enables recording completed hands to the given store.
+func (m Model) WithHistory(store *stats.Store) Model {
+	m.history = store
+	return m
+}
+
+// WithLedgerDir sets where per-hand chip ledgers are exported.
+func (m Model) WithLedgerDir(dir string) Model {
+	m.ledgerDir = dir
+	return m
+}
+
+// WithSeed fixes the session seed used for offline games.
+func (m Model) WithSeed(seed int64) Model {
+	m.offline.seed = seed
+	m.offline.seedSet = true
+	return m
+}
+
+// WithReplay makes the next offline game replay the given token.
+func (m Model) WithReplay(token string) Model {
+	m.offline.replayToken = token
+	return m
+}
+
+// WithSessionMode sets how offline sessions count; undo is practice-only.
+func (m Model) WithSessionMode(mode service.SessionMode) Model {
+	m.offline.mode = mode
+	return m
+}
+
+// WithScenario makes offline games start from the given spot.
+func (m Model) WithScenario(sc *scenario.Scenario) Model {
+	m.offline.scenario = sc
+	return m
+}
+
+// WithLANAddr sets the address hosted LAN tables listen on.
+func (m Model) WithLANAddr(addr string) Model {
+	m.lan.addr = addr
+	return m
+}
+
+// WithDiscovery lists tables announced to listener on the home screen and
+// announces hosted tables with opts. listener may be nil to only announce.
+func (m Model) WithDiscovery(listener *discovery.Listener, opts discovery.AnnounceOptions) Model {
+	m.lan.listener = listener
+	m.lan.announce = opts
+	return m
+}
+
+// WithDiscoveryFailure warns on the home screen that LAN tables cannot be
+// listed because listening failed with err.
+func (m Model) WithDiscoveryFailure(err error) Model {
+	m.lan.listenErr = err
+	return m
+}
+
 // Init implements tea.Model.
 func (m Model) Init() tea.Cmd {
 	cmds := []tea.Cmd{
@@ -124,8 +245,17 @@ func (m Model) Init() tea.Cmd {
 		animationTickCmd(),
 	}
 
-	if m.online && m.client != nil {
-		cmds = append(cmds, listenForMessages(m.client))
+	if m.client != nil {
+		cmds = append(cmds,
+			listenForMessages(m.client),
+			listenForConnState(m.client),
+			listenForQuality(m.client),
+			listenForResults(m.client),
+			connectCmd(m.client),
+		)
+	}
+	if m.lan.listener != nil {
+		cmds = append(cmds, lanRefreshCmd())
 	}
 
 	return tea.Batch(cmds...)
@@ -150,8 +280,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleKey(msg)
 
 	case spinner.TickMsg:
+		// The spinner only runs while waiting for a match
+		if m.screen != screenMatching {
+			return m, nil
+		}
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
+		m.matching.elapsed = time.Since(m.matching.started)
 		return m, cmd
 
 	case animationTickMsg:
@@ -160,9 +295,25 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case serverMessageMsg:
 		return m.handleServerMessage(msg)
 
+	case connStateMsg:
+		return m.handleConnState(msg)
+
+	case linkQualityMsg:
+		return m.handleLinkQuality(msg)
+
+	case actionResultMsg:
+		return m.handleActionResult(msg)
+
+	case protocolErrorMsg:
+		m = m.withStatus(statusError, fmt.Sprintf("프로토콜 오류: %v", msg.Err), 5*time.Second)
+		return m, tea.Batch(listenForMessages(m.client), m.statusCommand(5*time.Second))
+
 	case aiTurnMsg:
 		return m.handleAITurn()
 
+	case lanRefreshMsg:
+		return m.refreshLANTables()
+
 	case statusClearMsg:
 		if msg.seq == m.status.seq {
 			m.status.message = ""
@@ -187,11 +338,23 @@ func (m Model) View() string {
 		content = m.viewHome()
 	case screenGame:
 		content = m.viewOfflineGame()
+	case screenOnline:
+		content = m.viewOnlineGame()
+	case screenMatching:
+		content = m.viewMatching()
+	case screenStats:
+		content = m.viewStats()
+	case screenTables:
+		content = m.viewTables()
+	case screenSettle:
+		content = m.viewSettle()
+	case screenUpgrade:
+		content = m.viewUpgrade()
 	default:
 		content = ""
 	}
 
-	if m.modal != modalNone && m.screen != screenGame {
+	if m.modal != modalNone && m.screen != screenGame && m.screen != screenOnline {
 		return m.renderModalOverlay(content)
 	}
 
@@ -205,6 +368,20 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, tea.Quit
 	}
 
+	// Text entry takes every key, including the global shortcuts
+	if m.screen == screenOnline && m.chat.composing {
+		return m.handleChatKey(msg)
+	}
+
+	switch m.modal {
+	case modalConnect:
+		return m.handleConnectKey(msg)
+	case modalRoom:
+		return m.handleRoomKey(msg)
+	case modalDealer:
+		return m.handleDealerKey(msg)
+	}
+
 	if msg.Type == tea.KeyRunes && len(msg.Runes) == 1 {
 		switch msg.Runes[0] {
 		case '?':
@@ -230,8 +407,7 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if cmd != nil {
 			if doneMsg := cmd(); doneMsg != nil {
 				if _, ok := doneMsg.(intro.DoneMsg); ok {
-					m.screen = screenHome
-					return m, nil
+					return m.leaveIntro()
 				}
 			}
 		}
@@ -242,6 +418,24 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case screenGame:
 		return m.handleGameKey(msg)
+
+	case screenOnline:
+		return m.handleOnlineKey(msg)
+
+	case screenMatching:
+		return m.handleMatchingKey(msg)
+
+	case screenStats:
+		return m.handleStatsKey(msg)
+
+	case screenTables:
+		return m.handleTablesKey(msg)
+
+	case screenSettle:
+		return m.handleSettleKey(msg)
+
+	case screenUpgrade:
+		return m.handleUpgradeKey(msg)
 	}
 
 	return m, nil
@@ -258,20 +452,76 @@ func (m Model) handleAnimationTick() (tea.Model, tea.Cmd) {
 }
 
 func (m Model) handleServerMessage(msg serverMessageMsg) (tea.Model, tea.Cmd) {
-	// Placeholder: update status bar for now.
-	m = m.withStatus(statusInfo, fmt.Sprintf("서버 이벤트: %s", msg.Message.Type), 3*time.Second)
-
 	cmds := []tea.Cmd{
 		listenForMessages(m.client),
 	}
 
-	if cmd := m.statusCommand(3 * time.Second); cmd != nil {
+	switch msg.Message.Type {
+	case protocol.ServerRegisterSuccess:
+		// The capabilities it declared decide which entries work
+		m.home.items = m.buildHomeMenu()
+	case protocol.ServerMatchingStarted, protocol.ServerMatchingProgress,
+		protocol.ServerMatchingCompleted, protocol.ServerMatchingCancelled, protocol.ServerRoomCreated:
+		var cmd tea.Cmd
+		m, cmd = m.handleMatchingEvent(msg.Message)
 		cmds = append(cmds, cmd)
+	case protocol.ServerGameStarted, protocol.ServerGameStateUpdate, protocol.ServerTurnChanged,
+		protocol.ServerRoundProgressed, protocol.ServerPlayerAction, protocol.ServerRoundCompleted,
+		protocol.ServerGameEnded:
+		var cmd tea.Cmd
+		m, cmd = m.handleGameEvent(msg.Message)
+		cmds = append(cmds, cmd)
+	case protocol.ServerSpectateStarted, protocol.ServerSpectatorsChanged, protocol.ServerTableList:
+		var cmd tea.Cmd
+		m, cmd = m.handleSpectateEvent(msg.Message)
+		cmds = append(cmds, cmd)
+	case protocol.ServerChatMessage:
+		m = m.receiveChat(msg.Message)
+	case protocol.ServerSeatRequested, protocol.ServerHostAction, protocol.ServerHostGranted:
+		var cmd tea.Cmd
+		m, cmd = m.handleDealerEvent(msg.Message)
+		cmds = append(cmds, cmd)
+	case protocol.ServerAck:
+		// Host commands are acknowledged; their effect arrives as HOST_ACTION
+	case protocol.ServerError:
+		var cmd tea.Cmd
+		m, cmd = m.handleServerError(msg.Message)
+		cmds = append(cmds, cmd)
+	default:
+		m.table.Update(msg.Message)
+		m = m.withStatus(statusInfo, fmt.Sprintf("서버 이벤트: %s", msg.Message.Type), 3*time.Second)
+		cmds = append(cmds, m.statusCommand(3*time.Second))
 	}
 
 	return m, tea.Batch(cmds...)
 }
 
+// handleServerError reports an ERROR against whatever request is waiting.
+func (m Model) handleServerError(msg network.ServerMessage) (Model, tea.Cmd) {
+	p, ok := msg.Payload.(protocol.Error)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case p.Code == protocol.ErrorNotHost:
+		m = m.withStatus(statusError, "방장만 사용할 수 있습니다.", 4*time.Second)
+	case p.Code == protocol.ErrorHostCommand:
+		m = m.withStatus(statusError, "방장 명령 거부: "+p.Message, 4*time.Second)
+	case m.screen == screenMatching && m.matching.private:
+		return m.handleMatchingEvent(msg)
+	case m.spectate.pending:
+		m.spectate.pending = false
+		if !m.spectate.active {
+			m.room.code = ""
+		}
+		m = m.withStatus(statusError, spectateErrorText(p), 4*time.Second)
+	default:
+		m = m.withStatus(statusError, "서버 오류: "+p.Message, 4*time.Second)
+	}
+	return m, m.statusCommand(4 * time.Second)
+}
+
 func (m Model) handleAITurn() (tea.Model, tea.Cmd) {
 	if m.game.offlineGame == nil {
 		return m, nil
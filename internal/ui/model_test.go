@@ -1,24 +1,37 @@
 package ui
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gorilla/websocket"
 
 	"github.com/bunnyholes/pokerhole/client/internal/core/application/service"
 	"github.com/bunnyholes/pokerhole/client/internal/core/domain/game/vo"
+	"github.com/bunnyholes/pokerhole/client/internal/discovery"
+	"github.com/bunnyholes/pokerhole/client/internal/network"
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+	"github.com/bunnyholes/pokerhole/client/internal/scenario"
+	"github.com/bunnyholes/pokerhole/client/internal/state"
+	"github.com/bunnyholes/pokerhole/client/internal/stats"
 )
 
 func TestNewModelStartsInIntro(t *testing.T) {
-	m := NewModel(nil, false, "Tester")
+	m := NewModel(nil, "Tester")
 	if m.screen != screenIntro {
 		t.Fatalf("expected screenIntro, got %v", m.screen)
 	}
 }
 
 func TestSkipIntroMovesToHome(t *testing.T) {
-	m := NewModel(nil, false, "Tester")
+	m := NewModel(nil, "Tester")
 	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}})
 	m = updated.(Model)
 	if m.screen != screenHome {
@@ -27,7 +40,7 @@ func TestSkipIntroMovesToHome(t *testing.T) {
 }
 
 func TestStartOfflineGameFromHome(t *testing.T) {
-	m := NewModel(nil, false, "Tester")
+	m := NewModel(nil, "Tester")
 	m.screen = screenHome
 
 	updated, cmd := m.handleHomeKey(tea.KeyMsg{Type: tea.KeyEnter})
@@ -45,7 +58,7 @@ func TestStartOfflineGameFromHome(t *testing.T) {
 }
 
 func TestHelpModalLifecycle(t *testing.T) {
-	m := NewModel(nil, false, "Tester")
+	m := NewModel(nil, "Tester")
 	m.screen = screenHome
 
 	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
@@ -62,7 +75,7 @@ func TestHelpModalLifecycle(t *testing.T) {
 }
 
 func TestShowdownRestart(t *testing.T) {
-	m := NewModel(nil, false, "Tester")
+	m := NewModel(nil, "Tester")
 	session, _ := m.startOfflineSession()
 	m = session.(Model)
 
@@ -97,8 +110,164 @@ func TestShowdownRestart(t *testing.T) {
 	}
 }
 
+func TestStatsScreenShowsRecordedHand(t *testing.T) {
+	store := stats.NewStore(filepath.Join(t.TempDir(), "hands.jsonl"))
+	m := NewModel(nil, "Tester").WithHistory(store)
+	session, _ := m.startOfflineSession()
+	m = session.(Model)
+
+	m.game.offlineGame.PlayerAction(0, vo.Call, 0)
+	m.game.offlineGame.PlayerAction(1, vo.Check, 0)
+	for i := 0; i < 3; i++ {
+		m.game.offlineGame.ProgressRound()
+		m.game.offlineGame.PlayerAction(0, vo.Check, 0)
+		m.game.offlineGame.PlayerAction(1, vo.Check, 0)
+	}
+	m.game.snapshot = m.currentSnapshot()
+	m, _ = m.evaluateRoundProgress()
+	if m.modal != modalShowdown {
+		t.Fatalf("expected showdown modal, got %v", m.modal)
+	}
+
+	records, err := store.Load()
+	if err != nil || len(records) != 1 {
+		t.Fatalf("expected 1 recorded hand, got %d (err %v)", len(records), err)
+	}
+	if records[0].Position != "SB" || records[0].Pot != 40 {
+		t.Fatalf("unexpected record: %+v", records[0])
+	}
+
+	m.modal = modalNone
+	m.screen = screenHome
+	updated, _ := m.handleHomeKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1' + rune(homeActionStats)}})
+	m = updated.(Model)
+	if m.screen != screenStats {
+		t.Fatalf("expected screenStats, got %v", m.screen)
+	}
+	if m.stats.dashboard.Lifetime.Hands != 1 {
+		t.Fatalf("expected 1 hand in dashboard, got %d", m.stats.dashboard.Lifetime.Hands)
+	}
+	if view := m.View(); !strings.Contains(view, "포지션별 성적") {
+		t.Fatalf("expected position table in stats view")
+	}
+}
+
+func TestFoldedHandIsRecorded(t *testing.T) {
+	store := stats.NewStore(filepath.Join(t.TempDir(), "hands.jsonl"))
+	m := NewModel(nil, "Tester").WithHistory(store)
+	session, _ := m.startOfflineSession()
+	m = session.(Model)
+
+	updated, _ := m.performPlayerAction(vo.Fold, 0)
+	m = updated.(Model)
+	if m.modal != modalShowdown {
+		t.Fatalf("expected the fold to end the hand, got modal %v in %s", m.modal, m.game.snapshot.Round)
+	}
+
+	records, err := store.Load()
+	if err != nil || len(records) != 1 {
+		t.Fatalf("expected 1 recorded hand, got %d (err %v)", len(records), err)
+	}
+	if r := records[0]; r.Result != stats.ResultLoss || r.Net() != -10 || r.Pot != 20 || r.Mode != "practice" {
+		t.Fatalf("unexpected record: %+v", r)
+	}
+	if view := m.renderShowdownModal(); !strings.Contains(view, "폴드") {
+		t.Errorf("expected the showdown to say the hand was won by folds")
+	}
+}
+
+func TestRecordedHandsKeepSessionMode(t *testing.T) {
+	store := stats.NewStore(filepath.Join(t.TempDir(), "hands.jsonl"))
+	fold := func(m Model) {
+		t.Helper()
+		session, _ := m.WithHistory(store).startOfflineSession()
+		updated, _ := session.(Model).performPlayerAction(vo.Fold, 0)
+		if updated.(Model).modal != modalShowdown {
+			t.Fatalf("expected the fold to end the hand")
+		}
+	}
+
+	scored := NewModel(nil, "Tester").WithSeed(7).WithSessionMode(service.ModeScored)
+	fold(scored)
+
+	// Drills and replays repeat a fixed spot, so they are not results
+	session, _ := NewModel(nil, "Tester").WithSeed(7).startOfflineSession()
+	token, _ := session.(Model).game.offlineGame.ReplayToken()
+	fold(NewModel(nil, "Tester").WithReplay(token))
+
+	sc, err := scenario.Parse([]byte(`{
+		"name": "hero to act",
+		"round": "FLOP",
+		"pot": 100,
+		"board": ["2h", "9h", "Kc"],
+		"seats": [
+			{"nickname": "Hero", "chips": 500, "hole": ["Ah", "Jh"]},
+			{"nickname": "AI Player", "chips": 500}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("scenario parse failed: %v", err)
+	}
+	fold(NewModel(nil, "Tester").WithScenario(sc))
+
+	records, err := store.Load()
+	if err != nil || len(records) != 1 {
+		t.Fatalf("expected only the scored hand, got %d (err %v)", len(records), err)
+	}
+	if records[0].Mode != "scored" {
+		t.Errorf("expected mode scored, got %q", records[0].Mode)
+	}
+}
+
+func TestRenderBankrollGraph(t *testing.T) {
+	rows := renderBankrollGraph([]int{-100, 0, 100}, 5, 2)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	if []rune(rows[1])[0] != '▁' || []rune(rows[0])[2] != '█' {
+		t.Fatalf("unexpected graph rows: %q", rows)
+	}
+}
+
+func TestSeededSessionAndReplay(t *testing.T) {
+	m := NewModel(nil, "Tester").WithSeed(1234)
+	session, _ := m.startOfflineSession()
+	m = session.(Model)
+	if seed := m.game.offlineGame.Seed(); seed != 1234 {
+		t.Fatalf("expected seed 1234, got %d", seed)
+	}
+
+	updated, _ := m.performPlayerAction(vo.Call, 0)
+	m = updated.(Model)
+	token, err := m.game.offlineGame.ReplayToken()
+	if err != nil {
+		t.Fatalf("ReplayToken failed: %v", err)
+	}
+
+	replay := NewModel(nil, "Tester").WithReplay(token)
+	session, _ = replay.startOfflineSession()
+	replay = session.(Model)
+	if replay.game.offlineGame == nil {
+		t.Fatalf("replay session should be initialized")
+	}
+	if replay.currentSnapshot().Pot != m.currentSnapshot().Pot {
+		t.Fatalf("replayed pot %d, want %d", replay.currentSnapshot().Pot, m.currentSnapshot().Pot)
+	}
+	// Only the first game replays the token
+	session, _ = replay.startOfflineSession()
+	if next := session.(Model).game.offlineGame; next.IsReplay() || len(next.Events()) != 0 {
+		t.Fatalf("second game should not replay the token")
+	}
+
+	updated, _ = m.handleGameKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m = updated.(Model)
+	if m.modal != modalReplay {
+		t.Fatalf("expected replay modal, got %v", m.modal)
+	}
+}
+
 func TestStatusCommandClearsMessage(t *testing.T) {
-	m := NewModel(nil, false, "Tester")
+	m := NewModel(nil, "Tester")
 	m = m.withStatus(statusInfo, "테스트", 10*time.Millisecond)
 	if cmd := m.statusCommand(10 * time.Millisecond); cmd == nil {
 		t.Fatalf("expected non-nil command")
@@ -107,3 +276,878 @@ func TestStatusCommandClearsMessage(t *testing.T) {
 
 // ensure offline package referenced for build
 var _ = service.NewOfflineGame
+
+func TestScenarioSessionStartsAtSpot(t *testing.T) {
+	sc, err := scenario.Parse([]byte(`{
+		"name": "bot to act",
+		"round": "FLOP",
+		"pot": 100,
+		"board": ["2h", "9h", "Kc"],
+		"toAct": 1,
+		"seats": [
+			{"nickname": "Hero", "chips": 500, "hole": ["Ah", "Jh"]},
+			{"nickname": "AI Player", "chips": 500}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("scenario parse failed: %v", err)
+	}
+
+	m := NewModel(nil, "Tester").WithScenario(sc)
+	session, cmd := m.startOfflineSession()
+	m = session.(Model)
+	if cmd == nil {
+		t.Fatalf("expected command after starting scenario session")
+	}
+
+	if m.game.snapshot.Round != "FLOP" || m.game.snapshot.CurrentPlayer != 1 {
+		t.Fatalf("unexpected spot: round %s, to act %d", m.game.snapshot.Round, m.game.snapshot.CurrentPlayer)
+	}
+	if !strings.Contains(m.View(), "Drill - bot to act") {
+		t.Fatalf("expected drill title in header")
+	}
+}
+
+func TestUndoMisclickedAllIn(t *testing.T) {
+	m := NewModel(nil, "Tester").WithSeed(77)
+	session, _ := m.startOfflineSession()
+	m = session.(Model)
+	chips := m.game.snapshot.Players[0].Chips
+
+	updated, _ := m.handleGameKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	m = updated.(Model)
+	updated, _ = m.handleGameKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	m = updated.(Model)
+
+	if got := m.game.snapshot.Players[0].Chips; got != chips {
+		t.Fatalf("expected %d chips after undo, got %d", chips, got)
+	}
+	if m.game.snapshot.CurrentPlayer != 0 {
+		t.Fatalf("expected the user to act again after undo")
+	}
+
+	scored := NewModel(nil, "Tester").WithSeed(77).WithSessionMode(service.ModeScored)
+	session, _ = scored.startOfflineSession()
+	scored = session.(Model)
+	updated, _ = scored.handleGameKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	scored = updated.(Model)
+	updated, _ = scored.handleGameKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	scored = updated.(Model)
+
+	if scored.status.level != statusWarning || scored.game.snapshot.Players[0].Chips != 0 {
+		t.Fatalf("undo should be disabled in scored sessions: %+v", scored.status)
+	}
+}
+
+func TestConnectionStateTogglesOnlineMenu(t *testing.T) {
+	client := network.NewClient("ws://localhost:8080/ws/game", "uuid", "Tester")
+	m := NewModel(client, "Tester")
+	m.screen = screenHome
+	if !m.home.items[1].disabled {
+		t.Fatalf("online match should be disabled before connecting")
+	}
+
+	updated, _ := m.Update(connStateMsg{Change: network.StateChange{State: network.StateConnected}})
+	m = updated.(Model)
+	if m.home.items[1].disabled || m.status.level != statusSuccess {
+		t.Fatalf("online match should be enabled once connected: %+v", m.status)
+	}
+
+	updated, _ = m.Update(connStateMsg{Change: network.StateChange{State: network.StateReconnecting, Attempt: 1, NextRetry: time.Second}})
+	m = updated.(Model)
+	if !m.home.items[1].disabled || !strings.Contains(m.View(), "RECONNECTING") {
+		t.Fatalf("expected reconnecting badge and disabled online match")
+	}
+}
+
+func TestOutdatedClientSeesUpgradeScreen(t *testing.T) {
+	client := network.NewClient("ws://localhost:8080/ws/game", "uuid", "Tester")
+	m := NewModel(client, "Tester")
+	m.screen = screenMatching
+
+	upgrade := &network.UpgradeError{Current: protocol.Version, Required: protocol.Version + 1, Reason: "please update"}
+	updated, _ := m.Update(connStateMsg{Change: network.StateChange{State: network.StateFailed, Err: upgrade}})
+	m = updated.(Model)
+	view := m.View()
+	if m.screen != screenUpgrade || !strings.Contains(view, "업데이트") || !strings.Contains(view, fmt.Sprintf("v%d 이상", protocol.Version+1)) {
+		t.Fatalf("expected the upgrade screen, got %v:\n%s", m.screen, view)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m = updated.(Model); m.screen != screenHome {
+		t.Fatalf("expected home after the upgrade screen, got %v", m.screen)
+	}
+}
+
+func TestConnectionQualityAndTurnClock(t *testing.T) {
+	client := network.NewClient("ws://localhost:8080/ws/game", "me", "Tester")
+	m := NewModel(client, "Tester")
+	m.screen = screenHome
+	m.conn.state = network.StateConnected
+
+	measure := func(q network.LinkQuality) {
+		t.Helper()
+		updated, _ := m.Update(linkQualityMsg{Quality: q})
+		m = updated.(Model)
+	}
+
+	measure(network.LinkQuality{RTT: 40 * time.Millisecond, Offset: 2 * time.Second, Samples: 1})
+	if view := m.View(); !strings.Contains(view, "● 40ms") {
+		t.Fatalf("expected the round trip in the status bar:\n%s", view)
+	}
+	measure(network.LinkQuality{RTT: 40 * time.Millisecond, Offset: 2 * time.Second, Samples: 1, Missed: 2})
+	if m.status.level != statusWarning || !strings.Contains(m.renderLatency(), "응답 없음") {
+		t.Fatalf("expected a warning for unanswered heartbeats, got %+v", m.status)
+	}
+	measure(network.LinkQuality{RTT: 50 * time.Millisecond, Offset: 2 * time.Second, Samples: 2})
+	if m.status.level != statusInfo {
+		t.Fatalf("expected the recovery announced, got %+v", m.status)
+	}
+
+	// The server clock runs two seconds ahead; the deadline is still 20s away
+	send := func(p protocol.ServerPayload) {
+		t.Helper()
+		updated, _ := m.Update(serverMessageMsg{Message: protocol.NewServerMessage(p)})
+		m = updated.(Model)
+	}
+	send(protocol.GameStarted{GameState: protocol.GameState{
+		GameID:  "game-1",
+		Round:   "PRE_FLOP",
+		Players: []protocol.PlayerInfo{{ID: "me", Nickname: "Tester", Chips: 1000}, {ID: "p2", Nickname: "QuietFox", Chips: 1000}},
+	}})
+	deadline := time.Now().Add(22*time.Second + 400*time.Millisecond).UnixMilli()
+	send(protocol.TurnChanged{GameID: "game-1", CurrentPlayer: "me", ValidActions: []protocol.ClientMessageType{protocol.ClientFold}, Deadline: deadline})
+	if clock := m.renderTurnClock(m.table.GetSnapshot()); !strings.Contains(clock, "남은 시간 20초") {
+		t.Fatalf("expected the deadline on the local clock, got %q", clock)
+	}
+
+	updated, _ := m.Update(connStateMsg{Change: network.StateChange{State: network.StateReconnecting, Attempt: 1}})
+	if m = updated.(Model); m.conn.quality.Measured() {
+		t.Errorf("a dropped connection should forget its measurements")
+	}
+}
+
+func TestConnectModalTakesTextInput(t *testing.T) {
+	client := network.NewClient("ws://localhost:8080/ws/game", "uuid", "Tester")
+	m := NewModel(client, "Tester")
+	m.screen = screenHome
+	m.conn.state = network.StateFailed
+
+	updated, _ := m.activateMenuItem(int(homeActionConnect))
+	m = updated.(Model)
+	if m.modal != modalConnect {
+		t.Fatalf("expected connect modal, got %v", m.modal)
+	}
+
+	m.conn.input.SetValue("")
+	for _, r := range "http://h?" {
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(Model)
+	}
+	if m.modal != modalConnect || m.conn.input.Value() != "http://h?" {
+		t.Fatalf("shortcuts should be typed into the URL field, got %q in %v", m.conn.input.Value(), m.modal)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.modal != modalConnect || m.status.level != statusWarning {
+		t.Fatalf("non-websocket URL should be rejected")
+	}
+	if client.ServerURL() != "ws://localhost:8080/ws/game" {
+		t.Fatalf("server URL changed to %q", client.ServerURL())
+	}
+}
+
+func TestRejectedActionRollsBackTable(t *testing.T) {
+	m := NewModel(nil, "Tester")
+	m.table.Update(protocol.NewServerMessage(protocol.GameStateUpdate{GameState: protocol.GameState{
+		GameID:        "game-1",
+		Round:         "FLOP",
+		Pot:           40,
+		CurrentBet:    20,
+		Players:       []protocol.PlayerInfo{{ID: "me", Nickname: "Tester", Chips: 500}},
+		CurrentPlayer: "me",
+		ValidActions:  []protocol.ClientMessageType{protocol.ClientCall, protocol.ClientRaise},
+	}}))
+	m.table.Optimistic("req-1", "me", protocol.ClientRaise, 60)
+
+	rejected := &network.RequestError{RequestID: "req-1", Action: protocol.ClientRaise, Reason: "raise below minimum"}
+	updated, _ := m.Update(actionResultMsg{Result: network.Result{RequestID: "req-1", Action: protocol.ClientRaise, Amount: 60, Err: rejected}})
+	m = updated.(Model)
+
+	if snap := m.table.GetSnapshot(); snap.Pot != 40 || snap.CurrentPlayer != "me" {
+		t.Fatalf("expected raise rolled back, got %+v", snap)
+	}
+	if m.status.level != statusError || !strings.Contains(m.status.message, "레이즈 60 거부: raise below minimum") {
+		t.Fatalf("expected targeted error, got %q", m.status.message)
+	}
+}
+
+func TestOnlineGameFollowsServerState(t *testing.T) {
+	client := network.NewClient("ws://localhost:8080/ws/game", "me", "Tester")
+	m := NewModel(client, "Tester")
+	m.screen = screenHome
+
+	send := func(p protocol.ServerPayload) {
+		t.Helper()
+		updated, _ := m.Update(serverMessageMsg{Message: protocol.NewServerMessage(p)})
+		m = updated.(Model)
+	}
+
+	send(protocol.GameStarted{GameState: protocol.GameState{
+		GameID: "game-1",
+		Round:  "PRE_FLOP",
+		Pot:    30,
+		Players: []protocol.PlayerInfo{
+			{ID: "me", Nickname: "Tester", Chips: 990, Bet: 10, HoleCards: []string{"♠A", "♥A"}},
+			{ID: "p2", Nickname: "QuietFox", Chips: 980, Bet: 20},
+		},
+		CurrentBet: 20,
+	}})
+	if m.screen != screenOnline {
+		t.Fatalf("expected online screen after GAME_STARTED, got %v", m.screen)
+	}
+
+	send(protocol.TurnChanged{GameID: "game-1", CurrentPlayer: "me", ValidActions: []protocol.ClientMessageType{protocol.ClientFold, protocol.ClientCall}})
+	snap := m.table.GetSnapshot()
+	if !m.actionEnabled(snap, protocol.ClientCall) || m.actionEnabled(snap, protocol.ClientCheck) {
+		t.Fatalf("only advertised actions should be enabled: %v", snap.ValidActions)
+	}
+
+	updated, _ := m.handleOnlineKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	m = updated.(Model)
+	if m.status.level != statusWarning {
+		t.Fatalf("expected check to be refused locally, got %+v", m.status)
+	}
+
+	send(protocol.PlayerActed{GameID: "game-1", PlayerID: "p2", Action: protocol.ClientRaise, Amount: 60})
+	if !strings.Contains(m.status.message, "QuietFox: 레이즈 60") {
+		t.Fatalf("expected opponent action announced, got %q", m.status.message)
+	}
+
+	send(protocol.RoundCompleted{GameID: "game-1", Pot: 120, Winners: []protocol.Winner{{PlayerID: "me", Amount: 120}}})
+	send(protocol.GameEnded{GameID: "game-1", Reason: "player left"})
+	view := m.View()
+	if !strings.Contains(view, "[♠A]") || !strings.Contains(view, "게임 종료") {
+		t.Fatalf("expected own cards and game end in view")
+	}
+
+	updated, _ = m.handleOnlineKey(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.screen != screenSettle {
+		t.Fatalf("expected the settle-up screen after leaving an ended game, got %v", m.screen)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m = updated.(Model); m.screen != screenHome {
+		t.Fatalf("expected home after the settle-up screen, got %v", m.screen)
+	}
+}
+
+func TestOnlineTableResyncsAfterGap(t *testing.T) {
+	client := network.NewClient("ws://localhost:8080/ws/game", "me", "Tester")
+	m := NewModel(client, "Tester")
+	m.screen = screenHome
+
+	send := func(seq int64, p protocol.ServerPayload) {
+		t.Helper()
+		msg := protocol.NewServerMessage(p)
+		msg.Seq = seq
+		updated, _ := m.Update(serverMessageMsg{Message: msg})
+		m = updated.(Model)
+	}
+	table := func(round string) protocol.GameState {
+		return protocol.GameState{
+			GameID:  "game-1",
+			Round:   round,
+			Players: []protocol.PlayerInfo{{ID: "me", Nickname: "Tester", Chips: 1000}, {ID: "p2", Nickname: "QuietFox", Chips: 1000}},
+		}
+	}
+
+	send(1, protocol.GameStarted{GameState: table("PRE_FLOP")})
+	send(2, protocol.PlayerActed{GameID: "game-1", PlayerID: "p2", Action: protocol.ClientCall})
+	m.status = statusState{}
+	send(2, protocol.PlayerActed{GameID: "game-1", PlayerID: "p2", Action: protocol.ClientCall})
+	if m.status.message != "" {
+		t.Fatalf("a duplicated update should not be announced again, got %q", m.status.message)
+	}
+
+	// 3 never arrives; the table waits for a snapshot instead of guessing
+	send(4, protocol.RoundProgressed{GameID: "game-1", Round: "FLOP", CommunityCards: []string{"♠A", "♥K", "♦7"}})
+	if m.status.level != statusWarning || !m.table.Syncing() || !strings.Contains(m.View(), "동기화 중") {
+		t.Fatalf("expected the gap to be reported, got %+v", m.status)
+	}
+	if m.table.GetSnapshot().Round != "PRE_FLOP" {
+		t.Fatalf("an update past the gap was applied")
+	}
+
+	send(4, protocol.GameStateUpdate{GameState: table("FLOP")})
+	if snap := m.table.GetSnapshot(); m.table.Syncing() || snap.Round != "FLOP" || m.table.Seq() != 4 {
+		t.Fatalf("expected the snapshot to close the gap, got %s at %d", snap.Round, m.table.Seq())
+	}
+}
+
+func TestSettleUpAfterLeaving(t *testing.T) {
+	dir := t.TempDir()
+	m := NewModel(nil, "Tester").WithLedgerDir(dir)
+	m.screen = screenHome
+
+	send := func(p protocol.ServerPayload) {
+		t.Helper()
+		updated, _ := m.Update(serverMessageMsg{Message: protocol.NewServerMessage(p)})
+		m = updated.(Model)
+	}
+	players := func(me, p2, p3 int) []protocol.PlayerInfo {
+		return []protocol.PlayerInfo{
+			{ID: "me", Nickname: "Tester", Chips: me},
+			{ID: "p2", Nickname: "QuietFox", Chips: p2},
+			{ID: "p3", Nickname: "Lucky", Chips: p3},
+		}
+	}
+
+	send(protocol.GameStarted{GameState: protocol.GameState{GameID: "game-1", Players: players(1000, 1000, 1000)}})
+	send(protocol.GameStateUpdate{GameState: protocol.GameState{GameID: "game-1", Players: players(1800, 0, 1200)}})
+	send(protocol.HostActed{GameID: "game-1", Action: protocol.ClientHostAdjustStack, PlayerID: "p2", Nickname: "QuietFox", Amount: 1000, Chips: 1000, Reason: "리바이"})
+	send(protocol.GameStateUpdate{GameState: protocol.GameState{GameID: "game-1", Players: players(2100, 500, 1400)}})
+
+	updated, _ := m.handleOnlineKey(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.screen != screenSettle {
+		t.Fatalf("expected the settle-up screen, got %v", m.screen)
+	}
+	view := m.View()
+	for _, want := range []string{"정산", "QuietFox", "2회", "+1100", "-1500", "QuietFox → Tester"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("settle-up screen missing %q:\n%s", want, view)
+		}
+	}
+
+	for _, key := range []rune{'t', 'v'} {
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{key}})
+		if m = updated.(Model); m.status.level != statusSuccess {
+			t.Fatalf("export %q failed: %+v", key, m.status)
+		}
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "settle-*"))
+	if len(files) != 2 {
+		t.Fatalf("expected text and csv settlements, got %v", files)
+	}
+}
+
+func TestMatchingFlow(t *testing.T) {
+	client := network.NewClient("ws://localhost:8080/ws/game", "me", "Tester")
+	m := NewModel(client, "Tester")
+	m.screen = screenMatching
+	m.matching = matchingState{started: time.Now()}
+
+	send := func(p protocol.ServerPayload) {
+		t.Helper()
+		updated, _ := m.Update(serverMessageMsg{Message: protocol.NewServerMessage(p)})
+		m = updated.(Model)
+	}
+
+	send(protocol.MatchingStarted{RequiredPlayers: 3})
+	send(protocol.MatchingProgress{CurrentPlayers: 2, RequiredPlayers: 3})
+	if m.matching.current != 2 || !strings.Contains(m.View(), "2 / 3 명") {
+		t.Fatalf("expected queue progress 2/3, got %+v", m.matching)
+	}
+
+	send(protocol.MatchingCompleted{GameID: "game-1"})
+	if m.screen != screenOnline || m.table.GetSnapshot().GameID != "game-1" {
+		t.Fatalf("expected online screen for game-1, got %v", m.screen)
+	}
+
+	// Esc while disconnected cannot reach the server and just leaves
+	m.screen = screenMatching
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.screen != screenHome || m.status.level != statusWarning {
+		t.Fatalf("expected home with warning, got %v %+v", m.screen, m.status)
+	}
+
+	m.screen = screenMatching
+	m.conn.state = network.StateConnected
+	updated, _ = m.Update(connStateMsg{Change: network.StateChange{State: network.StateReconnecting, Attempt: 1}})
+	m = updated.(Model)
+	if m.screen != screenHome {
+		t.Fatalf("expected dropped connection to end matching, got %v", m.screen)
+	}
+}
+
+func TestPrivateRoomFlow(t *testing.T) {
+	client := network.NewClient("ws://localhost:8080/ws/game", "me", "Tester")
+	m := NewModel(client, "Tester")
+	m.screen = screenHome
+	m.conn.state = network.StateConnected
+	m.home.items = m.buildHomeMenu()
+
+	updated, _ := m.activateMenuItem(int(homeActionPrivateRoom))
+	m = updated.(Model)
+	if m.modal != modalRoom {
+		t.Fatalf("expected room modal, got %v", m.modal)
+	}
+
+	for _, r := range "k7p?" {
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(Model)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.modal != modalRoom || m.status.level != statusWarning {
+		t.Fatalf("expected malformed code to be refused in the modal, got %v %+v", m.modal, m.status)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = updated.(Model)
+	m.room.inputs[roomFieldSeats].SetValue("12")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.modal != modalRoom || !strings.Contains(m.status.message, "좌석 수") {
+		t.Fatalf("expected seat count warning, got %+v", m.status)
+	}
+
+	send := func(p protocol.ServerPayload) {
+		t.Helper()
+		updated, _ := m.Update(serverMessageMsg{Message: protocol.NewServerMessage(p)})
+		m = updated.(Model)
+	}
+
+	m = m.enterRoomLobby(6)
+	send(protocol.RoomCreated{Code: "K7PX2Q", SmallBlind: 10, BigBlind: 20, Seats: 6})
+	if view := m.View(); !strings.Contains(view, "K7PX2Q") || !strings.Contains(view, "비공개 방") {
+		t.Fatalf("expected invite code on the lobby screen")
+	}
+
+	send(protocol.MatchingCompleted{GameID: "game-1"})
+	if m.screen != screenOnline || !strings.Contains(m.View(), "방 코드 K7PX2Q") {
+		t.Fatalf("expected room code in the game header")
+	}
+
+	m = m.enterRoomLobby(0)
+	send(protocol.Error{Code: protocol.ErrorRoomFull, Message: "room is full"})
+	if m.screen != screenHome || m.status.message != "방이 가득 찼습니다." || m.room.code != "" {
+		t.Fatalf("expected full room to return home, got %v %+v", m.screen, m.status)
+	}
+}
+
+func TestSanitizeText(t *testing.T) {
+	cases := map[string]string{
+		"plain 한글":                   "plain 한글",
+		"red\x1b[31mtext\x1b[0m":     "redtext",
+		"clear\x1b[2J\x1b[H":         "clear",
+		"title\x1b]0;pwned\aafter":   "titleafter",
+		"osc\x1b]8;;http://x\x1b\\!": "osc!",
+		"c1\u009b31mcsi":             "c1csi",
+		"line\nbreak\ttab":           "line break tab",
+		"bell\a\x7f\u202eflip":       "bellflip",
+	}
+	for in, want := range cases {
+		if got := sanitizeText(in); got != want {
+			t.Errorf("sanitizeText(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestChatPane(t *testing.T) {
+	client := network.NewClient("ws://localhost:8080/ws/game", "me", "Tester")
+	m := NewModel(client, "Tester")
+	m.screen = screenOnline
+	m.table.Update(protocol.NewServerMessage(protocol.GameStarted{GameState: protocol.GameState{
+		GameID: "game-1",
+		Round:  "PRE_FLOP",
+		Players: []protocol.PlayerInfo{
+			{ID: "me", Nickname: "Tester", Chips: 1000, Status: "ACTIVE"},
+			{ID: "p2", Nickname: "Troll", Chips: 1000, Status: "ACTIVE", Position: 1},
+		},
+		CurrentPlayer: "me",
+		ValidActions:  []protocol.ClientMessageType{protocol.ClientFold, protocol.ClientCall},
+	}}))
+
+	key := func(msg tea.KeyMsg) {
+		t.Helper()
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+	typeText := func(s string) {
+		t.Helper()
+		for _, r := range s {
+			key(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+
+	updated, _ := m.Update(serverMessageMsg{Message: protocol.NewServerMessage(protocol.ChatReceived{
+		PlayerID: "p2", Nickname: "Troll", Message: "hi\x1b[2J\x1b[31m there",
+	})})
+	m = updated.(Model)
+	if m.chat.unread != 1 || m.chat.lines[0].text != "hi there" {
+		t.Fatalf("expected one sanitized unread message, got %d %+v", m.chat.unread, m.chat.lines)
+	}
+
+	key(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if !m.chat.open || m.chat.unread != 0 || !strings.Contains(m.View(), "Troll: hi there") {
+		t.Fatalf("expected open chat pane showing the message")
+	}
+
+	// Game keys are typed into the composer instead of folding
+	key(tea.KeyMsg{Type: tea.KeyEnter})
+	typeText("fold")
+	if !m.chat.composing || m.chat.input.Value() != "fold" || m.status.level == statusError {
+		t.Fatalf("expected composer to take game keys, got %q", m.chat.input.Value())
+	}
+	key(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.chat.composing || m.screen != screenOnline {
+		t.Fatalf("esc should only leave the composer")
+	}
+
+	key(tea.KeyMsg{Type: tea.KeyEnter})
+	m.chat.input.SetValue("/mute troll")
+	key(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.chat.muted["p2"] || strings.Contains(m.View(), "hi there") {
+		t.Fatalf("expected Troll's messages to be hidden after /mute")
+	}
+
+	key(tea.KeyMsg{Type: tea.KeyEnter})
+	m.chat.input.SetValue("/unmute Troll")
+	key(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.chat.muted["p2"] || !strings.Contains(m.View(), "hi there") {
+		t.Fatalf("expected Troll's messages back after /unmute")
+	}
+}
+
+func TestSpectatorMode(t *testing.T) {
+	client := network.NewClient("ws://localhost:8080/ws/game", "me", "Tester")
+	m := NewModel(client, "Tester")
+	m.screen = screenTables
+	m.spectate = spectateState{loading: true}
+
+	send := func(p protocol.ServerPayload) {
+		t.Helper()
+		updated, _ := m.Update(serverMessageMsg{Message: protocol.NewServerMessage(p)})
+		m = updated.(Model)
+	}
+
+	send(protocol.TableList{Tables: []protocol.TableInfo{
+		{GameID: "game-1", Round: "FLOP", Players: 2, Seats: 6, SmallBlind: 10, BigBlind: 20},
+		{GameID: "game-2", Round: "PRE_FLOP", Players: 3, Seats: 6, SmallBlind: 5, BigBlind: 10},
+	}})
+	if view := m.View(); !strings.Contains(view, "game-1") || !strings.Contains(view, "game-2") {
+		t.Fatalf("expected both tables in the picker")
+	}
+
+	send(protocol.SpectateStarted{GameID: "game-1", Spectators: 2})
+	if m.screen != screenOnline || !m.spectate.active {
+		t.Fatalf("expected read-only online screen, got %v", m.screen)
+	}
+
+	send(protocol.GameStateUpdate{GameState: protocol.GameState{
+		GameID: "game-1",
+		Round:  "RIVER",
+		Players: []protocol.PlayerInfo{
+			{ID: "p1", Nickname: "Alice", Chips: 900, Status: "ACTIVE", HoleCards: []string{"♣Q", "♣J"}},
+			{ID: "p2", Nickname: "Bob", Chips: 900, Status: "ACTIVE", Position: 1},
+		},
+		CurrentPlayer: "p1",
+		ValidActions:  []protocol.ClientMessageType{protocol.ClientCheck},
+	}})
+	view := m.View()
+	if strings.Contains(view, "♣Q") {
+		t.Fatalf("spectators should not see hole cards before showdown")
+	}
+	if !strings.Contains(view, "관전 중 2명") || !strings.Contains(view, "테이블 전환 1/2") || strings.Contains(view, "폴드") {
+		t.Fatalf("expected spectator header and controls instead of the action bar")
+	}
+
+	send(protocol.RoundCompleted{GameID: "game-1", Pot: 200, Winners: []protocol.Winner{{PlayerID: "p1", Amount: 200}}})
+	if !strings.Contains(m.View(), "♣Q") {
+		t.Fatalf("expected hole cards to be shown at showdown")
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.screen != screenHome || m.spectate.active {
+		t.Fatalf("expected esc to stop spectating, got %v", m.screen)
+	}
+}
+
+func TestLANHosting(t *testing.T) {
+	const upstream = "ws://127.0.0.1:1/ws/game"
+	client := network.NewClient(upstream, "host-id", "Host")
+	defer client.Close()
+	m := NewModel(client, "Host").WithLANAddr("127.0.0.1:0")
+	m.screen = screenHome
+
+	updated, _ := m.activateMenuItem(int(homeActionHostLAN))
+	m = updated.(Model)
+	if m.modal != modalRoom || m.room.mode != roomHost || !strings.Contains(m.View(), "LAN 테이블 열기") {
+		t.Fatalf("expected host settings modal, got %v mode %v", m.modal, m.room.mode)
+	}
+
+	m.room.inputs[roomFieldSeats].SetValue("3")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if !m.hosting() || m.screen != screenMatching {
+		t.Fatalf("expected hosting lobby, got %v %+v", m.screen, m.status)
+	}
+	defer m.lan.host.Close()
+	if client.ServerURL() != m.lan.host.LocalURL() {
+		t.Fatalf("client should play on its own table, got %s", client.ServerURL())
+	}
+
+	if err := client.ConnectWithTimeout(time.Second); err != nil {
+		t.Fatalf("connect to hosted table: %v", err)
+	}
+	updated, _ = m.Update(connStateMsg{Change: network.StateChange{State: network.StateConnected}})
+	m = updated.(Model)
+
+	deadline := time.After(2 * time.Second)
+	for m.room.code == "" {
+		select {
+		case msg := <-client.Receive():
+			updated, _ = m.Update(serverMessageMsg{Message: msg})
+			m = updated.(Model)
+		case <-deadline:
+			t.Fatal("hosted room was not created")
+		}
+	}
+	if view := m.View(); !strings.Contains(view, m.room.code) || !strings.Contains(view, "LAN 주소") || m.matching.required != 3 {
+		t.Fatalf("expected room code and LAN address in the lobby, required %d", m.matching.required)
+	}
+
+	m.screen = screenHome
+	if item := m.home.items[homeActionHostLAN]; item.title != "LAN 호스팅 종료" {
+		t.Fatalf("expected stop item while hosting, got %q", item.title)
+	}
+	updated, _ = m.activateMenuItem(int(homeActionHostLAN))
+	m = updated.(Model)
+	if m.hosting() || client.IsConnected() || client.ServerURL() != upstream {
+		t.Fatalf("expected hosting to stop and restore %s, got %s", upstream, client.ServerURL())
+	}
+}
+
+// pump feeds server messages to the model until done holds.
+func pump(t *testing.T, m Model, client *network.Client, what string, done func(Model) bool) Model {
+	t.Helper()
+	deadline := time.After(2 * time.Second)
+	for !done(m) {
+		select {
+		case msg := <-client.Receive():
+			updated, _ := m.Update(serverMessageMsg{Message: msg})
+			m = updated.(Model)
+		case <-deadline:
+			t.Fatalf("%s: timed out on screen %v", what, m.screen)
+		}
+	}
+	return m
+}
+
+func TestServerWithoutCapabilities(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.ReadMessage() // REGISTER
+		data, _ := protocol.EncodeServer(protocol.NewServerMessage(protocol.RegisterSuccess{UUID: "me", Nickname: "Tester", ProtocolVersion: protocol.Version}))
+		conn.WriteMessage(websocket.TextMessage, data)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	client := network.NewClient("ws"+strings.TrimPrefix(server.URL, "http"), "me", "Tester")
+	defer client.Close()
+	m := NewModel(client, "Tester")
+	m.screen = screenHome
+	if err := client.ConnectWithTimeout(time.Second); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	updated, _ := m.Update(connStateMsg{Change: network.StateChange{State: network.StateConnected}})
+	m = pump(t, updated.(Model), client, "registered", func(Model) bool { return client.ServerVersion() > 0 })
+
+	for _, item := range m.home.items {
+		switch item.action {
+		case homeActionPrivateRoom, homeActionSpectate:
+			if !item.disabled || !strings.Contains(item.disabledMsg, "지원하지 않습니다") {
+				t.Errorf("%s should be off on a server without the capability, got %+v", item.title, item)
+			}
+		case homeActionOnlineMatch:
+			if item.disabled {
+				t.Errorf("matching should stay on, got %+v", item)
+			}
+		}
+	}
+
+	m.screen = screenOnline
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	m = updated.(Model)
+	if m.chat.open || m.status.level != statusWarning || strings.Contains(m.View(), "[T]") {
+		t.Fatalf("chat should be off on a server without it, got %q", m.status.message)
+	}
+}
+
+func TestDiscoveryFailureWarns(t *testing.T) {
+	held, err := discovery.Listen("127.0.0.1:0", time.Second)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer held.Close()
+	_, err = discovery.Listen(held.Addr(), time.Second)
+	if err == nil {
+		t.Fatal("expected the second listener to fail")
+	}
+
+	m, cmd := NewModel(nil, "Hero").WithDiscoveryFailure(err).WithDiscovery(nil, discovery.AnnounceOptions{}).leaveIntro()
+	if m.screen != screenHome || m.status.level != statusWarning || !strings.Contains(m.status.message, "LAN") || cmd == nil {
+		t.Fatalf("expected a LAN warning on the home screen, got %+v", m.status)
+	}
+	if again, _ := m.withStatus(statusInfo, "", 0).leaveIntro(); again.status.message != "" {
+		t.Fatalf("the warning should show once, got %q", again.status.message)
+	}
+}
+
+func TestLANDiscoveryJoin(t *testing.T) {
+	listener, err := discovery.Listen("127.0.0.1:0", time.Second)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+	announce := discovery.AnnounceOptions{Targets: []string{listener.Addr()}, Interval: 20 * time.Millisecond}
+
+	hostClient := network.NewClient("ws://127.0.0.1:1/ws/game", "host-id", "Host")
+	defer hostClient.Close()
+	host := NewModel(hostClient, "Host").WithLANAddr("127.0.0.1:0").WithDiscovery(listener, announce)
+	host.screen = screenHome
+	updated, _ := host.activateMenuItem(int(homeActionHostLAN))
+	host = updated.(Model)
+	host.room.inputs[roomFieldSeats].SetValue("2")
+	updated, _ = host.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	host = updated.(Model)
+	if !host.hosting() {
+		t.Fatalf("expected hosting, got %+v", host.status)
+	}
+	defer host.lan.host.Close()
+	if err := hostClient.ConnectWithTimeout(time.Second); err != nil {
+		t.Fatalf("connect host: %v", err)
+	}
+	updated, _ = host.Update(connStateMsg{Change: network.StateChange{State: network.StateConnected}})
+	host = updated.(Model)
+	host = pump(t, host, hostClient, "room created", func(m Model) bool { return m.room.code != "" })
+
+	guestClient := network.NewClient("ws://127.0.0.1:1/ws/game", "guest-id", "Guest")
+	defer guestClient.Close()
+	guest := NewModel(guestClient, "Guest").WithDiscovery(listener, announce)
+	guest.screen = screenHome
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(guest.lan.tables) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("hosted table was not discovered")
+		}
+		time.Sleep(20 * time.Millisecond)
+		updated, _ = guest.Update(lanRefreshMsg{})
+		guest = updated.(Model)
+	}
+
+	idx := len(guest.home.items) - 1
+	item := guest.home.items[idx]
+	if item.action != homeActionJoinLAN || item.table.Code != host.room.code || item.table.FreeSeats != 1 {
+		t.Fatalf("expected a joinable LAN entry for %s, got %+v", host.room.code, item)
+	}
+	guest.home.selected = idx
+	if view := guest.View(); !strings.Contains(view, "근처 LAN 테이블") || !strings.Contains(view, "Host의 테이블") {
+		t.Fatalf("expected discovered table on the home screen:\n%s", view)
+	}
+
+	// The host does not list its own table
+	updated, _ = host.Update(lanRefreshMsg{})
+	if tables := updated.(Model).lan.tables; len(tables) != 0 {
+		t.Fatalf("host should skip its own announcement, got %+v", tables)
+	}
+
+	updated, _ = guest.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	guest = updated.(Model)
+	if guest.screen != screenMatching || guestClient.ServerURL() != host.lan.host.LocalURL() {
+		t.Fatalf("expected to wait for %s, got %v %s", host.lan.host.LocalURL(), guest.screen, guestClient.ServerURL())
+	}
+	if err := guestClient.ConnectWithTimeout(time.Second); err != nil {
+		t.Fatalf("connect guest: %v", err)
+	}
+	updated, _ = guest.Update(connStateMsg{Change: network.StateChange{State: network.StateConnected}})
+	guest = updated.(Model)
+	guest = pump(t, guest, guestClient, "seated at the LAN table", func(m Model) bool { return m.screen == screenOnline })
+	if guest.lan.joining != "" {
+		t.Fatalf("pending join should be cleared, got %q", guest.lan.joining)
+	}
+}
+
+func TestHostPanel(t *testing.T) {
+	client := network.NewClient("ws://127.0.0.1:1/ws/game", "host-id", "Host")
+	defer client.Close()
+	m := NewModel(client, "Host").WithLANAddr("127.0.0.1:0")
+	m.screen = screenHome
+	updated, _ := m.activateMenuItem(int(homeActionHostLAN))
+	m = updated.(Model)
+	m.room.inputs[roomFieldSeats].SetValue("2")
+	m.room.inputs[roomFieldApprove].SetValue("y")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if !m.hosting() {
+		t.Fatalf("expected hosting, got %+v", m.status)
+	}
+	defer m.lan.host.Close()
+	if err := client.ConnectWithTimeout(time.Second); err != nil {
+		t.Fatalf("connect host: %v", err)
+	}
+	updated, _ = m.Update(connStateMsg{Change: network.StateChange{State: network.StateConnected}})
+	m = updated.(Model)
+	m = pump(t, m, client, "room created", func(m Model) bool { return m.room.code != "" })
+
+	guest := network.NewClient(m.lan.host.LocalURL(), "guest-id", "Guest")
+	defer guest.Close()
+	if err := guest.ConnectWithTimeout(time.Second); err != nil {
+		t.Fatalf("connect guest: %v", err)
+	}
+	guest.JoinCodeMatch(m.room.code)
+
+	// The lobby asks the host before seating anyone
+	m = pump(t, m, client, "seat request", func(m Model) bool { return len(m.dealer.requests) == 1 })
+	if view := m.View(); !strings.Contains(view, "입장 요청") || !strings.Contains(view, "Guest") {
+		t.Fatalf("expected the seat request in the lobby:\n%s", view)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = pump(t, updated.(Model), client, "game started", func(m Model) bool { return m.screen == screenOnline && len(m.table.GetSnapshot().Players) == 2 })
+	if !m.isHost(m.table.GetSnapshot()) || !strings.Contains(m.View(), "[P]") {
+		t.Fatal("expected host tools for the room's creator")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	m = updated.(Model)
+	if m.modal != modalDealer || !strings.Contains(m.View(), "방장 도구") {
+		t.Fatalf("expected the host panel, got modal %q", m.modal)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	m = pump(t, updated.(Model), client, "paused", func(m Model) bool { return m.table.GetSnapshot().Paused })
+
+	// Stack adjustments need a reason and land in the audit list
+	m.dealer.selected = slices.IndexFunc(m.table.GetSnapshot().Players, func(p state.PlayerState) bool { return p.ID == "guest-id" })
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	m = updated.(Model)
+	m.dealer.input.SetValue("+500")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.dealer.edit != dealerEditStack || m.status.level != statusWarning {
+		t.Fatalf("adjustment without a reason should be refused, got %+v", m.status)
+	}
+	m.dealer.input.SetValue("+500 리바이")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = pump(t, updated.(Model), client, "stack adjusted", func(m Model) bool {
+		return len(m.dealer.log) > 0 && m.dealer.log[len(m.dealer.log)-1].Action == protocol.ClientHostAdjustStack
+	})
+	if view := m.View(); !strings.Contains(view, "일시정지") || !strings.Contains(view, "리바이") {
+		t.Fatalf("expected paused table and audit entry:\n%s", view)
+	}
+}
@@ -0,0 +1,469 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/bunnyholes/pokerhole/client/internal/core/domain/game/vo"
+	"github.com/bunnyholes/pokerhole/client/internal/network"
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+	"github.com/bunnyholes/pokerhole/client/internal/settle"
+	"github.com/bunnyholes/pokerhole/client/internal/state"
+)
+
+// onlineRaiseStep is how much [+]/[-] move the raise amount
+const onlineRaiseStep = 10
+
+// onlinePanelStyle drops the vertical padding so the table, seats and chat
+// fit the fixed frame
+var onlinePanelStyle = panelStyle.Copy().Padding(0, 1)
+
+// onlineState holds UI-only settings for the online table.
+type onlineState struct {
+	raiseSteps int // [+]/[-] presses on top of the suggested raise
+}
+
+// onlineActionKeys binds keys to game actions in action bar order
+var onlineActionKeys = []struct {
+	key    string
+	action protocol.ClientMessageType
+}{
+	{"f", protocol.ClientFold},
+	{"k", protocol.ClientCheck},
+	{"c", protocol.ClientCall},
+	{"r", protocol.ClientRaise},
+	{"a", protocol.ClientAllIn},
+}
+
+// onlineActionLabels names game actions in status messages
+var onlineActionLabels = map[protocol.ClientMessageType]string{
+	protocol.ClientFold:  "폴드",
+	protocol.ClientCheck: "체크",
+	protocol.ClientCall:  "콜",
+	protocol.ClientRaise: "레이즈",
+	protocol.ClientAllIn: "올인",
+}
+
+// onlineActionValues maps wire actions to domain actions
+var onlineActionValues = map[protocol.ClientMessageType]vo.PlayerAction{
+	protocol.ClientFold:  vo.Fold,
+	protocol.ClientCheck: vo.Check,
+	protocol.ClientCall:  vo.Call,
+	protocol.ClientRaise: vo.Raise,
+	protocol.ClientAllIn: vo.AllIn,
+}
+
+// sendOnlineAction sends a game action and shows it on the table right
+// away; handleActionResult rolls it back if the server refuses it.
+func (m Model) sendOnlineAction(action protocol.ClientMessageType, amount int) (Model, tea.Cmd) {
+	if m.client == nil || !m.isOnline() {
+		m = m.withStatus(statusWarning, "서버에 연결되어 있지 않습니다.", 3*time.Second)
+		return m, m.statusCommand(3 * time.Second)
+	}
+
+	id, err := m.remote.ExecuteAction(onlineActionValues[action], amount)
+	if err != nil {
+		m = m.withStatus(statusError, fmt.Sprintf("%s 전송 실패: %v", onlineActionLabels[action], err), 4*time.Second)
+		return m, m.statusCommand(4 * time.Second)
+	}
+
+	m.table.Optimistic(id, m.client.UUID(), action, amount)
+	return m, nil
+}
+
+func (m Model) handleActionResult(msg actionResultMsg) (tea.Model, tea.Cmd) {
+	res := msg.Result
+	label := onlineActionLabels[res.Action]
+	if res.Action == protocol.ClientRaise {
+		label = fmt.Sprintf("%s %d", label, res.Amount)
+	}
+
+	var rerr *network.RequestError
+	switch {
+	case res.Err == nil:
+		m.table.Confirm(res.RequestID)
+		m = m.withStatus(statusSuccess, label+" 완료", 2*time.Second)
+		return m, tea.Batch(listenForResults(m.client), m.statusCommand(2*time.Second))
+	case errors.As(res.Err, &rerr):
+		m = m.withStatus(statusError, fmt.Sprintf("%s 거부: %s", label, rerr.Reason), 5*time.Second)
+	case errors.Is(res.Err, network.ErrRequestTimeout):
+		m = m.withStatus(statusError, label+" 응답 없음 - 다시 시도하세요.", 5*time.Second)
+	case errors.Is(res.Err, network.ErrConnectionLost):
+		m = m.withStatus(statusError, label+" 전송 중 연결이 끊겼습니다.", 5*time.Second)
+	default:
+		m = m.withStatus(statusError, fmt.Sprintf("%s 실패: %v", label, res.Err), 5*time.Second)
+	}
+
+	m.table.Rollback(res.RequestID)
+	return m, tea.Batch(listenForResults(m.client), m.statusCommand(5*time.Second))
+}
+
+// applyUpdate puts a server update on the table. It reports false for
+// updates already applied or held behind a missing one; finding a gap
+// also asks the server for the whole table.
+func (m Model) applyUpdate(msg network.ServerMessage) (Model, tea.Cmd, bool) {
+	switch m.table.Update(msg) {
+	case state.Applied:
+		return m, nil, true
+	case state.Gap:
+		if m.remote != nil {
+			m.remote.SyncGameState()
+		}
+		m = m.withStatus(statusWarning, "놓친 업데이트가 있어 테이블을 다시 받는 중...", 3*time.Second)
+		return m, m.statusCommand(3 * time.Second), false
+	}
+	return m, nil, false
+}
+
+// handleGameEvent applies a game message to the table and announces it.
+func (m Model) handleGameEvent(msg network.ServerMessage) (Model, tea.Cmd) {
+	before := m.table.GetSnapshot()
+	m, cmd, applied := m.applyUpdate(msg)
+	if !applied {
+		return m, cmd
+	}
+	snap := m.table.GetSnapshot()
+
+	var notice string
+	level := statusInfo
+	switch p := msg.Payload.(type) {
+	case protocol.GameStarted:
+		m.screen = screenOnline
+		m.modal = modalNone
+		m.online = onlineState{}
+		if !m.spectate.active {
+			m.book = settle.NewBook()
+		}
+		notice = "온라인 게임이 시작되었습니다."
+	case protocol.TurnChanged:
+		if p.CurrentPlayer != before.CurrentPlayer {
+			m.online.raiseSteps = 0
+		}
+		if m.myTurn(snap) {
+			notice = "당신의 차례입니다."
+		}
+	case protocol.PlayerActed:
+		if m.client == nil || p.PlayerID != m.client.UUID() {
+			notice = fmt.Sprintf("%s: %s", nicknameOf(snap, p.PlayerID), actionText(p.Action, p.Amount))
+		}
+	case protocol.RoundCompleted:
+		var names []string
+		for _, w := range p.Winners {
+			names = append(names, fmt.Sprintf("%s +%d", nicknameOf(snap, w.PlayerID), w.Amount))
+		}
+		notice = "핸드 종료 · " + strings.Join(names, ", ")
+		level = statusSuccess
+	case protocol.GameEnded:
+		notice = "게임이 끝났습니다. [ESC] 메뉴로"
+		if p.Reason != "" {
+			notice = fmt.Sprintf("게임 종료: %s · [ESC] 메뉴로", p.Reason)
+		}
+		level = statusWarning
+	}
+	m.trackStacks(snap)
+
+	if notice == "" {
+		return m, nil
+	}
+	m = m.withStatus(level, notice, 4*time.Second)
+	return m, m.statusCommand(4 * time.Second)
+}
+
+func (m Model) handleOnlineKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.spectate.active {
+		return m.handleSpectateKey(msg)
+	}
+	snap := m.table.GetSnapshot()
+
+	switch key := msg.String(); key {
+	case "esc":
+		m.screen = screenHome
+		m.room.code = ""
+		if m.book != nil && !m.book.Empty() {
+			m = m.openSettle()
+		}
+		if !snap.Ended && m.client != nil && m.isOnline() {
+			if err := m.client.SendPayload(protocol.LeaveGame{}); err != nil {
+				m = m.withStatus(statusError, fmt.Sprintf("게임 나가기 실패: %v", err), 4*time.Second)
+				return m, m.statusCommand(4 * time.Second)
+			}
+			m = m.withStatus(statusInfo, "게임에서 나왔습니다.", 3*time.Second)
+			return m, m.statusCommand(3 * time.Second)
+		}
+		m = m.withStatus(statusInfo, "메뉴로 돌아갑니다.", 3*time.Second)
+		return m, m.statusCommand(3 * time.Second)
+	case "t", "T":
+		return m.toggleChat()
+	case "p", "P":
+		return m.openDealerPanel()
+	case "enter":
+		return m.composeChat()
+	case "pgup", "pgdown":
+		if m.chat.open {
+			return m.scrollChat(msg)
+		}
+		return m, nil
+	case "+", "=":
+		m.online.raiseSteps++
+		return m, nil
+	case "-":
+		if m.raiseAmount(snap)-onlineRaiseStep >= m.minRaise(snap) {
+			m.online.raiseSteps--
+		}
+		return m, nil
+	default:
+		for _, binding := range onlineActionKeys {
+			if key != binding.key {
+				continue
+			}
+			if !m.actionEnabled(snap, binding.action) {
+				m = m.withStatus(statusWarning, fmt.Sprintf("지금은 %s 할 수 없습니다.", onlineActionLabels[binding.action]), 2*time.Second)
+				return m, m.statusCommand(2 * time.Second)
+			}
+			amount := 0
+			if binding.action == protocol.ClientRaise {
+				amount = m.raiseAmount(snap)
+			}
+			return m.sendOnlineAction(binding.action, amount)
+		}
+	}
+
+	return m, nil
+}
+
+// myTurn reports whether the server is waiting on this client.
+func (m Model) myTurn(snap state.Snapshot) bool {
+	return m.client != nil && snap.CurrentPlayer != "" && snap.CurrentPlayer == m.client.UUID()
+}
+
+// actionEnabled reports whether the server advertised the action for us.
+func (m Model) actionEnabled(snap state.Snapshot, action protocol.ClientMessageType) bool {
+	if !m.myTurn(snap) || snap.Paused {
+		return false
+	}
+	for _, valid := range snap.ValidActions {
+		if valid == string(action) {
+			return true
+		}
+	}
+	return false
+}
+
+// me returns this client's seat, if seated.
+func (m Model) me(snap state.Snapshot) (state.PlayerState, bool) {
+	if m.client == nil {
+		return state.PlayerState{}, false
+	}
+	for _, p := range snap.Players {
+		if p.ID == m.client.UUID() {
+			return p, true
+		}
+	}
+	return state.PlayerState{}, false
+}
+
+// minRaise suggests the smallest raise: double the bet, or one step when
+// nothing has been bet.
+func (m Model) minRaise(snap state.Snapshot) int {
+	return max(snap.CurrentBet*2, onlineRaiseStep*2)
+}
+
+// raiseAmount is the raise-to total, capped at our stack.
+func (m Model) raiseAmount(snap state.Snapshot) int {
+	amount := m.minRaise(snap) + m.online.raiseSteps*onlineRaiseStep
+	if me, ok := m.me(snap); ok {
+		amount = min(amount, me.Chips+me.Bet)
+	}
+	return amount
+}
+
+func (m Model) viewOnlineGame() string {
+	snap := m.table.GetSnapshot()
+
+	header := lipgloss.JoinHorizontal(lipgloss.Top,
+		headerTitleStyle.Render("PokerHole - Online"),
+		lipgloss.NewStyle().Foreground(ColorTextSecondary).PaddingLeft(2).Render(fmt.Sprintf("라운드: %s", snap.Round)),
+		lipgloss.NewStyle().Foreground(ColorTextMuted).PaddingLeft(2).Render(truncate(snap.GameID, 12)),
+		m.renderRoomCode(),
+		m.renderSpectators(snap),
+	)
+
+	// The chat pane takes the community panel's place; the cards move to the pot line
+	top := onlinePanelStyle.Width(m.contentWidth() - onlinePanelStyle.GetHorizontalBorderSize()).Render(lipgloss.JoinVertical(lipgloss.Left,
+		headerMetaStyle.Render("Community"),
+		renderCommunityCardsCompact(snap.CommunityCards),
+	))
+	if m.chat.open {
+		top = m.renderChatPane(snap)
+	}
+
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		top,
+		m.renderOnlineTail(snap),
+	)
+
+	content := m.applyShell(body)
+	if m.modal != modalNone {
+		return m.renderModalOverlay(content)
+	}
+	return content
+}
+
+// renderOnlineTail renders everything below the community or chat pane.
+func (m Model) renderOnlineTail(snap state.Snapshot) string {
+	bar := m.renderOnlineActionBar(snap)
+	if m.spectate.active {
+		bar = m.renderSpectatorBar(snap)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		"",
+		m.renderOnlineSeats(snap),
+		"",
+		m.renderOnlinePot(snap),
+		"",
+		bar,
+	)
+}
+
+func (m Model) renderOnlineSeats(snap state.Snapshot) string {
+	if len(snap.Players) == 0 {
+		return onlinePanelStyle.Width(m.contentWidth() - onlinePanelStyle.GetHorizontalBorderSize()).Render(chatMutedStyle.Render("서버에서 테이블 정보를 기다리는 중..."))
+	}
+
+	var rows []string
+	for _, p := range snap.Players {
+		nameStyle := menuItemStyle
+		if p.ID == snap.CurrentPlayer {
+			nameStyle = menuItemSelectedStyle
+		}
+
+		name := sanitizeText(p.Nickname)
+		if m.client != nil && p.ID == m.client.UUID() {
+			name += " (나)"
+		}
+
+		parts := []string{
+			nameStyle.Render(truncate(name, 16)),
+			statusBarStyle(statusNeutral).Render(fmt.Sprintf("칩 %d", p.Chips)),
+			statusBarStyle(statusNeutral).Render(fmt.Sprintf("베팅 %d", p.Bet)),
+			renderHandCompact(parseHand(strings.Join(p.HoleCards, " ")), len(p.HoleCards) == 0 || !m.revealHoleCards(snap)),
+		}
+		switch {
+		case p.Status == "FOLDED":
+			parts = append(parts, lipgloss.NewStyle().Foreground(ColorTextMuted).PaddingLeft(1).Render("폴드"))
+		case p.LastAction != "":
+			parts = append(parts, lipgloss.NewStyle().Foreground(ColorInfo).PaddingLeft(1).Render(p.LastAction))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Left, parts...))
+	}
+
+	return onlinePanelStyle.Width(m.contentWidth() - onlinePanelStyle.GetHorizontalBorderSize()).Render(strings.Join(rows, "\n"))
+}
+
+func (m Model) renderOnlinePot(snap state.Snapshot) string {
+	var parts []string
+	if m.chat.open {
+		parts = append(parts, renderCommunityCardsCompact(snap.CommunityCards), "  ")
+	}
+	parts = append(parts,
+		statusBarStyle(statusInfo).Render(fmt.Sprintf("Pot %d", snap.Pot)),
+		"  ",
+		statusBarStyle(statusInfo).Render(fmt.Sprintf("현재 베팅 %d", snap.CurrentBet)),
+	)
+
+	switch {
+	case snap.Ended:
+		parts = append(parts, "  ", lipgloss.NewStyle().Foreground(ColorWarning).Render("게임 종료"))
+	case snap.Paused:
+		parts = append(parts, "  ", lipgloss.NewStyle().Foreground(ColorWarning).Bold(true).Render("일시정지"))
+	case len(snap.Winners) > 0:
+		var names []string
+		for _, w := range snap.Winners {
+			names = append(names, fmt.Sprintf("%s +%d", nicknameOf(snap, w.PlayerID), w.Amount))
+		}
+		parts = append(parts, "  ", lipgloss.NewStyle().Foreground(ColorAccentGreen).Render("승자: "+strings.Join(names, ", ")))
+	case m.myTurn(snap):
+		parts = append(parts, "  ", lipgloss.NewStyle().Foreground(ColorAccentGold).Bold(true).Render("당신의 차례"))
+	}
+	if clock := m.renderTurnClock(snap); clock != "" {
+		parts = append(parts, "  ", clock)
+	}
+
+	if !m.chat.open && !m.serverLacks(protocol.CapChat) {
+		parts = append(parts, "  ", m.chatHint())
+	}
+	if m.isHost(snap) {
+		parts = append(parts, "  ", helpKeyStyle.Render("[P]")+" 방장")
+	}
+	if m.table.Syncing() {
+		parts = append(parts, "  ", lipgloss.NewStyle().Foreground(ColorWarning).Render("동기화 중…"))
+	}
+
+	return onlinePanelStyle.Render(lipgloss.JoinHorizontal(lipgloss.Left, parts...))
+}
+
+// renderTurnClock counts down the server's turn deadline on this
+// client's clock, so a skewed clock does not shorten or stretch the turn.
+func (m Model) renderTurnClock(snap state.Snapshot) string {
+	if snap.TurnDeadline == 0 || snap.Ended || snap.Paused {
+		return ""
+	}
+	left := time.Until(m.conn.quality.LocalTime(snap.TurnDeadline))
+	seconds := int(max(left, 0).Round(time.Second) / time.Second)
+
+	style := lipgloss.NewStyle().Foreground(ColorTextSecondary)
+	if seconds <= 5 {
+		style = lipgloss.NewStyle().Foreground(ColorWarning).Bold(true)
+	}
+	return style.Render(fmt.Sprintf("남은 시간 %d초", seconds))
+}
+
+func (m Model) renderOnlineActionBar(snap state.Snapshot) string {
+	muted := lipgloss.NewStyle().Foreground(ColorTextMuted)
+
+	var parts []string
+	for _, binding := range onlineActionKeys {
+		label := onlineActionLabels[binding.action]
+		if binding.action == protocol.ClientRaise {
+			label = fmt.Sprintf("%s %d", label, m.raiseAmount(snap))
+		}
+		key := "[" + strings.ToUpper(binding.key) + "]"
+		part := muted.Render(key + " " + label)
+		if m.actionEnabled(snap, binding.action) {
+			part = helpKeyStyle.Render(key) + " " + label
+		}
+		if binding.action == protocol.ClientRaise {
+			part += " " + helpKeyStyle.Render("[+/-]")
+		}
+		parts = append(parts, part)
+	}
+	parts = append(parts, helpKeyStyle.Render("[ESC]")+" 나가기")
+
+	return onlinePanelStyle.Render(strings.Join(parts, "  "))
+}
+
+// nicknameOf resolves a player ID to a display name.
+func nicknameOf(snap state.Snapshot, id string) string {
+	for _, p := range snap.Players {
+		if p.ID == id {
+			return sanitizeText(p.Nickname)
+		}
+	}
+	return id
+}
+
+// actionText describes an action for the status bar.
+func actionText(action protocol.ClientMessageType, amount int) string {
+	label := onlineActionLabels[action]
+	if amount > 0 && action != protocol.ClientFold && action != protocol.ClientCheck {
+		return fmt.Sprintf("%s %d", label, amount)
+	}
+	return label
+}
@@ -0,0 +1,335 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+)
+
+// roomMode picks what the private room modal does on Enter.
+type roomMode int
+
+const (
+	roomJoin roomMode = iota
+	roomCreate
+	roomSpectate
+	roomHost // LAN hosting; opened from the home menu, not a tab
+)
+
+// Private room form fields, in roomState.inputs order.
+const (
+	roomFieldCode = iota
+	roomFieldSmallBlind
+	roomFieldBigBlind
+	roomFieldSeats
+	roomFieldApprove
+)
+
+// roomState holds the private room form and the room we are seated in.
+type roomState struct {
+	mode   roomMode
+	inputs []textinput.Model
+	focus  int
+	code   string // invite code of the current private room
+}
+
+// openRoomModal asks for an invite code or the settings of a new room.
+func (m Model) openRoomModal() (tea.Model, tea.Cmd) {
+	field := func(prompt, value string, limit int) textinput.Model {
+		input := textinput.New()
+		input.Prompt = prompt
+		input.CharLimit = limit
+		input.Width = 12
+		input.SetValue(value)
+		return input
+	}
+
+	code := field("방 코드      ", "", protocol.RoomCodeLength+2)
+	code.Placeholder = "K7PX2Q"
+
+	m.room = roomState{
+		inputs: []textinput.Model{
+			roomFieldCode:       code,
+			roomFieldSmallBlind: field("스몰 블라인드 ", "10", 6),
+			roomFieldBigBlind:   field("빅 블라인드   ", "20", 6),
+			roomFieldSeats:      field("좌석 수       ", "6", 1),
+			roomFieldApprove:    field("입장 승인 Y/N ", "N", 1),
+		},
+	}
+	m.modal = modalRoom
+	return m.focusRoomField(roomFieldCode)
+}
+
+// focusRoomField moves the cursor to one form field.
+func (m Model) focusRoomField(idx int) (Model, tea.Cmd) {
+	m.room.focus = idx
+	for i := range m.room.inputs {
+		m.room.inputs[i].Blur()
+	}
+	return m, m.room.inputs[idx].Focus()
+}
+
+func (m Model) handleRoomKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.modal = modalNone
+		return m, nil
+	case tea.KeyTab:
+		if m.room.mode == roomHost {
+			return m, nil
+		}
+		m.room.mode = (m.room.mode + 1) % (roomSpectate + 1)
+		if m.room.mode == roomCreate {
+			return m.focusRoomField(roomFieldSmallBlind)
+		}
+		return m.focusRoomField(roomFieldCode)
+	case tea.KeyUp, tea.KeyDown:
+		if m.room.mode != roomCreate && m.room.mode != roomHost {
+			return m, nil
+		}
+		next := m.room.focus + 1
+		if msg.Type == tea.KeyUp {
+			next = m.room.focus - 1
+		}
+		if next < roomFieldSmallBlind {
+			next = roomFieldApprove
+		} else if next > roomFieldApprove {
+			next = roomFieldSmallBlind
+		}
+		return m.focusRoomField(next)
+	case tea.KeyEnter:
+		switch m.room.mode {
+		case roomCreate:
+			return m.createRoom()
+		case roomSpectate:
+			return m.spectateRoom()
+		case roomHost:
+			return m.hostTable()
+		}
+		return m.joinRoom()
+	}
+
+	var cmd tea.Cmd
+	m.room.inputs[m.room.focus], cmd = m.room.inputs[m.room.focus].Update(msg)
+	return m, cmd
+}
+
+// typedRoomCode returns the normalized invite code from the form.
+func (m Model) typedRoomCode() (string, bool) {
+	code := protocol.NormalizeRoomCode(m.room.inputs[roomFieldCode].Value())
+	return code, protocol.ValidRoomCode(code)
+}
+
+// invalidRoomCode warns about a malformed invite code.
+func (m Model) invalidRoomCode() (tea.Model, tea.Cmd) {
+	m = m.withStatus(statusWarning, fmt.Sprintf("방 코드는 영문과 숫자 %d자리입니다.", protocol.RoomCodeLength), 4*time.Second)
+	return m, m.statusCommand(4 * time.Second)
+}
+
+// joinRoom sends the typed invite code and waits for the room to fill.
+func (m Model) joinRoom() (tea.Model, tea.Cmd) {
+	code, ok := m.typedRoomCode()
+	if !ok {
+		return m.invalidRoomCode()
+	}
+
+	if err := m.client.JoinCodeMatch(code); err != nil {
+		m = m.withStatus(statusError, fmt.Sprintf("방 입장 요청 실패: %v", err), 4*time.Second)
+		return m, m.statusCommand(4 * time.Second)
+	}
+
+	m.room.code = code
+	m = m.enterRoomLobby(0)
+	m = m.withStatus(statusInfo, fmt.Sprintf("방 %s에 입장을 요청했습니다.", code), 3*time.Second)
+	return m, tea.Batch(m.spinner.Tick, m.statusCommand(3*time.Second))
+}
+
+// spectateRoom watches a friend's private table by its invite code.
+func (m Model) spectateRoom() (tea.Model, tea.Cmd) {
+	code, ok := m.typedRoomCode()
+	if !ok {
+		return m.invalidRoomCode()
+	}
+
+	updated, cmd := m.watchTable(protocol.SpectateGame{Code: code})
+	if updated.spectate.pending {
+		updated.modal = modalNone
+	}
+	return updated, cmd
+}
+
+// Room settings that cannot be parsed
+var (
+	errRoomNumbers = errors.New("블라인드와 좌석 수는 숫자로 입력하세요.")
+	errRoomApprove = errors.New("입장 승인은 Y 또는 N으로 입력하세요.")
+)
+
+// roomSettings reads the room form; field is the input to fix on error.
+func (m Model) roomSettings() (req protocol.CreateRoom, field int, err error) {
+	var values [roomFieldSeats + 1]int
+	for i := roomFieldSmallBlind; i <= roomFieldSeats; i++ {
+		n, err := strconv.Atoi(strings.TrimSpace(m.room.inputs[i].Value()))
+		if err != nil {
+			return req, i, errRoomNumbers
+		}
+		values[i] = n
+	}
+
+	req = protocol.CreateRoom{
+		SmallBlind: values[roomFieldSmallBlind],
+		BigBlind:   values[roomFieldBigBlind],
+		Seats:      values[roomFieldSeats],
+	}
+	switch strings.ToUpper(strings.TrimSpace(m.room.inputs[roomFieldApprove].Value())) {
+	case "Y":
+		req.ApproveSeats = true
+	case "N", "":
+	default:
+		return req, roomFieldApprove, errRoomApprove
+	}
+	return req, m.room.focus, req.Validate()
+}
+
+// rejectRoomSettings explains a bad room setting and focuses its input.
+func (m Model) rejectRoomSettings(field int, err error) (tea.Model, tea.Cmd) {
+	m = m.withStatus(statusWarning, roomFormError(err), 4*time.Second)
+	m, cmd := m.focusRoomField(field)
+	return m, tea.Batch(cmd, m.statusCommand(4*time.Second))
+}
+
+// createRoom asks the server for a new private table.
+func (m Model) createRoom() (tea.Model, tea.Cmd) {
+	req, field, err := m.roomSettings()
+	if err != nil {
+		return m.rejectRoomSettings(field, err)
+	}
+
+	if err := m.client.SendPayload(req); err != nil {
+		m = m.withStatus(statusError, fmt.Sprintf("방 만들기 실패: %v", err), 4*time.Second)
+		return m, m.statusCommand(4 * time.Second)
+	}
+
+	m.room.code = ""
+	m = m.enterRoomLobby(req.Seats)
+	m = m.withStatus(statusInfo, "비공개 방을 만드는 중입니다.", 3*time.Second)
+	return m, tea.Batch(m.spinner.Tick, m.statusCommand(3*time.Second))
+}
+
+// enterRoomLobby waits on the matching screen until the room fills.
+func (m Model) enterRoomLobby(seats int) Model {
+	m.modal = modalNone
+	m.matching = matchingState{started: time.Now(), private: true, required: seats}
+	m.dealer = dealerState{}
+	m.screen = screenMatching
+	return m
+}
+
+// roomFormError explains which room setting the server would refuse.
+func roomFormError(err error) string {
+	var verr *protocol.ValidationError
+	if !errors.As(err, &verr) {
+		return err.Error()
+	}
+	switch verr.Field {
+	case "smallBlind":
+		return "스몰 블라인드는 0보다 커야 합니다."
+	case "bigBlind":
+		return "빅 블라인드는 스몰 블라인드보다 커야 합니다."
+	case "seats":
+		return fmt.Sprintf("좌석 수는 %d~%d 사이여야 합니다.", protocol.MinSeats, protocol.MaxSeats)
+	}
+	return err.Error()
+}
+
+// roomErrorText turns a room ERROR from the server into a user message.
+func roomErrorText(e protocol.Error) string {
+	switch e.Code {
+	case protocol.ErrorRoomNotFound:
+		return "존재하지 않는 방 코드입니다."
+	case protocol.ErrorRoomFull:
+		return "방이 가득 찼습니다."
+	case protocol.ErrorRoomExpired:
+		return "만료된 방입니다. 새 코드를 받아 주세요."
+	case protocol.ErrorSeatDenied:
+		return "방장이 입장을 거절했습니다."
+	case protocol.ErrorKicked:
+		return "방장이 방에서 내보냈습니다."
+	}
+	return "비공개 방 오류: " + e.Message
+}
+
+func (m Model) renderRoomModal() string {
+	width := minInt(64, m.contentWidth()-4)
+	if m.room.mode == roomHost {
+		return m.renderHostModal(width)
+	}
+	header := headerTitleStyle.Width(width).Render("비공개 방")
+
+	tab := func(label string, active bool) string {
+		if active {
+			return menuItemSelectedStyle.Render(label)
+		}
+		return menuItemStyle.Render(label)
+	}
+
+	lines := []string{
+		tab("코드로 참가", m.room.mode == roomJoin) + "  " + tab("방 만들기", m.room.mode == roomCreate) + "  " + tab("코드로 관전", m.room.mode == roomSpectate),
+		"",
+	}
+	switch m.room.mode {
+	case roomJoin, roomSpectate:
+		prompt := "친구에게 받은 초대 코드를 입력하세요."
+		if m.room.mode == roomSpectate {
+			prompt = "자리에 앉지 않고 친구의 테이블을 지켜봅니다."
+		}
+		lines = append(lines,
+			prompt,
+			"",
+			m.room.inputs[roomFieldCode].View(),
+		)
+	default:
+		lines = append(lines,
+			"블라인드와 좌석 수를 정하면 초대 코드를 받습니다.",
+			"",
+			m.room.inputs[roomFieldSmallBlind].View(),
+			m.room.inputs[roomFieldBigBlind].View(),
+			m.room.inputs[roomFieldSeats].View(),
+			m.room.inputs[roomFieldApprove].View(),
+		)
+	}
+	lines = append(lines, "", menuDescStyle.Render("[Enter] 확인 • [Tab] 전환 • [↑/↓] 항목 • [ESC] 취소"))
+
+	return panelEmphasisStyle.Width(width).Render(header + "\n\n" + strings.Join(lines, "\n"))
+}
+
+// renderHostModal shows the settings of a table hosted on this machine.
+func (m Model) renderHostModal(width int) string {
+	header := headerTitleStyle.Width(width).Render("LAN 테이블 열기")
+	lines := []string{
+		"이 컴퓨터에서 테이블을 열고 같은 네트워크의 친구를 초대합니다.",
+		"",
+		m.room.inputs[roomFieldSmallBlind].View(),
+		m.room.inputs[roomFieldBigBlind].View(),
+		m.room.inputs[roomFieldSeats].View(),
+		m.room.inputs[roomFieldApprove].View(),
+		"",
+		menuDescStyle.Render("[Enter] 열기 • [↑/↓] 항목 • [ESC] 취소"),
+	}
+	return panelEmphasisStyle.Width(width).Render(header + "\n\n" + strings.Join(lines, "\n"))
+}
+
+// renderRoomCode shows the invite code of the current private room.
+func (m Model) renderRoomCode() string {
+	if m.room.code == "" {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(ColorAccentGold).Bold(true).PaddingLeft(2).Render("방 코드 " + m.room.code)
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"strings"
+	"unicode"
+)
+
+// sanitizeText strips terminal escape sequences and control characters so
+// text from other players cannot move the cursor or restyle the screen.
+func sanitizeText(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\x1b':
+			i = skipEscape(runes, i+1)
+		case r == '\u009b': // 8-bit CSI
+			i = skipCSI(runes, i+1)
+		case r == '\u0090' || r == '\u009d' || r == '\u0098' || r == '\u009e' || r == '\u009f': // 8-bit DCS, OSC, SOS, PM, APC
+			i = skipString(runes, i+1)
+		case r == '\t' || r == '\n' || r == '\r':
+			b.WriteRune(' ')
+		case unicode.IsControl(r), unicode.Is(unicode.Bidi_Control, r):
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// skipEscape returns the index of the last rune of the sequence whose
+// ESC came just before runes[i].
+func skipEscape(runes []rune, i int) int {
+	if i >= len(runes) {
+		return i
+	}
+	switch runes[i] {
+	case '[':
+		return skipCSI(runes, i+1)
+	case ']', 'P', 'X', '^', '_':
+		return skipString(runes, i+1)
+	}
+	for i < len(runes) && runes[i] >= 0x20 && runes[i] <= 0x2f {
+		i++
+	}
+	return i
+}
+
+// skipCSI skips parameters and intermediates up to the final byte.
+func skipCSI(runes []rune, i int) int {
+	for i < len(runes) && (runes[i] < 0x40 || runes[i] > 0x7e) {
+		i++
+	}
+	return i
+}
+
+// skipString skips a control string up to BEL or the ESC \ terminator.
+func skipString(runes []rune, i int) int {
+	for ; i < len(runes); i++ {
+		switch {
+		case runes[i] == '\a', runes[i] == '\u009c':
+			return i
+		case runes[i] == '\x1b' && i+1 < len(runes) && runes[i+1] == '\\':
+			return i + 1
+		}
+	}
+	return i
+}
@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/bunnyholes/pokerhole/client/internal/settle"
+	"github.com/bunnyholes/pokerhole/client/internal/state"
+)
+
+// settleShown caps the rows that fit the fixed frame
+const settleShown = 8
+
+// settleState holds the settle-up screen shown after leaving a table.
+type settleState struct {
+	summary settle.Summary
+}
+
+// trackStacks feeds the table's stacks into the buy-in book; bets still
+// in front of a player count as theirs.
+func (m Model) trackStacks(snap state.Snapshot) {
+	if m.book == nil || m.spectate.active {
+		return
+	}
+	for _, p := range snap.Players {
+		m.book.Seat(p.ID, sanitizeText(p.Nickname), p.Chips+p.Bet)
+	}
+}
+
+// openSettle shows who pays whom for the table we just left.
+func (m Model) openSettle() Model {
+	m.settle = settleState{summary: m.book.Summary()}
+	m.screen = screenSettle
+	return m
+}
+
+func (m Model) handleSettleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "enter", "q":
+		m.screen = screenHome
+		return m, nil
+	case "t", "T":
+		return m.exportSettlement("txt", func(s settle.Summary) ([]byte, error) { return []byte(s.Text()), nil })
+	case "v", "V":
+		return m.exportSettlement("csv", func(s settle.Summary) ([]byte, error) {
+			var b bytes.Buffer
+			err := s.WriteCSV(&b)
+			return b.Bytes(), err
+		})
+	}
+	return m, nil
+}
+
+// exportSettlement writes the summary next to the hand ledgers.
+func (m Model) exportSettlement(ext string, render func(settle.Summary) ([]byte, error)) (tea.Model, tea.Cmd) {
+	if m.ledgerDir == "" {
+		m = m.withStatus(statusWarning, "정산 저장 위치가 설정되지 않았습니다.", 4*time.Second)
+		return m, m.statusCommand(4 * time.Second)
+	}
+
+	path := filepath.Join(m.ledgerDir, fmt.Sprintf("settle-%s.%s", m.settle.summary.Started.Format("20060102-150405"), ext))
+	data, err := render(m.settle.summary)
+	if err == nil {
+		err = os.MkdirAll(m.ledgerDir, 0o755)
+	}
+	if err == nil {
+		err = os.WriteFile(path, data, 0o644)
+	}
+	if err != nil {
+		m = m.withStatus(statusError, fmt.Sprintf("정산 저장 실패: %v", err), 4*time.Second)
+		return m, m.statusCommand(4 * time.Second)
+	}
+
+	m = m.withStatus(statusSuccess, "정산 저장: "+path, 5*time.Second)
+	return m, m.statusCommand(5 * time.Second)
+}
+
+func (m Model) viewSettle() string {
+	width := m.contentWidth()
+	s := m.settle.summary
+
+	title := headerTitleStyle.Copy().
+		Width(width).
+		Align(lipgloss.Center).
+		Render("SETTLE UP · 정산")
+
+	lines := []string{StyleBodyMuted.Render(settleRow("플레이어", "바이인", "투입", "회수", "손익"))}
+	for i, a := range s.Accounts {
+		if i == settleShown {
+			lines = append(lines, StyleBodyMuted.Render(fmt.Sprintf("외 %d명 · 전체는 저장해서 확인하세요", len(s.Accounts)-i)))
+			break
+		}
+		lines = append(lines, settleRow(
+			truncate(a.Name, 16),
+			fmt.Sprintf("%d회", a.BuyIns),
+			fmt.Sprintf("%d", a.In),
+			fmt.Sprintf("%d", a.CashOut()),
+			renderSignedChips(a.Net()),
+		))
+	}
+	players := statsPanel(width).Render(strings.Join(lines, "\n"))
+
+	lines = []string{StyleLabel.Render("송금")}
+	if len(s.Transfers) == 0 {
+		lines = append(lines, StyleBodyMuted.Render("정산할 금액이 없습니다."))
+	}
+	for i, t := range s.Transfers {
+		if i == settleShown {
+			lines = append(lines, StyleBodyMuted.Render(fmt.Sprintf("외 %d건", len(s.Transfers)-i)))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%s → %s  %s", truncate(t.From, 16), truncate(t.To, 16), statsPositiveStyle.Render(fmt.Sprintf("%d", t.Amount))))
+	}
+	if !s.Balanced() {
+		lines = append(lines, lipgloss.NewStyle().Foreground(ColorWarning).Render(
+			fmt.Sprintf("바이인 %d · 회수 %d · 핸드 도중이거나 봇 칩이 섞여 맞지 않습니다.", s.In, s.Out)))
+	}
+	transfers := statsPanel(width).Render(strings.Join(lines, "\n"))
+
+	hint := homeDetailBodyStyle.Render(strings.Join([]string{
+		helpKeyStyle.Render("[T]") + " 텍스트 저장",
+		helpKeyStyle.Render("[V]") + " CSV 저장",
+		helpKeyStyle.Render("[Esc]") + " 홈으로",
+	}, "  "))
+
+	body := lipgloss.JoinVertical(lipgloss.Left, title, players, transfers, hint)
+	return m.applyShell(body)
+}
+
+// settleRow lays out the player table by display width.
+func settleRow(name, buyIns, in, out, net string) string {
+	cell := lipgloss.NewStyle().Width(9).Align(lipgloss.Right)
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Width(18).Render(name),
+		cell.Render(buyIns),
+		cell.Render(in),
+		cell.Render(out),
+		cell.Copy().Width(10).Render(net),
+	)
+}
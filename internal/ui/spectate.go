@@ -0,0 +1,255 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/bunnyholes/pokerhole/client/internal/network"
+	"github.com/bunnyholes/pokerhole/client/internal/network/protocol"
+	"github.com/bunnyholes/pokerhole/client/internal/state"
+)
+
+// spectateState tracks watching tables without a seat.
+type spectateState struct {
+	active   bool // watching; the online screen is read-only
+	pending  bool // SPECTATE_GAME sent, waiting for SPECTATE_STARTED
+	loading  bool // LIST_TABLES sent, waiting for TABLE_LIST
+	tables   []protocol.TableInfo
+	selected int
+}
+
+// openTables asks for the watchable tables and shows the picker.
+func (m Model) openTables() (tea.Model, tea.Cmd) {
+	if err := m.client.SendPayload(protocol.ListTables{}); err != nil {
+		m = m.withStatus(statusError, fmt.Sprintf("테이블 목록 요청 실패: %v", err), 4*time.Second)
+		return m, m.statusCommand(4 * time.Second)
+	}
+	m.spectate = spectateState{loading: true}
+	m.screen = screenTables
+	return m, nil
+}
+
+// watchTable asks to watch a table by game ID or room code.
+func (m Model) watchTable(req protocol.SpectateGame) (Model, tea.Cmd) {
+	if err := m.client.SendPayload(req); err != nil {
+		m = m.withStatus(statusError, fmt.Sprintf("관전 요청 실패: %v", err), 4*time.Second)
+		return m, m.statusCommand(4 * time.Second)
+	}
+	m.spectate.pending = true
+	m.room.code = req.Code
+	m = m.withStatus(statusInfo, "관전을 요청했습니다.", 3*time.Second)
+	return m, m.statusCommand(3 * time.Second)
+}
+
+// handleSpectateEvent follows spectator bookkeeping from the server.
+func (m Model) handleSpectateEvent(msg network.ServerMessage) (Model, tea.Cmd) {
+	m, cmd, _ := m.applyUpdate(msg)
+
+	switch p := msg.Payload.(type) {
+	case protocol.TableList:
+		m.spectate.loading = false
+		m.spectate.tables = p.Tables
+		m.spectate.selected = min(m.spectate.selected, max(len(p.Tables)-1, 0))
+	case protocol.SpectateStarted:
+		m.spectate.active = true
+		m.spectate.pending = false
+		m.screen = screenOnline
+		m.modal = modalNone
+		m.online = onlineState{}
+		m.chat.lines = nil
+		m = m.refreshChat()
+		m = m.withStatus(statusSuccess, "관전을 시작했습니다.", 3*time.Second)
+		return m, m.statusCommand(3 * time.Second)
+	}
+	return m, cmd
+}
+
+// spectateErrorText explains why a table could not be watched.
+func spectateErrorText(e protocol.Error) string {
+	switch e.Code {
+	case protocol.ErrorRoomNotFound, protocol.ErrorRoomExpired:
+		return roomErrorText(e)
+	}
+	return "관전 실패: " + e.Message
+}
+
+func (m Model) handleTablesKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.screen = screenHome
+		m.spectate = spectateState{}
+		return m, nil
+	case "up", "k":
+		if m.spectate.selected > 0 {
+			m.spectate.selected--
+		}
+		return m, nil
+	case "down", "j":
+		if m.spectate.selected < len(m.spectate.tables)-1 {
+			m.spectate.selected++
+		}
+		return m, nil
+	case "r", "R":
+		return m.openTables()
+	case "enter":
+		if m.spectate.pending || len(m.spectate.tables) == 0 {
+			return m, nil
+		}
+		return m.watchTable(protocol.SpectateGame{GameID: m.spectate.tables[m.spectate.selected].GameID})
+	}
+	return m, nil
+}
+
+// handleSpectateKey replaces the betting keys while watching.
+func (m Model) handleSpectateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		return m.stopSpectating()
+	case "t", "T":
+		return m.toggleChat()
+	case "enter":
+		return m.composeChat()
+	case "pgup", "pgdown":
+		if m.chat.open {
+			return m.scrollChat(msg)
+		}
+	case "left", "right":
+		return m.switchTable(msg.String() == "right")
+	case "r", "R":
+		if err := m.client.SendPayload(protocol.ListTables{}); err == nil {
+			m.spectate.loading = true
+		}
+	}
+	return m, nil
+}
+
+// switchTable watches the previous or next table from the last list.
+func (m Model) switchTable(forward bool) (tea.Model, tea.Cmd) {
+	tables := m.spectate.tables
+	current := m.table.GetSnapshot().GameID
+
+	idx := -1
+	for i, table := range tables {
+		if table.GameID == current {
+			idx = i
+		}
+	}
+	if len(tables) == 0 || (len(tables) == 1 && idx == 0) {
+		m = m.withStatus(statusWarning, "다른 테이블이 없습니다. [R] 목록 새로고침", 3*time.Second)
+		return m, m.statusCommand(3 * time.Second)
+	}
+
+	next := 0
+	switch {
+	case idx < 0 && !forward:
+		next = len(tables) - 1
+	case idx >= 0 && forward:
+		next = (idx + 1) % len(tables)
+	case idx >= 0:
+		next = (idx - 1 + len(tables)) % len(tables)
+	}
+	m.spectate.selected = next
+	return m.watchTable(protocol.SpectateGame{GameID: tables[next].GameID})
+}
+
+// stopSpectating leaves the table and returns to the menu.
+func (m Model) stopSpectating() (tea.Model, tea.Cmd) {
+	if m.client != nil && m.isOnline() {
+		m.client.SendPayload(protocol.LeaveSpectate{})
+	}
+	m.spectate = spectateState{}
+	m.room.code = ""
+	m.screen = screenHome
+	m = m.withStatus(statusInfo, "관전을 마쳤습니다.", 3*time.Second)
+	return m, m.statusCommand(3 * time.Second)
+}
+
+// abandonSpectating leaves the table when the connection drops; the
+// server forgets spectators with the connection.
+func (m Model) abandonSpectating() Model {
+	if !m.spectate.active && m.screen != screenTables {
+		return m
+	}
+	m.spectate = spectateState{}
+	m.room.code = ""
+	m.screen = screenHome
+	return m.withStatus(statusWarning, "서버 연결이 끊겨 관전을 종료했습니다.", 4*time.Second)
+}
+
+// revealHoleCards reports whether seats show their hole cards; spectators
+// only see them once the hand reaches showdown.
+func (m Model) revealHoleCards(snap state.Snapshot) bool {
+	return !m.spectate.active || snap.Round == "SHOWDOWN" || len(snap.Winners) > 0
+}
+
+// renderSpectators labels the header with the audience size.
+func (m Model) renderSpectators(snap state.Snapshot) string {
+	style := lipgloss.NewStyle().Foreground(ColorInfo).PaddingLeft(2)
+	switch {
+	case m.spectate.active:
+		return style.Render(fmt.Sprintf("관전 중 %d명", snap.Spectators))
+	case snap.Spectators > 0:
+		return style.Render(fmt.Sprintf("관전 %d명", snap.Spectators))
+	}
+	return ""
+}
+
+// renderSpectatorBar replaces the action bar while watching.
+func (m Model) renderSpectatorBar(snap state.Snapshot) string {
+	position := ""
+	for i, table := range m.spectate.tables {
+		if table.GameID == snap.GameID {
+			position = fmt.Sprintf(" %d/%d", i+1, len(m.spectate.tables))
+		}
+	}
+
+	parts := []string{
+		helpKeyStyle.Render("[←/→]") + " 테이블 전환" + position,
+		helpKeyStyle.Render("[R]") + " 목록 갱신",
+		helpKeyStyle.Render("[ESC]") + " 관전 종료",
+	}
+	return onlinePanelStyle.Render(strings.Join(parts, "  "))
+}
+
+func (m Model) viewTables() string {
+	width := m.contentWidth()
+
+	title := headerTitleStyle.Copy().
+		Width(width).
+		Align(lipgloss.Center).
+		Render("SPECTATE · 테이블 관전")
+
+	var rows []string
+	switch {
+	case m.spectate.loading:
+		rows = append(rows, m.spinner.View()+" 테이블 목록을 불러오는 중...")
+	case len(m.spectate.tables) == 0:
+		rows = append(rows, chatMutedStyle.Render("관전할 수 있는 테이블이 없습니다."))
+	}
+	if !m.spectate.loading {
+		for i, table := range m.spectate.tables {
+			line := fmt.Sprintf("%-14s %d/%d명  관전 %d  블라인드 %d/%d  %s",
+				truncate(table.GameID, 14), table.Players, table.Seats, table.Spectators,
+				table.SmallBlind, table.BigBlind, table.Round)
+			if i == m.spectate.selected {
+				rows = append(rows, menuItemSelectedStyle.Render("▶ "+line))
+			} else {
+				rows = append(rows, menuItemStyle.Render("  "+line))
+			}
+		}
+	}
+
+	rows = append(rows, "",
+		chatMutedStyle.Render("친구의 비공개 방은 [비공개 방]에서 코드로 관전하세요."),
+		"",
+		helpKeyStyle.Render("[↑/↓]")+" 선택  "+helpKeyStyle.Render("[Enter]")+" 관전  "+
+			helpKeyStyle.Render("[R]")+" 새로고침  "+helpKeyStyle.Render("[ESC]")+" 뒤로",
+	)
+
+	panel := panelStyle.Copy().Width(width - panelStyle.GetHorizontalBorderSize()).Render(strings.Join(rows, "\n"))
+	return m.applyShell(lipgloss.JoinVertical(lipgloss.Left, title, "", panel))
+}
@@ -0,0 +1,270 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/bunnyholes/pokerhole/client/internal/stats"
+)
+
+const statsTopPots = 3
+
+var graphLevels = []rune{' ', '▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+
+var (
+	statsPanelStyle = lipgloss.NewStyle().
+			Background(ColorBgSecondary).
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(ColorBorderNormal).
+			Padding(0, 1)
+
+	statsPositiveStyle = lipgloss.NewStyle().Foreground(ColorAccentGreen).Bold(true)
+	statsNegativeStyle = lipgloss.NewStyle().Foreground(ColorAccentRed).Bold(true)
+	statsGraphStyle    = lipgloss.NewStyle().Foreground(ColorAccentGold)
+)
+
+func (m Model) openStats() (tea.Model, tea.Cmd) {
+	m.stats = statsState{}
+
+	if m.history == nil {
+		m.stats.loadErr = "전적 기록이 비활성화되어 있습니다."
+	} else if records, err := m.history.Load(); err != nil {
+		m.stats.loadErr = fmt.Sprintf("전적을 불러오지 못했습니다: %v", err)
+	} else {
+		m.stats.dashboard = stats.Summarize(records, m.sessionID, statsTopPots)
+	}
+
+	m.screen = screenStats
+	return m, nil
+}
+
+func (m Model) handleStatsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.screen = screenHome
+		return m, nil
+	case "r":
+		return m.openStats()
+	}
+	return m, nil
+}
+
+// recordCompletedHand appends the finished offline hand to the local history.
+// Scenario and replayed games repeat a fixed spot rather than play one, so
+// their hands are left out of the results.
+func (m Model) recordCompletedHand() Model {
+	if m.history == nil || m.game.offlineGame == nil {
+		return m
+	}
+	if g := m.game.offlineGame; g.IsScenario() || g.IsReplay() {
+		return m
+	}
+
+	hand, err := m.game.offlineGame.CompletedHand()
+	if err != nil || len(hand.Seats) == 0 {
+		return m
+	}
+
+	winners := 0
+	for _, seat := range hand.Seats {
+		if seat.Winner {
+			winners++
+		}
+	}
+
+	me := hand.Seats[0]
+	record := stats.HandRecord{
+		SessionID:  m.sessionID,
+		HandNumber: hand.HandNumber,
+		PlayedAt:   time.Now(),
+		Mode:       m.game.offlineGame.Mode().String(),
+		Position:   me.Position,
+		Pot:        hand.Pot,
+		StartChips: me.StartChips,
+		EndChips:   me.EndChips,
+		HandRank:   me.HandRank,
+		Result:     stats.ResultFor(me.Net(), me.Winner, winners),
+	}
+
+	if err := m.history.Append(record); err != nil {
+		m.status = statusState{message: fmt.Sprintf("전적 저장 실패: %v", err), level: statusWarning, seq: m.status.seq + 1}
+	}
+	return m
+}
+
+func (m Model) viewStats() string {
+	width := m.contentWidth()
+	d := m.stats.dashboard
+
+	title := headerTitleStyle.Copy().
+		Width(width).
+		Align(lipgloss.Center).
+		Render("STATISTICS · 전적 통계")
+
+	if m.stats.loadErr != "" || d.Lifetime.Hands == 0 {
+		message := m.stats.loadErr
+		if message == "" {
+			message = "아직 기록된 핸드가 없습니다. 오프라인 연습전을 플레이해 보세요."
+		}
+		body := lipgloss.JoinVertical(lipgloss.Left,
+			title,
+			statsPanel(width).Render(homeDetailBodyStyle.Render(message)),
+			homeHintStyle.Render(helpKeyStyle.Render("[Esc]")+homeDetailBodyStyle.Render(" 뒤로")),
+		)
+		return m.applyShell(body)
+	}
+
+	half := (width - 2) / 2
+	totals := lipgloss.JoinHorizontal(lipgloss.Top,
+		renderTotalsPanel("통산", d.Lifetime, half),
+		"  ",
+		renderTotalsPanel("이번 세션", d.Session, width-half-2),
+	)
+
+	frame, _ := statsPanelStyle.GetFrameSize()
+	graph := statsPanel(width).Render(lipgloss.JoinVertical(lipgloss.Left,
+		StyleLabel.Render(fmt.Sprintf("뱅크롤 추이 (최근 %d핸드)", minInt(len(d.Bankroll), width-frame))),
+		statsGraphStyle.Render(strings.Join(renderBankrollGraph(d.Bankroll, width-frame, 4), "\n")),
+	))
+
+	bottom := lipgloss.JoinHorizontal(lipgloss.Top,
+		renderBiggestPots(d, half),
+		"  ",
+		renderPositionTable(d.ByPosition, width-half-2),
+	)
+
+	hint := homeDetailBodyStyle.Render(strings.Join([]string{
+		helpKeyStyle.Render("[R]") + " 새로고침",
+		helpKeyStyle.Render("[Esc]") + " 뒤로",
+	}, "  "))
+
+	body := lipgloss.JoinVertical(lipgloss.Left, title, totals, graph, bottom, hint)
+	return m.applyShell(body)
+}
+
+// statsPanel returns the panel style sized so the rendered box, borders
+// included, is exactly width cells wide.
+func statsPanel(width int) lipgloss.Style {
+	return statsPanelStyle.Copy().Width(width - statsPanelStyle.GetHorizontalBorderSize())
+}
+
+func renderTotalsPanel(label string, t stats.Totals, width int) string {
+	lines := []string{
+		StyleLabel.Render(label),
+		fmt.Sprintf("%d핸드  %d승 %d패 %d무  승률 %.0f%%", t.Hands, t.Wins, t.Losses, t.Splits, t.WinRate()*100),
+		"손익 " + renderSignedChips(t.Net),
+	}
+	return statsPanel(width).Render(strings.Join(lines, "\n"))
+}
+
+func renderBiggestPots(d stats.Dashboard, width int) string {
+	lines := []string{StyleLabel.Render("최대 획득 팟")}
+	lines = append(lines, potLines(d.BiggestWon)...)
+	lines = append(lines, StyleLabel.Render("최대 손실 팟"))
+	lines = append(lines, potLines(d.BiggestLost)...)
+	return statsPanel(width).Render(strings.Join(lines, "\n"))
+}
+
+func potLines(records []stats.HandRecord) []string {
+	if len(records) == 0 {
+		return []string{StyleBodyMuted.Render("  기록 없음")}
+	}
+
+	lines := make([]string, 0, len(records))
+	for _, r := range records {
+		lines = append(lines, fmt.Sprintf("  #%-3d %-3s 팟 %-5d %s", r.HandNumber, r.Position, r.Pot, renderSignedChips(r.Net())))
+	}
+	return lines
+}
+
+func renderPositionTable(rows []stats.PositionStats, width int) string {
+	lines := []string{
+		StyleLabel.Render("포지션별 성적"),
+		StyleBodyMuted.Render(tableRow("POS", "핸드", "승률", "손익")),
+	}
+	for _, row := range rows {
+		lines = append(lines, tableRow(
+			row.Position,
+			fmt.Sprintf("%d", row.Hands),
+			fmt.Sprintf("%.0f%%", row.WinRate()*100),
+			renderSignedChips(row.Net),
+		))
+	}
+	return statsPanel(width).Render(strings.Join(lines, "\n"))
+}
+
+// tableRow lays out position table cells using display width, so Korean
+// headers line up with numeric rows.
+func tableRow(position, hands, winRate, net string) string {
+	cell := lipgloss.NewStyle().Width(7).Align(lipgloss.Right)
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Width(5).Render(position),
+		cell.Render(hands),
+		cell.Render(winRate),
+		cell.Copy().Width(9).Render(net),
+	)
+}
+
+func renderSignedChips(n int) string {
+	switch {
+	case n > 0:
+		return statsPositiveStyle.Render(fmt.Sprintf("+%d", n))
+	case n < 0:
+		return statsNegativeStyle.Render(fmt.Sprintf("%d", n))
+	default:
+		return StyleBodyMuted.Render("0")
+	}
+}
+
+// renderBankrollGraph draws the most recent values as a block-character area
+// chart, returning one string per row from top to bottom.
+func renderBankrollGraph(values []int, width, height int) []string {
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+
+	lo, hi := 0, 0
+	for _, v := range values {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+	span := hi - lo
+	steps := height * (len(graphLevels) - 1)
+
+	rows := make([][]rune, height)
+	for i := range rows {
+		rows[i] = []rune(strings.Repeat(" ", width))
+	}
+
+	for col, v := range values {
+		filled := steps
+		if span > 0 {
+			filled = (v - lo) * steps / span
+		}
+		if filled == 0 {
+			filled = 1
+		}
+		for row := height - 1; row >= 0 && filled > 0; row-- {
+			level := minInt(filled, len(graphLevels)-1)
+			rows[row][col] = graphLevels[level]
+			filled -= level
+		}
+	}
+
+	out := make([]string, height)
+	for i, row := range rows {
+		out[i] = string(row)
+	}
+	return out
+}
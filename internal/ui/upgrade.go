@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/bunnyholes/pokerhole/client/internal/network"
+)
+
+// upgradeState explains why the server refused this client.
+type upgradeState struct {
+	err *network.UpgradeError
+}
+
+// openUpgrade replaces whatever online screen was showing with the
+// upgrade notice; a half-working game is worse than none.
+func (m Model) openUpgrade(err *network.UpgradeError) Model {
+	m = m.abandonMatching()
+	m = m.abandonSpectating()
+	m.upgrade = upgradeState{err: err}
+	m.screen = screenUpgrade
+	m.modal = modalNone
+	return m
+}
+
+func (m Model) handleUpgradeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "enter":
+		m.screen = screenHome
+	}
+	return m, nil
+}
+
+func (m Model) viewUpgrade() string {
+	width := m.contentWidth()
+	err := m.upgrade.err
+
+	title := headerTitleStyle.Copy().
+		Width(width).
+		Align(lipgloss.Center).
+		Render("UPDATE REQUIRED · 업데이트 필요")
+
+	required := "더 새로운 버전"
+	if err.Required > 0 {
+		required = fmt.Sprintf("v%d 이상", err.Required)
+	}
+	lines := []string{
+		lipgloss.NewStyle().Foreground(ColorWarning).Bold(true).Render("이 서버는 더 새로운 PokerHole 클라이언트만 받습니다."),
+		"",
+		StyleLabel.Render("서버 요구 프로토콜  ") + required,
+		StyleLabel.Render("현재 클라이언트    ") + fmt.Sprintf("v%d", err.Current),
+	}
+	if err.Reason != "" {
+		lines = append(lines, StyleLabel.Render("서버 메시지        ")+truncate(sanitizeText(err.Reason), width-24))
+	}
+	lines = append(lines,
+		"",
+		"최신 버전으로 업데이트한 뒤 다시 접속하세요.",
+		StyleBodyMuted.Render("오프라인 연습전과 LAN 테이블은 계속 이용할 수 있습니다."),
+	)
+	panel := statsPanel(width).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	hint := homeHintStyle.Render(helpKeyStyle.Render("[Enter]") + homeDetailBodyStyle.Render(" 홈으로"))
+	return m.applyShell(lipgloss.JoinVertical(lipgloss.Left, title, panel, hint))
+}